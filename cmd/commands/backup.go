@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/khulnasoft/inngest/cmd/commands/internal/localconfig"
+	"github.com/khulnasoft/inngest/pkg/cqrs/base_cqrs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewCmdExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export an `inngest start` server's data to an archive.",
+		Long: `Export an ` + "`inngest start`" + ` server's data to a single archive, including apps,
+functions, events, run history, traces, cancellations and the queue and run
+state snapshot.
+
+Stop the server before exporting so that its queue and run state are
+snapshotted to the database and included in the archive.`,
+		Example: `  inngest export -o backup.tar.gz
+  inngest export --postgres-uri postgres://localhost/inngest -o - > backup.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: doExport,
+	}
+	addPersistenceFlags(cmd)
+	cmd.Flags().StringP("output", "o", "", "Path to write the archive to, or - for stdout (default \"inngest-export-<timestamp>.tar.gz\")")
+	return cmd
+}
+
+func NewCmdImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <archive>",
+		Short: "Import an archive created by `inngest export` into an empty server.",
+		Long: `Import an archive created by ` + "`inngest export`" + ` into an empty server's database.
+
+The server must be stopped while importing.  Queued runs resume the next time
+the server is started.`,
+		Example: `  inngest import backup.tar.gz
+  cat backup.tar.gz | inngest import -`,
+		Args: cobra.ExactArgs(1),
+		RunE: doImport,
+	}
+	addPersistenceFlags(cmd)
+	return cmd
+}
+
+// addPersistenceFlags adds the database flags used by `inngest start`.
+func addPersistenceFlags(cmd *cobra.Command) {
+	cmd.Flags().String("config", "", "Path to an Inngest configuration file")
+	cmd.Flags().String("sqlite-dir", "", "Directory of the server's SQLite database.")
+	cmd.Flags().String("postgres-uri", "", "PostgreSQL database URI of the server's database.")
+}
+
+// persistenceOpts returns the options for opening the server's database, along with
+// the database's driver.
+func persistenceOpts(cmd *cobra.Command) (*base_cqrs.BaseCQRSOptions, string, error) {
+	if err := localconfig.InitPersistenceConfig(cmd.Context(), cmd); err != nil {
+		return nil, "", err
+	}
+
+	opts := &base_cqrs.BaseCQRSOptions{
+		PostgresURI: viper.GetString("postgres-uri"),
+		Directory:   viper.GetString("sqlite-dir"),
+	}
+	driver := "sqlite"
+	if opts.PostgresURI != "" {
+		driver = "postgres"
+	}
+	return opts, driver, nil
+}
+
+func doExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	opts, driver, err := persistenceOpts(cmd)
+	if err != nil {
+		return err
+	}
+	db, err := base_cqrs.New(*opts)
+	if err != nil {
+		return fmt.Errorf("error opening database: %w", err)
+	}
+	defer db.Close()
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		output = fmt.Sprintf("inngest-export-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	}
+
+	if output == "-" {
+		manifest, err := base_cqrs.Export(ctx, db, driver, os.Stdout)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d rows from %d tables\n", backupRows(manifest), len(manifest.Tables))
+		return nil
+	}
+
+	f, err := os.OpenFile(output, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error creating archive: %w", err)
+	}
+	manifest, err := base_cqrs.Export(ctx, db, driver, f)
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(output)
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d rows from %d tables to %s\n", backupRows(manifest), len(manifest.Tables), output)
+	return nil
+}
+
+func doImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	opts, driver, err := persistenceOpts(cmd)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("error opening archive: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	db, err := base_cqrs.New(*opts)
+	if err != nil {
+		return fmt.Errorf("error opening database: %w", err)
+	}
+	defer db.Close()
+
+	manifest, err := base_cqrs.Import(ctx, db, driver, r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Imported %d rows into %d tables from an export created at %s\n", backupRows(manifest), len(manifest.Tables), manifest.CreatedAt.Format(time.RFC3339))
+	return nil
+}
+
+// backupRows returns the total number of rows in an archive.  Summaries are printed
+// to stderr, leaving stdout free for archives.
+func backupRows(m *base_cqrs.BackupManifest) int {
+	rows := 0
+	for _, n := range m.Tables {
+		rows += n
+	}
+	return rows
+}
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/khulnasoft/inngest/cmd/commands/internal/apiclient"
+	"github.com/spf13/cobra"
+)
+
+// addAPIClientFlags adds the flags used to connect to a running server.
+func addAPIClientFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("api-host", apiclient.DefaultHost, "Inngest server URL")
+	cmd.PersistentFlags().String("signing-key", os.Getenv("INNGEST_SIGNING_KEY"), "Signing key used to authenticate with the server")
+}
+
+// apiClient returns a client for the server given by the command's flags.
+func apiClient(cmd *cobra.Command) *apiclient.Client {
+	host, _ := cmd.Flags().GetString("api-host")
+	key, _ := cmd.Flags().GetString("signing-key")
+	return apiclient.New(host, key)
+}
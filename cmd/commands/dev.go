@@ -12,6 +12,7 @@ import (
 	"github.com/khulnasoft/inngest/cmd/commands/internal/localconfig"
 	"github.com/khulnasoft/inngest/pkg/config"
 	"github.com/khulnasoft/inngest/pkg/devserver"
+	"github.com/khulnasoft/inngest/pkg/eventdedupe"
 	"github.com/khulnasoft/inngest/pkg/headers"
 	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
 	"github.com/spf13/cobra"
@@ -46,6 +47,11 @@ func NewCmdDev(rootCmd *cobra.Command) *cobra.Command {
 	advancedFlags.Int("queue-workers", devserver.DefaultQueueWorkers, "Number of executor workers to execute steps from the queue")
 	advancedFlags.Int("tick", devserver.DefaultTick, "The interval (in milliseconds) at which the executor polls the queue")
 	advancedFlags.Int("connect-gateway-port", devserver.DefaultConnectGatewayPort, "Port to expose connect gateway endpoint")
+	advancedFlags.Duration("event-dedupe-window", eventdedupe.DefaultWindow, "Window in which events sent with the same ID are dropped as duplicates, or 0 to disable")
+	advancedFlags.StringSlice("lifecycle-sink", []string{}, "URI of a webhook (https://), NATS (nats://host/subject) or Kafka (kafka://broker/topic) sink to export run lifecycle events to")
+	advancedFlags.String("lifecycle-sink-signing-key", "", "Key used to sign requests to lifecycle webhook sinks, in the Standard Webhooks whsec_ format")
+	advancedFlags.Int64("ai-token-budget", 0, "Maximum AI gateway tokens each environment may use per budget period. 0 means unlimited")
+	advancedFlags.Duration("ai-token-budget-period", 24*time.Hour, "Period after which the AI token budget resets")
 	cmd.Flags().AddFlagSet(advancedFlags)
 	groups = append(groups, FlagGroup{name: "Advanced Flags:", fs: advancedFlags})
 
@@ -151,6 +157,12 @@ func doDev(cmd *cobra.Command, args []string) {
 		Tick:               time.Duration(tick) * time.Millisecond,
 		URLs:               urls,
 		ConnectGatewayPort: connectGatewayPort,
+		EventDedupeWindow:  viper.GetDuration("event-dedupe-window"),
+
+		LifecycleSinks:          viper.GetStringSlice("lifecycle-sink"),
+		LifecycleSinkSigningKey: viper.GetString("lifecycle-sink-signing-key"),
+		AITokenBudget:           viper.GetInt64("ai-token-budget"),
+		AITokenBudgetPeriod:     viper.GetDuration("ai-token-budget-period"),
 	}
 
 	err = devserver.New(ctx, opts)
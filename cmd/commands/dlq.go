@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/khulnasoft/inngest/cmd/commands/internal/table"
+	"github.com/khulnasoft/inngest/pkg/api/apiv1"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDLQ() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dlq",
+		Short:   "Inspect, redrive and purge permanently failed runs.",
+		Example: "inngest dlq ls --app-id my-app --function-id charge-card",
+	}
+	addAPIClientFlags(cmd)
+
+	ls := &cobra.Command{
+		Use:   "ls",
+		Short: "List failed runs, newest first.",
+		Args:  cobra.NoArgs,
+		RunE:  doDLQList,
+	}
+	ls.Flags().String("app-id", "", "Filter to functions in the given app")
+	ls.Flags().String("function-id", "", "Filter to the given function")
+	ls.Flags().Int("limit", apiv1.DefaultDeadLetters, "Maximum number of failed runs to list")
+	ls.Flags().String("cursor", "", "List failed runs before the given run ID")
+
+	get := &cobra.Command{
+		Use:   "get <run-id>",
+		Short: "Show a failed run's events, error and failing step.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  doDLQGet,
+	}
+
+	redrive := &cobra.Command{
+		Use:   "redrive <run-id>...",
+		Short: "Re-run failed runs from their failing step.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  doDLQRedrive,
+	}
+
+	purge := &cobra.Command{
+		Use:   "purge [run-id]...",
+		Short: "Delete failed runs from the dead-letter queue.",
+		RunE:  doDLQPurge,
+	}
+	purge.Flags().String("app-id", "", "Purge failed runs of functions in the given app")
+	purge.Flags().String("function-id", "", "Purge failed runs of the given function")
+	purge.Flags().Duration("older-than", 0, "Purge failed runs older than the given duration, eg. 72h")
+	purge.Flags().Bool("all", false, "Purge all failed runs matching the filters")
+
+	cmd.AddCommand(ls, get, redrive, purge)
+	return cmd
+}
+
+func doDLQList(cmd *cobra.Command, args []string) error {
+	q := url.Values{}
+	for _, flag := range []string{"app-id", "function-id", "cursor"} {
+		if v, _ := cmd.Flags().GetString(flag); v != "" {
+			q.Set(flagToParam(flag), v)
+		}
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	q.Set("limit", strconv.Itoa(limit))
+
+	dls := []cqrs.DeadLetter{}
+	if err := apiClient(cmd).Do(cmd.Context(), http.MethodGet, "/v1/dlq", q, nil, &dls); err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(dls)
+	}
+	if len(dls) == 0 {
+		fmt.Println("No failed runs found")
+		return nil
+	}
+
+	t := table.New(table.Row{"Run ID", "Function", "Failed at", "Failed step", "Error", "Redriven as"})
+	for _, dl := range dls {
+		step := ""
+		if dl.LastStepName != nil {
+			step = *dl.LastStepName
+		} else if dl.LastStepID != nil {
+			step = *dl.LastStepID
+		}
+		redrive := ""
+		if dl.RedriveRunID != nil {
+			redrive = dl.RedriveRunID.String()
+		}
+		t.AppendRow(table.Row{
+			dl.RunID.String(),
+			dl.FunctionSlug,
+			dl.FailedAt.Format(time.RFC3339),
+			step,
+			errorMessage(dl.Error),
+			redrive,
+		})
+	}
+	t.Render()
+	return nil
+}
+
+func doDLQGet(cmd *cobra.Command, args []string) error {
+	dl := cqrs.DeadLetter{}
+	if err := apiClient(cmd).Do(cmd.Context(), http.MethodGet, "/v1/dlq/"+url.PathEscape(args[0]), nil, nil, &dl); err != nil {
+		return err
+	}
+	return printJSON(dl)
+}
+
+func doDLQRedrive(cmd *cobra.Command, args []string) error {
+	c := apiClient(cmd)
+	for _, id := range args {
+		res := apiv1.RedriveDeadLetterResponse{}
+		if err := c.Do(cmd.Context(), http.MethodPost, "/v1/dlq/"+url.PathEscape(id)+"/redrive", nil, nil, &res); err != nil {
+			return fmt.Errorf("error redriving %s: %w", id, err)
+		}
+		if jsonOutput() {
+			if err := printJSON(res); err != nil {
+				return err
+			}
+			continue
+		}
+		if res.FromStepID != nil {
+			fmt.Printf("Redrove %s as %s from step %s\n", res.OriginalRunID, res.RunID, *res.FromStepID)
+		} else {
+			fmt.Printf("Redrove %s as %s\n", res.OriginalRunID, res.RunID)
+		}
+	}
+	return nil
+}
+
+func doDLQPurge(cmd *cobra.Command, args []string) error {
+	c := apiClient(cmd)
+	res := apiv1.PurgeDeadLettersResponse{}
+
+	if len(args) > 0 {
+		for _, id := range args {
+			deleted := apiv1.PurgeDeadLettersResponse{}
+			if err := c.Do(cmd.Context(), http.MethodDelete, "/v1/dlq/"+url.PathEscape(id), nil, nil, &deleted); err != nil {
+				return fmt.Errorf("error purging %s: %w", id, err)
+			}
+			res.Deleted += deleted.Deleted
+		}
+	} else {
+		q := url.Values{}
+		for _, flag := range []string{"app-id", "function-id"} {
+			if v, _ := cmd.Flags().GetString(flag); v != "" {
+				q.Set(flagToParam(flag), v)
+			}
+		}
+		if older, _ := cmd.Flags().GetDuration("older-than"); older > 0 {
+			q.Set("before", time.Now().Add(-older).Format(time.RFC3339))
+		}
+		if all, _ := cmd.Flags().GetBool("all"); !all && len(q) == 0 {
+			return fmt.Errorf("specify run IDs, filters, or --all to purge failed runs")
+		}
+		if err := c.Do(cmd.Context(), http.MethodDelete, "/v1/dlq", q, nil, &res); err != nil {
+			return err
+		}
+	}
+
+	if jsonOutput() {
+		return printJSON(res)
+	}
+	fmt.Printf("Purged %d failed runs\n", res.Deleted)
+	return nil
+}
+
+// errorMessage returns the message of a run's final error.
+func errorMessage(byt json.RawMessage) string {
+	e := struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	}{}
+	if err := json.Unmarshal(byt, &e); err != nil || e.Message == "" {
+		return string(byt)
+	}
+	if e.Name != "" {
+		return e.Name + ": " + e.Message
+	}
+	return e.Message
+}
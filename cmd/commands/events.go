@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/khulnasoft/inngest/cmd/commands/internal/table"
+	"github.com/khulnasoft/inngest/pkg/api/apiv1"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdEvents() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Work with stored events.",
+	}
+	addAPIClientFlags(cmd)
+
+	replay := &cobra.Command{
+		Use:   "replay",
+		Short: "Re-deliver stored events to the functions whose triggers match them.",
+		Long: `Re-deliver stored events to the functions whose triggers currently match them,
+eg. to backfill a newly written function from historical events.  Events are
+replayed oldest first.  Functions which already ran for an event within their
+idempotency period are not run again.`,
+		Example: `  inngest events replay --name user/signup --since 72h --dry-run
+  inngest events replay --name order/created --since 24h --if 'event.data.total > 100' \
+    --app-id my-app --function-id my-app-send-receipt`,
+		Args: cobra.NoArgs,
+		RunE: doEventsReplay,
+	}
+	replay.Flags().String("name", "", "Only replay events with the given name")
+	replay.Flags().Duration("since", 0, "Replay events received within the given duration, eg. 24h")
+	replay.Flags().String("from", "", "Replay events received after the given RFC3339 time")
+	replay.Flags().String("until", "", "Replay events received before the given RFC3339 time")
+	replay.Flags().String("if", "", "Only replay events matching the given CEL expression")
+	replay.Flags().String("app-id", "", "The app defining the function to replay events to")
+	replay.Flags().String("function-id", "", "Only replay events to the given function")
+	replay.Flags().Bool("dry-run", false, "Count matching events and functions without running them")
+
+	cmd.AddCommand(replay)
+	return cmd
+}
+
+func doEventsReplay(cmd *cobra.Command, args []string) error {
+	body := apiv1.ReplayEventsBody{}
+	body.Name, _ = cmd.Flags().GetString("name")
+	body.AppID, _ = cmd.Flags().GetString("app-id")
+	body.FunctionID, _ = cmd.Flags().GetString("function-id")
+	body.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	if expr, _ := cmd.Flags().GetString("if"); expr != "" {
+		body.If = &expr
+	}
+
+	for flag, t := range map[string]*time.Time{"from": &body.From, "until": &body.Until} {
+		v, _ := cmd.Flags().GetString(flag)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", flag, err)
+		}
+		*t = parsed
+	}
+	if since, _ := cmd.Flags().GetDuration("since"); since > 0 {
+		body.From = time.Now().Add(-since)
+	}
+	if body.From.IsZero() {
+		return fmt.Errorf("specify --since or --from to select events to replay")
+	}
+
+	res := apiv1.ReplayEventsResponse{}
+	if err := apiClient(cmd).Do(cmd.Context(), http.MethodPost, "/v1/events/replay", nil, body, &res); err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(res)
+	}
+
+	if res.DryRun {
+		fmt.Printf("%d events match %d function triggers (dry run)\n", res.Events, res.Triggers)
+	} else {
+		fmt.Printf("Replayed %d events matching %d function triggers, scheduling %d runs\n", res.Events, res.Triggers, res.Runs)
+	}
+	if len(res.Functions) > 0 {
+		slugs := make([]string, 0, len(res.Functions))
+		for slug := range res.Functions {
+			slugs = append(slugs, slug)
+		}
+		sort.Strings(slugs)
+
+		t := table.New(table.Row{"Function", "Events"})
+		for _, slug := range slugs {
+			t.AppendRow(table.Row{slug, res.Functions[slug]})
+		}
+		t.Render()
+	}
+	for _, e := range res.Errors {
+		fmt.Printf("Error: %s\n", e)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultHost = "http://localhost:8288"
+
+// Client makes requests to the REST API of a running Inngest server.
+type Client struct {
+	host       string
+	signingKey string
+	http       *http.Client
+}
+
+func New(host, signingKey string) *Client {
+	if host == "" {
+		host = DefaultHost
+	}
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	return &Client{
+		host:       strings.TrimSuffix(host, "/"),
+		signingKey: signingKey,
+		http:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Do makes a request to the given API path, unmarshalling the response's data into out.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+
+	var r io.Reader
+	if body != nil {
+		byt, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(byt)
+	}
+
+	byt, err := c.do(ctx, method, path, r)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+	wrapper := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(byt, &wrapper); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return json.Unmarshal(wrapper.Data, out)
+}
+
+// Raw makes a request to the given path with a pre-encoded JSON body,
+// unmarshalling the entire response into out.  This is used for endpoints which
+// don't wrap responses, such as the event API.
+func (c *Client) Raw(ctx context.Context, method, path string, body []byte, out any) error {
+	byt, err := c.do(ctx, method, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(byt, out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.host+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.signingKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.signingKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request to %s: %w", c.host, err)
+	}
+	defer resp.Body.Close()
+
+	byt, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Some servers respond with a 200 for public errors, so the body's error
+	// and status are checked as well as the response's status code.
+	apiErr := struct {
+		Error  string `json:"error"`
+		Status int    `json:"status"`
+	}{}
+	if err := json.Unmarshal(byt, &apiErr); err == nil && apiErr.Error != "" {
+		status := resp.StatusCode
+		if apiErr.Status > 299 {
+			status = apiErr.Status
+		}
+		return nil, fmt.Errorf("%s (%d)", apiErr.Error, status)
+	}
+	if resp.StatusCode > 299 {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(byt))
+	}
+	return byt, nil
+}
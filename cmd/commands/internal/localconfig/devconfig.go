@@ -34,6 +34,18 @@ func InitStartConfig(ctx context.Context, cmd *cobra.Command) error {
 	return nil
 }
 
+// InitPersistenceConfig loads the persistence flags used by `inngest start` for
+// commands which read or write the server's database directly.
+func InitPersistenceConfig(ctx context.Context, cmd *cobra.Command) error {
+	if err := mapPersistenceFlags(cmd); err != nil {
+		return err
+	}
+
+	loadConfigFile(ctx, cmd)
+
+	return nil
+}
+
 func loadConfigFile(ctx context.Context, cmd *cobra.Command) {
 	l := logger.From(ctx).With().Logger()
 
@@ -99,6 +111,11 @@ func mapDevFlags(cmd *cobra.Command) error {
 	err = errors.Join(err, viper.BindPFlag("tick", cmd.Flags().Lookup("tick")))
 	err = errors.Join(err, viper.BindPFlag("sdk-url", cmd.Flags().Lookup("sdk-url")))
 	err = errors.Join(err, viper.BindPFlag("connect-gateway-port", cmd.Flags().Lookup("connect-gateway-port")))
+	err = errors.Join(err, viper.BindPFlag("event-dedupe-window", cmd.Flags().Lookup("event-dedupe-window")))
+	err = errors.Join(err, viper.BindPFlag("lifecycle-sink", cmd.Flags().Lookup("lifecycle-sink")))
+	err = errors.Join(err, viper.BindPFlag("lifecycle-sink-signing-key", cmd.Flags().Lookup("lifecycle-sink-signing-key")))
+	err = errors.Join(err, viper.BindPFlag("ai-token-budget", cmd.Flags().Lookup("ai-token-budget")))
+	err = errors.Join(err, viper.BindPFlag("ai-token-budget-period", cmd.Flags().Lookup("ai-token-budget-period")))
 
 	return err
 }
@@ -117,7 +134,22 @@ func mapStartFlags(cmd *cobra.Command) error {
 	err = errors.Join(err, viper.BindPFlag("queue-workers", cmd.Flags().Lookup("queue-workers")))
 	err = errors.Join(err, viper.BindPFlag("sdk-url", cmd.Flags().Lookup("sdk-url")))
 	err = errors.Join(err, viper.BindPFlag("sqlite-dir", cmd.Flags().Lookup("sqlite-dir")))
+	err = errors.Join(err, viper.BindPFlag("state-store", cmd.Flags().Lookup("state-store")))
 	err = errors.Join(err, viper.BindPFlag("tick", cmd.Flags().Lookup("tick")))
+	err = errors.Join(err, viper.BindPFlag("event-dedupe-window", cmd.Flags().Lookup("event-dedupe-window")))
+	err = errors.Join(err, viper.BindPFlag("lifecycle-sink", cmd.Flags().Lookup("lifecycle-sink")))
+	err = errors.Join(err, viper.BindPFlag("lifecycle-sink-signing-key", cmd.Flags().Lookup("lifecycle-sink-signing-key")))
+	err = errors.Join(err, viper.BindPFlag("ai-token-budget", cmd.Flags().Lookup("ai-token-budget")))
+	err = errors.Join(err, viper.BindPFlag("ai-token-budget-period", cmd.Flags().Lookup("ai-token-budget-period")))
+
+	return err
+}
+
+// mapPersistenceFlags binds the database flags to the viper configuration
+func mapPersistenceFlags(cmd *cobra.Command) error {
+	var err error
+	err = errors.Join(err, viper.BindPFlag("postgres-uri", cmd.Flags().Lookup("postgres-uri")))
+	err = errors.Join(err, viper.BindPFlag("sqlite-dir", cmd.Flags().Lookup("sqlite-dir")))
 
 	return err
 }
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/coreapi/apiutil"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/spf13/cobra"
+)
+
+const invokePollInterval = 500 * time.Millisecond
+
+func NewCmdInvoke() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "invoke <function-slug>",
+		Short: "Invoke a function on a running Inngest server.",
+		Example: `  inngest invoke my-app-send-welcome-email --data '{"user_id": 1}'
+  inngest invoke my-app-generate-report --wait --timeout 10m`,
+		Args: cobra.ExactArgs(1),
+		RunE: doInvoke,
+	}
+	addAPIClientFlags(cmd)
+	cmd.Flags().String("data", "", "JSON data passed to the function as event.data")
+	cmd.Flags().Bool("wait", false, "Wait for the run to finish and print its output")
+	cmd.Flags().Duration("timeout", 5*time.Minute, "How long to wait for the run to finish when using --wait")
+	return cmd
+}
+
+func doInvoke(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	c := apiClient(cmd)
+
+	body := map[string]any{}
+	if data, _ := cmd.Flags().GetString("data"); data != "" {
+		raw := json.RawMessage(data)
+		if !json.Valid(raw) {
+			return fmt.Errorf("--data must be valid JSON")
+		}
+		body["data"] = raw
+	}
+	byt, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	res := apiutil.InvokeAPIResponse{}
+	if err := c.Raw(ctx, http.MethodPost, "/invoke/"+url.PathEscape(args[0]), byt, &res); err != nil {
+		return fmt.Errorf("error invoking function: %w", err)
+	}
+
+	if wait, _ := cmd.Flags().GetBool("wait"); !wait {
+		if jsonOutput() {
+			return printJSON(res)
+		}
+		fmt.Println(res.ID)
+		return nil
+	}
+
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	run, err := awaitEventRun(ctx, cmd, res.ID)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		if err := printJSON(run); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Run %s %s\n", run.RunID, run.Status)
+		if len(run.Output) > 0 {
+			fmt.Println(string(run.Output))
+		}
+	}
+
+	if run.Status != enums.RunStatusCompleted {
+		return fmt.Errorf("run %s %s", run.RunID, run.Status)
+	}
+	return nil
+}
+
+// awaitEventRun polls until the run triggered by the given event has ended.
+func awaitEventRun(ctx context.Context, cmd *cobra.Command, eventID string) (*cqrs.FunctionRun, error) {
+	c := apiClient(cmd)
+	path := "/v1/events/" + url.PathEscape(eventID) + "/runs"
+
+	t := time.NewTicker(invokePollInterval)
+	defer t.Stop()
+	for {
+		runs := []*cqrs.FunctionRun{}
+		if err := c.Do(ctx, http.MethodGet, path, nil, nil, &runs); err != nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("error loading run: %w", err)
+		}
+		if len(runs) > 0 && enums.RunStatusEnded(runs[0].Status) {
+			return runs[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for the run to finish")
+		case <-t.C:
+		}
+	}
+}
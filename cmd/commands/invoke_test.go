@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoke(t *testing.T) {
+	eventID := ulid.MustNew(ulid.Now(), rand.Reader)
+	runID := ulid.MustNew(ulid.Now(), rand.Reader)
+
+	tests := []struct {
+		name string
+		args []string
+		// statuses are returned by each poll of the event's runs, with the
+		// last status repeated.  No runs are returned when empty.
+		statuses []enums.RunStatus
+		// body is the expected invoke request body.
+		body  string
+		polls int
+		err   string
+	}{
+		{
+			name: "invokes without data",
+			args: []string{"app-fn"},
+			body: `{}`,
+		},
+		{
+			name: "invokes with data",
+			args: []string{"app-fn", "--data", `{"id": 1}`},
+			body: `{"data":{"id":1}}`,
+		},
+		{
+			name: "invalid data",
+			args: []string{"app-fn", "--data", `{"id": `},
+			err:  "--data must be valid JSON",
+		},
+		{
+			name:     "waits for the run to end",
+			args:     []string{"app-fn", "--wait"},
+			statuses: []enums.RunStatus{enums.RunStatusRunning, enums.RunStatusCompleted},
+			body:     `{}`,
+			polls:    2,
+		},
+		{
+			name:     "failed runs error",
+			args:     []string{"app-fn", "--wait"},
+			statuses: []enums.RunStatus{enums.RunStatusFailed},
+			body:     `{}`,
+			polls:    1,
+			err:      "run " + runID.String() + " Failed",
+		},
+		{
+			name:  "times out waiting for the run",
+			args:  []string{"app-fn", "--wait", "--timeout", "100ms"},
+			body:  `{}`,
+			polls: 1,
+			err:   "timed out waiting for the run to finish",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				l      sync.Mutex
+				bodies []string
+				polls  int
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				l.Lock()
+				defer l.Unlock()
+
+				switch r.URL.Path {
+				case "/invoke/app-fn":
+					byt, _ := io.ReadAll(r.Body)
+					bodies = append(bodies, string(byt))
+					_ = json.NewEncoder(w).Encode(map[string]any{"id": eventID.String(), "status": 200})
+				case "/v1/events/" + eventID.String() + "/runs":
+					polls++
+					runs := []cqrs.FunctionRun{}
+					if len(tc.statuses) > 0 {
+						status := tc.statuses[min(polls, len(tc.statuses))-1]
+						runs = append(runs, cqrs.FunctionRun{RunID: runID, EventID: eventID, Status: status})
+					}
+					_ = json.NewEncoder(w).Encode(map[string]any{"data": runs})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer srv.Close()
+
+			err := runCommand(NewCmdInvoke(), append(tc.args, "--api-host", srv.URL), "")
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			l.Lock()
+			defer l.Unlock()
+			if tc.body == "" {
+				require.Empty(t, bodies)
+			} else {
+				require.Len(t, bodies, 1)
+				require.JSONEq(t, tc.body, bodies[0])
+			}
+			require.Equal(t, tc.polls, polls)
+		})
+	}
+}
+
+func TestAwaitEventRunErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+	}))
+	defer srv.Close()
+
+	cmd := NewCmdInvoke()
+	require.NoError(t, cmd.ParseFlags([]string{"--api-host", srv.URL}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := awaitEventRun(ctx, cmd, "event")
+	require.ErrorContains(t, err, "error loading run")
+}
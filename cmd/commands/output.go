@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// jsonOutput returns whether commands should print JSON instead of tables.
+func jsonOutput() bool {
+	return viper.GetBool("json")
+}
+
+func printJSON(v any) error {
+	byt, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(byt))
+	return nil
+}
+
+// flagToParam converts a CLI flag name to its API query parameter, eg. "app-id" to "app_id".
+func flagToParam(flag string) string {
+	return strings.ReplaceAll(flag, "-", "_")
+}
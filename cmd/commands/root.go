@@ -71,6 +71,13 @@ func Execute() {
 	rootCmd.AddCommand(NewCmdDev(rootCmd))
 	rootCmd.AddCommand(NewCmdVersion())
 	rootCmd.AddCommand(NewCmdStart(rootCmd))
+	rootCmd.AddCommand(NewCmdDLQ())
+	rootCmd.AddCommand(NewCmdSend())
+	rootCmd.AddCommand(NewCmdInvoke())
+	rootCmd.AddCommand(NewCmdRuns())
+	rootCmd.AddCommand(NewCmdEvents())
+	rootCmd.AddCommand(NewCmdExport())
+	rootCmd.AddCommand(NewCmdImport())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -0,0 +1,325 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/inngest/cmd/commands/internal/apiclient"
+	"github.com/khulnasoft/inngest/cmd/commands/internal/table"
+	"github.com/khulnasoft/inngest/pkg/api/apiv1"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/spf13/cobra"
+)
+
+// tailRuns is the number of runs loaded per request when tailing runs.
+const tailRuns = 100
+
+// tailTimes are the times which runs are followed by when tailing, so that
+// runs are printed when they're queued, when they start and when they end.
+var tailTimes = []enums.TraceRunTime{
+	enums.TraceRunTimeQueuedAt,
+	enums.TraceRunTimeStartedAt,
+	enums.TraceRunTimeEndedAt,
+}
+
+func NewCmdRuns() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runs",
+		Short: "List, inspect, tail and cancel function runs.",
+		Example: `  inngest runs ls --status failed --since 1h
+  inngest runs get 01HZX0CG3A2S8FB0ZK7X2RZJ6E
+  inngest runs tail --function-id 5f1c8b64-8cd4-4e52-8f4b-5b0b6d0f4f0d`,
+	}
+	addAPIClientFlags(cmd)
+
+	ls := &cobra.Command{
+		Use:   "ls",
+		Short: "List runs, newest first.",
+		Args:  cobra.NoArgs,
+		RunE:  doRunsList,
+	}
+	addRunFilterFlags(ls)
+	ls.Flags().Duration("since", 0, "Only list runs queued within the given duration, eg. 1h")
+	ls.Flags().String("from", "", "Only list runs queued after the given RFC3339 time")
+	ls.Flags().String("until", "", "Only list runs queued before the given RFC3339 time")
+	ls.Flags().Int("limit", apiv1.DefaultRuns, "Maximum number of runs to list")
+	ls.Flags().String("cursor", "", "List runs after the given cursor")
+
+	get := &cobra.Command{
+		Use:   "get <run-id>",
+		Short: "Show a run's status, output and step trace.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  doRunsGet,
+	}
+
+	tail := &cobra.Command{
+		Use:   "tail",
+		Short: "Follow new runs and their status changes live.",
+		Args:  cobra.NoArgs,
+		RunE:  doRunsTail,
+	}
+	addRunFilterFlags(tail)
+	tail.Flags().Duration("interval", time.Second, "How often to check for new runs")
+
+	cancel := &cobra.Command{
+		Use:   "cancel <run-id>...",
+		Short: "Cancel runs.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  doRunsCancel,
+	}
+
+	cmd.AddCommand(ls, get, tail, cancel)
+	return cmd
+}
+
+func addRunFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("app-id", nil, "Filter to runs of functions in the given apps")
+	cmd.Flags().StringSlice("function-id", nil, "Filter to runs of the given functions")
+	cmd.Flags().StringSlice("status", nil, "Filter to runs with the given statuses, eg. running,failed")
+}
+
+// runFilters returns the query parameters for the command's run filter flags.
+func runFilters(cmd *cobra.Command) url.Values {
+	q := url.Values{}
+	for _, flag := range []string{"app-id", "function-id", "status"} {
+		values, _ := cmd.Flags().GetStringSlice(flag)
+		for _, v := range values {
+			q.Add(flagToParam(flag), v)
+		}
+	}
+	return q
+}
+
+func doRunsList(cmd *cobra.Command, args []string) error {
+	q := runFilters(cmd)
+	for _, flag := range []string{"from", "until", "cursor"} {
+		if v, _ := cmd.Flags().GetString(flag); v != "" {
+			q.Set(flag, v)
+		}
+	}
+	if since, _ := cmd.Flags().GetDuration("since"); since > 0 {
+		q.Set("from", time.Now().Add(-since).Format(time.RFC3339))
+	}
+	limit, _ := cmd.Flags().GetInt("limit")
+	q.Set("limit", strconv.Itoa(limit))
+
+	runs := []*cqrs.TraceRun{}
+	if err := apiClient(cmd).Do(cmd.Context(), http.MethodGet, "/v1/runs", q, nil, &runs); err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(runs)
+	}
+	if len(runs) == 0 {
+		fmt.Println("No runs found")
+		return nil
+	}
+
+	t := table.New(table.Row{"Run ID", "Function ID", "Status", "Queued at", "Duration"})
+	for _, r := range runs {
+		t.AppendRow(table.Row{r.RunID, r.FunctionID, r.Status, r.QueuedAt.Format(time.RFC3339), runDuration(r)})
+	}
+	t.Render()
+	if len(runs) == limit {
+		fmt.Printf("More runs are available with --cursor %s\n", runs[len(runs)-1].Cursor)
+	}
+	return nil
+}
+
+func doRunsGet(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	c := apiClient(cmd)
+	runID := url.PathEscape(args[0])
+
+	fr := cqrs.FunctionRun{}
+	if err := c.Do(ctx, http.MethodGet, "/v1/runs/"+runID, nil, nil, &fr); err != nil {
+		return err
+	}
+	trace := &apiv1.RunSpan{}
+	if err := c.Do(ctx, http.MethodGet, "/v1/runs/"+runID+"/trace", nil, nil, trace); err != nil {
+		// Runs which haven't started yet have no trace.
+		trace = nil
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string]any{
+			"run":   fr,
+			"trace": trace,
+		})
+	}
+
+	fmt.Printf("Run:       %s\n", fr.RunID)
+	fmt.Printf("Function:  %s\n", fr.FunctionID)
+	fmt.Printf("Status:    %s\n", fr.Status)
+	fmt.Printf("Started:   %s\n", fr.RunStartedAt.Format(time.RFC3339))
+	if fr.EndedAt != nil {
+		fmt.Printf("Ended:     %s\n", fr.EndedAt.Format(time.RFC3339))
+	}
+
+	if trace != nil {
+		t := table.New(table.Row{"Step", "Status", "Attempts", "Started at", "Duration"})
+		appendSpanRows(t, trace, 0)
+		t.Render()
+	}
+
+	if len(fr.Output) > 0 {
+		fmt.Printf("Output:\n%s\n", indentJSON(fr.Output))
+	}
+	return nil
+}
+
+// appendSpanRows appends a row for the span and each of its children, indenting
+// children beneath their parents.
+func appendSpanRows(t table.Table, s *apiv1.RunSpan, depth int) {
+	started := ""
+	if s.StartedAt != nil {
+		started = s.StartedAt.Format(time.RFC3339)
+	}
+	duration := ""
+	if s.DurationMS > 0 {
+		duration = (time.Duration(s.DurationMS) * time.Millisecond).String()
+	}
+	t.AppendRow(table.Row{strings.Repeat("  ", depth) + s.Name, s.Status, s.Attempts, started, duration})
+	for _, c := range s.Children {
+		appendSpanRows(t, c, depth+1)
+	}
+}
+
+func doRunsTail(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	c := apiClient(cmd)
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	q := runFilters(cmd)
+	q.Set("limit", strconv.Itoa(tailRuns))
+	q.Set("order", "asc")
+	q.Set("from", time.Now().Format(time.RFC3339))
+
+	if !jsonOutput() {
+		fmt.Fprintln(os.Stderr, "Waiting for runs...")
+	}
+
+	// Each time is followed using its own cursor, so that every run queued,
+	// started or ended since tailing began is printed regardless of how long
+	// it runs for.
+	cursors := map[enums.TraceRunTime]string{}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		// A run may be returned for more than one time, eg. if it was queued
+		// and finished since the last check, so only print each status once.
+		printed := map[string]enums.RunStatus{}
+		for _, field := range tailTimes {
+			cursor := cursors[field]
+			runs, err := nextRuns(ctx, c, q, field, &cursor)
+			cursors[field] = cursor
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				fmt.Fprintf(os.Stderr, "error loading runs: %s\n", err)
+			}
+
+			for _, r := range runs {
+				if status, ok := printed[r.RunID]; ok && status == r.Status {
+					continue
+				}
+				printed[r.RunID] = r.Status
+				if err := printRunEvent(r); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// nextRuns returns every run whose given time is after the cursor, oldest first,
+// advancing the cursor past the returned runs.
+func nextRuns(ctx context.Context, c *apiclient.Client, filters url.Values, field enums.TraceRunTime, cursor *string) ([]*cqrs.TraceRun, error) {
+	q := url.Values{}
+	for k, v := range filters {
+		q[k] = v
+	}
+	q.Set("time_field", field.String())
+
+	all := []*cqrs.TraceRun{}
+	for {
+		if *cursor != "" {
+			q.Set("cursor", *cursor)
+		}
+		runs := []*cqrs.TraceRun{}
+		if err := c.Do(ctx, http.MethodGet, "/v1/runs", q, nil, &runs); err != nil {
+			return all, err
+		}
+		all = append(all, runs...)
+		if len(runs) > 0 {
+			*cursor = runs[len(runs)-1].Cursor
+		}
+		if len(runs) < tailRuns {
+			return all, nil
+		}
+	}
+}
+
+func printRunEvent(r *cqrs.TraceRun) error {
+	if jsonOutput() {
+		byt, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(byt))
+		return nil
+	}
+	fmt.Printf("%s  %s  %s  %-9s  %s\n", time.Now().Format(time.TimeOnly), r.RunID, r.FunctionID, r.Status, runDuration(r))
+	return nil
+}
+
+func doRunsCancel(cmd *cobra.Command, args []string) error {
+	c := apiClient(cmd)
+	for _, id := range args {
+		if err := c.Do(cmd.Context(), http.MethodDelete, "/v1/runs/"+url.PathEscape(id), nil, nil, nil); err != nil {
+			return fmt.Errorf("error cancelling %s: %w", id, err)
+		}
+		if !jsonOutput() {
+			fmt.Printf("Cancelled %s\n", id)
+		}
+	}
+	return nil
+}
+
+func runDuration(r *cqrs.TraceRun) string {
+	if r.Duration > 0 {
+		return r.Duration.Truncate(time.Millisecond).String()
+	}
+	if !r.StartedAt.IsZero() && r.StartedAt.UnixMilli() > 0 && !enums.RunStatusEnded(r.Status) {
+		return time.Since(r.StartedAt).Truncate(time.Second).String()
+	}
+	return ""
+}
+
+func indentJSON(byt []byte) string {
+	out := map[string]any{}
+	if err := json.Unmarshal(byt, &out); err != nil {
+		return string(byt)
+	}
+	indented, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return string(byt)
+	}
+	return string(indented)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/khulnasoft/inngest/cmd/commands/internal/apiclient"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextRuns(t *testing.T) {
+	ctx := context.Background()
+
+	// The server has 250 runs, returned after the request's cursor.
+	var (
+		l       sync.Mutex
+		queries []url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Lock()
+		queries = append(queries, r.URL.Query())
+		l.Unlock()
+
+		after := -1
+		if c := r.URL.Query().Get("cursor"); c != "" {
+			after, _ = strconv.Atoi(c)
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		runs := []*cqrs.TraceRun{}
+		for n := after + 1; n < 250 && len(runs) < limit; n++ {
+			runs = append(runs, &cqrs.TraceRun{RunID: fmt.Sprintf("run-%d", n), Cursor: strconv.Itoa(n)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": runs})
+	}))
+	defer srv.Close()
+	c := apiclient.New(srv.URL, "")
+
+	filters := url.Values{}
+	filters.Set("limit", strconv.Itoa(tailRuns))
+	filters.Set("status", "Failed")
+
+	cursor := ""
+	runs, err := nextRuns(ctx, c, filters, enums.TraceRunTimeEndedAt, &cursor)
+	require.NoError(t, err)
+	require.Len(t, runs, 250)
+	require.Equal(t, "run-0", runs[0].RunID)
+	require.Equal(t, "run-249", runs[249].RunID)
+	require.Equal(t, "249", cursor)
+
+	// Every page is requested with the filters and time field.
+	require.Len(t, queries, 3)
+	for _, q := range queries {
+		require.Equal(t, "ended_at", q.Get("time_field"))
+		require.Equal(t, "Failed", q.Get("status"))
+	}
+	require.Empty(t, queries[0].Get("cursor"))
+	require.Equal(t, "99", queries[1].Get("cursor"))
+	require.Empty(t, filters.Get("cursor"))
+
+	t.Run("no new runs keep the cursor", func(t *testing.T) {
+		runs, err := nextRuns(ctx, c, filters, enums.TraceRunTimeEndedAt, &cursor)
+		require.NoError(t, err)
+		require.Empty(t, runs)
+		require.Equal(t, "249", cursor)
+	})
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/khulnasoft/inngest/cmd/commands/internal/apiclient"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/coreapi/apiutil"
+	"github.com/khulnasoft/inngest/pkg/eventstream"
+	"github.com/spf13/cobra"
+)
+
+// devEventKey is used when no event key is given.  The dev server accepts any
+// event key.
+const devEventKey = "dev"
+
+func NewCmdSend() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send [event-name]",
+		Short: "Send events to a running Inngest server.",
+		Long: `Send events to a running Inngest server.
+
+Events are read from --data when an event name is given, otherwise from --file
+or stdin.  Input may be a single event, an array of events, or a stream of
+newline-delimited events;  each JSON value is sent as it is read.`,
+		Example: `  inngest send app/user.created --data '{"id": 1}'
+  inngest send --file events.json
+  cat events.ndjson | inngest send`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: doSend,
+	}
+	addAPIClientFlags(cmd)
+	cmd.Flags().String("event-key", os.Getenv("INNGEST_EVENT_KEY"), "Event key used to send events")
+	cmd.Flags().String("data", "", "JSON data for the event given by name")
+	cmd.Flags().StringP("file", "f", "", "File containing events to send, or - for stdin")
+	return cmd
+}
+
+func doSend(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	key, _ := cmd.Flags().GetString("event-key")
+	if key == "" {
+		key = devEventKey
+	}
+
+	s := sender{
+		client: apiClient(cmd),
+		path:   "/e/" + url.PathEscape(key),
+	}
+
+	if len(args) == 1 {
+		evt := map[string]any{"name": args[0]}
+		if data, _ := cmd.Flags().GetString("data"); data != "" {
+			raw := json.RawMessage(data)
+			if !json.Valid(raw) {
+				return fmt.Errorf("--data must be valid JSON")
+			}
+			evt["data"] = raw
+		}
+		byt, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		return s.send(ctx, byt)
+	}
+
+	r := cmd.InOrStdin()
+	if file, _ := cmd.Flags().GetString("file"); file != "" && file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	// Decode each JSON value in turn, so that events piped to stdin are sent as
+	// they're written.
+	d := json.NewDecoder(r)
+	for {
+		value := json.RawMessage{}
+		err := d.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading events: %w", err)
+		}
+		if err := s.send(ctx, value); err != nil {
+			return err
+		}
+	}
+}
+
+type sender struct {
+	client *apiclient.Client
+	path   string
+}
+
+// send validates the given event or array of events, then sends them to the
+// event API, printing their IDs.
+func (s sender) send(ctx context.Context, value json.RawMessage) error {
+	stream := make(chan eventstream.StreamItem)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- eventstream.ParseStream(ctx, bytes.NewReader(value), stream, consts.AbsoluteMaxEventSize)
+	}()
+
+	events := []json.RawMessage{}
+	for item := range stream {
+		events = append(events, item.Item)
+	}
+	if err := <-errs; err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	byt, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	res := apiutil.EventAPIResponse{}
+	if err := s.client.Raw(ctx, http.MethodPost, s.path, byt, &res); err != nil {
+		return fmt.Errorf("error sending events: %w", err)
+	}
+
+	if jsonOutput() {
+		return printJSON(res)
+	}
+	for _, id := range res.IDs {
+		fmt.Println(id)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events.json")
+	require.NoError(t, os.WriteFile(file, []byte(`[{"name":"a"},{"name":"b"}]`), 0o600))
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		// status, if set, is returned by the event API.
+		status int
+		// requests are the events sent in each request to the event API.
+		requests []string
+		path     string
+		err      string
+	}{
+		{
+			name:     "event name with data",
+			args:     []string{"app/user.created", "--data", `{"id": 1}`},
+			requests: []string{`[{"data":{"id":1},"name":"app/user.created"}]`},
+		},
+		{
+			name:     "event name without data",
+			args:     []string{"app/user.created"},
+			requests: []string{`[{"name":"app/user.created"}]`},
+		},
+		{
+			name: "invalid data",
+			args: []string{"app/user.created", "--data", `{"id": `},
+			err:  "--data must be valid JSON",
+		},
+		{
+			name:     "event key",
+			args:     []string{"app/user.created", "--event-key", "my key"},
+			requests: []string{`[{"name":"app/user.created"}]`},
+			path:     "/e/my%20key",
+		},
+		{
+			name:     "a single event from stdin",
+			stdin:    `{"name":"a","data":{"n":1}}`,
+			requests: []string{`[{"name":"a","data":{"n":1}}]`},
+		},
+		{
+			name:     "an array of events from stdin",
+			stdin:    `[{"name":"a"}, {"name":"b"}]`,
+			requests: []string{`[{"name":"a"},{"name":"b"}]`},
+		},
+		{
+			name:     "newline-delimited events from stdin",
+			stdin:    "{\"name\":\"a\"}\n{\"name\":\"b\"}\n[{\"name\":\"c\"},{\"name\":\"d\"}]\n",
+			requests: []string{`[{"name":"a"}]`, `[{"name":"b"}]`, `[{"name":"c"},{"name":"d"}]`},
+		},
+		{
+			name:     "stdin given as a file",
+			args:     []string{"--file", "-"},
+			stdin:    `{"name":"a"}`,
+			requests: []string{`[{"name":"a"}]`},
+		},
+		{
+			name:     "events from a file",
+			args:     []string{"--file", file},
+			stdin:    `{"name":"ignored"}`,
+			requests: []string{`[{"name":"a"},{"name":"b"}]`},
+		},
+		{
+			name:     "empty arrays aren't sent",
+			stdin:    `[]`,
+			requests: []string{},
+		},
+		{
+			name:     "invalid json stops reading",
+			stdin:    "{\"name\":\"a\"}\n{\"name\":",
+			requests: []string{`[{"name":"a"}]`},
+			err:      "error reading events",
+		},
+		{
+			name:     "event api errors",
+			stdin:    `{"name":"a"}`,
+			status:   400,
+			requests: []string{`[{"name":"a"}]`},
+			err:      "error sending events",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				l        sync.Mutex
+				requests = []string{}
+				paths    = []string{}
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				byt, _ := io.ReadAll(r.Body)
+				l.Lock()
+				requests = append(requests, string(byt))
+				paths = append(paths, r.URL.EscapedPath())
+				l.Unlock()
+
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+					_, _ = fmt.Fprintf(w, `{"error":"invalid event","status":%d}`, tc.status)
+					return
+				}
+				evts := []json.RawMessage{}
+				require.NoError(t, json.Unmarshal(byt, &evts))
+				ids := make([]string, len(evts))
+				for i := range ids {
+					ids[i] = fmt.Sprintf("id-%d", i)
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"ids": ids, "status": 200})
+			}))
+			defer srv.Close()
+
+			err := runCommand(NewCmdSend(), append(tc.args, "--api-host", srv.URL), tc.stdin)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tc.requests == nil {
+				tc.requests = []string{}
+			}
+			require.Len(t, requests, len(tc.requests))
+			for i, expected := range tc.requests {
+				require.JSONEq(t, expected, requests[i])
+			}
+
+			path := tc.path
+			if path == "" {
+				path = "/e/" + devEventKey
+			}
+			for _, p := range paths {
+				require.Equal(t, path, p)
+			}
+		})
+	}
+}
+
+// runCommand executes the command with the given args and stdin.
+func runCommand(cmd *cobra.Command, args []string, stdin string) error {
+	cmd.SetArgs(args)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.ExecuteContext(context.Background())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/khulnasoft/inngest/cmd/commands/internal/localconfig"
 	"github.com/khulnasoft/inngest/pkg/config"
 	"github.com/khulnasoft/inngest/pkg/devserver"
+	"github.com/khulnasoft/inngest/pkg/eventdedupe"
 	"github.com/khulnasoft/inngest/pkg/lite"
 	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
 	"github.com/spf13/cobra"
@@ -46,6 +47,7 @@ func NewCmdStart(rootCmd *cobra.Command) *cobra.Command {
 	persistenceFlags.String("sqlite-dir", "", "Directory for where to write SQLite database.")
 	persistenceFlags.String("redis-uri", "", "Redis server URI for external queue and run state. Defaults to self-contained, in-memory Redis server with periodic snapshot backups.")
 	persistenceFlags.String("postgres-uri", "", "[Experimental] PostgreSQL database URI for configuration and history persistence. Defaults to SQLite database.")
+	persistenceFlags.String("state-store", "redis", "[Experimental] Backend for run state: \"redis\" or \"sql\", which stores run state in the SQLite or PostgreSQL database.")
 	cmd.Flags().AddFlagSet(persistenceFlags)
 	groups = append(groups, FlagGroup{name: "Persistence Flags:", fs: persistenceFlags})
 
@@ -54,6 +56,11 @@ func NewCmdStart(rootCmd *cobra.Command) *cobra.Command {
 	advancedFlags.Int("retry-interval", 0, "Retry interval in seconds for linear backoff when retrying functions - must be 1 or above")
 	advancedFlags.Int("queue-workers", devserver.DefaultQueueWorkers, "Number of executor workers to execute steps from the queue")
 	advancedFlags.Int("tick", devserver.DefaultTick, "The interval (in milliseconds) at which the executor polls the queue")
+	advancedFlags.Duration("event-dedupe-window", eventdedupe.DefaultWindow, "Window in which events sent with the same ID are dropped as duplicates, or 0 to disable")
+	advancedFlags.StringSlice("lifecycle-sink", []string{}, "URI of a webhook (https://), NATS (nats://host/subject) or Kafka (kafka://broker/topic) sink to export run lifecycle events to")
+	advancedFlags.String("lifecycle-sink-signing-key", "", "Key used to sign requests to lifecycle webhook sinks, in the Standard Webhooks whsec_ format")
+	advancedFlags.Int64("ai-token-budget", 0, "Maximum AI gateway tokens each environment may use per budget period. 0 means unlimited")
+	advancedFlags.Duration("ai-token-budget-period", 24*time.Hour, "Period after which the AI token budget resets")
 	cmd.Flags().AddFlagSet(advancedFlags)
 	groups = append(groups, FlagGroup{name: "Advanced Flags:", fs: advancedFlags})
 
@@ -139,8 +146,15 @@ func doStart(cmd *cobra.Command, args []string) {
 		Tick:          time.Duration(tick) * time.Millisecond,
 		URLs:          viper.GetStringSlice("sdk-url"),
 		SQLiteDir:     viper.GetString("sqlite-dir"),
+		StateStore:    viper.GetString("state-store"),
 		SigningKey:    viper.GetString("signing-key"),
 		EventKey:      viper.GetStringSlice("event-key"),
+
+		EventDedupeWindow:       viper.GetDuration("event-dedupe-window"),
+		LifecycleSinks:          viper.GetStringSlice("lifecycle-sink"),
+		LifecycleSinkSigningKey: viper.GetString("lifecycle-sink-signing-key"),
+		AITokenBudget:           viper.GetInt64("ai-token-budget"),
+		AITokenBudgetPeriod:     viper.GetDuration("ai-token-budget-period"),
 	}
 
 	err = lite.New(ctx, opts)
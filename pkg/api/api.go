@@ -13,11 +13,15 @@ import (
 	"github.com/khulnasoft/inngest/pkg/config"
 	"github.com/khulnasoft/inngest/pkg/consts"
 	"github.com/khulnasoft/inngest/pkg/coreapi/apiutil"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
 	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/eventdedupe"
+	"github.com/khulnasoft/inngest/pkg/eventschema"
 	"github.com/khulnasoft/inngest/pkg/eventstream"
 	"github.com/khulnasoft/inngest/pkg/headers"
 	"github.com/khulnasoft/inngest/pkg/publicerr"
 	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
+	"github.com/oklog/ulid/v2"
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
@@ -42,6 +46,18 @@ type Options struct {
 	// the server will still boot but core actions such as syncing, runs, and
 	// ingesting events will not work.
 	RequireKeys bool
+
+	// Schemas validates incoming events against the JSON Schemas registered for
+	// their names.  If nil, events are not validated against schemas.
+	Schemas *eventschema.Registry
+
+	// WebhookSources loads the webhook sources which receive requests at
+	// /webhooks/{path}.  If nil, webhook sources are disabled.
+	WebhookSources cqrs.WebhookSourceReader
+
+	// Dedupe drops events sent with the same ID as an earlier event within the
+	// dedupe window.  If nil, events are not deduplicated.
+	Dedupe *eventdedupe.Deduper
 }
 
 func NewAPI(o Options) (chi.Router, error) {
@@ -54,6 +70,9 @@ func NewAPI(o Options) (chi.Router, error) {
 		log:            &logger,
 		localEventKeys: o.LocalEventKeys,
 		requireKeys:    o.RequireKeys,
+		schemas:        o.Schemas,
+		webhookSources: o.WebhookSources,
+		dedupe:         o.Dedupe,
 	}
 
 	cors := cors.New(cors.Options{
@@ -70,6 +89,7 @@ func NewAPI(o Options) (chi.Router, error) {
 	api.Get("/health", api.HealthCheck)
 	api.Post("/e/{key}", api.ReceiveEvent)
 	api.Post("/invoke/{slug}", api.Invoke)
+	api.Post("/webhooks/*", api.ReceiveWebhook)
 
 	return api, nil
 }
@@ -94,6 +114,16 @@ type API struct {
 	// the server will still boot but core actions such as syncing, runs, and
 	// ingesting events will not work.
 	requireKeys bool
+
+	// schemas validates incoming events against their registered schemas.
+	schemas *eventschema.Registry
+
+	// webhookSources loads the webhook sources which transform requests into
+	// events.
+	webhookSources cqrs.WebhookSourceReader
+
+	// dedupe drops events duplicating an earlier event's ID.
+	dedupe *eventdedupe.Deduper
 }
 
 func (a *API) AddRoutes() {
@@ -226,41 +256,8 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 				return err
 			}
 
-			if evt.IsInternal() {
-				err := fmt.Errorf("event name is reserved for internal use: %s", evt.Name)
-				return err
-			}
-
-			// External event (i.e. doesn't have the "inngest/" prefix) data
-			// must not have internal metadata since it can cause issues. For
-			// example, if an invoked function's event data is forwarded into a
-			// new event then it may accidentally fulfill the invocation
-			delete(evt.Data, "_inngest")
-
-			ts := time.Now()
-			if evt.Timestamp == 0 {
-				evt.Timestamp = ts.UnixMilli()
-			}
-			if evt.User == nil {
-				evt.User = map[string]any{}
-			}
-
-			if err := evt.Validate(ctx); err != nil {
-				return err
-			}
-
-			ctx, span := itrace.UserTracer().Provider().
-				Tracer(consts.OtelScopeEvent).
-				Start(ctx, consts.OtelSpanEvent,
-					trace.WithTimestamp(ts),
-					trace.WithNewRoot(),
-					trace.WithLinks(trace.LinkFromContext(ctx)),
-				)
-			defer span.End()
-
-			id, err := a.handler(ctx, &evt)
+			id, err := a.ingest(ctx, evt)
 			if err != nil {
-				a.log.Error().Str("event", evt.Name).Err(err).Msg("error handling event")
 				return err
 			}
 			idChan <- struct {
@@ -297,6 +294,122 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ingest validates and handles a single incoming event, returning the event's
+// internal ID.
+func (a API) ingest(ctx context.Context, evt event.Event) (string, error) {
+	if evt.IsInternal() {
+		return "", fmt.Errorf("event name is reserved for internal use: %s", evt.Name)
+	}
+
+	// External event (i.e. doesn't have the "inngest/" prefix) data
+	// must not have internal metadata since it can cause issues. For
+	// example, if an invoked function's event data is forwarded into a
+	// new event then it may accidentally fulfill the invocation
+	delete(evt.Data, "_inngest")
+
+	ts := time.Now()
+	if evt.Timestamp == 0 {
+		evt.Timestamp = ts.UnixMilli()
+	}
+	if evt.User == nil {
+		evt.User = map[string]any{}
+	}
+
+	if err := evt.Validate(ctx); err != nil {
+		return "", err
+	}
+
+	failure, err := a.validateSchema(ctx, &evt)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, span := itrace.UserTracer().Provider().
+		Tracer(consts.OtelScopeEvent).
+		Start(ctx, consts.OtelSpanEvent,
+			trace.WithTimestamp(ts),
+			trace.WithNewRoot(),
+			trace.WithLinks(trace.LinkFromContext(ctx)),
+		)
+	defer span.End()
+
+	id, duplicate, err := a.handle(ctx, evt)
+	if err != nil {
+		a.log.Error().Str("event", evt.Name).Err(err).Msg("error handling event")
+		return "", err
+	}
+	if duplicate {
+		a.log.Info().
+			Str("event", evt.Name).
+			Str("external_id", evt.ID).
+			Str("internal_id", id).
+			Msg("dropping duplicate event")
+		return id, nil
+	}
+	if failure != nil {
+		a.recordSchemaFailure(ctx, evt, *failure, id)
+	}
+	return id, nil
+}
+
+// handle sends the event to the handler, unless it duplicates an event sent with
+// the same ID within the dedupe window.  Duplicates return the original event's
+// internal ID.
+func (a API) handle(ctx context.Context, evt event.Event) (string, bool, error) {
+	if a.dedupe == nil {
+		id, err := a.handler(ctx, &evt)
+		return id, false, err
+	}
+	return a.dedupe.Handle(ctx, consts.DevServerEnvId, evt, func(ctx context.Context, evt event.Event) (string, error) {
+		return a.handler(ctx, &evt)
+	})
+}
+
+// validateSchema validates the event against the schema registered for its name,
+// returning an error if the event should be rejected.  Events which are accepted
+// despite not matching their schema return the failure so that it's recorded once
+// the event has been handled.
+func (a API) validateSchema(ctx context.Context, evt *event.Event) (*eventschema.Failure, error) {
+	if a.schemas == nil {
+		return nil, nil
+	}
+
+	failure, err := a.schemas.Validate(ctx, *evt)
+	if err != nil {
+		// Never drop events because the registry is unavailable.
+		a.log.Error().Str("event", evt.Name).Err(err).Msg("error validating event schema")
+		return nil, nil
+	}
+	if failure == nil {
+		return nil, nil
+	}
+
+	switch failure.Mode {
+	case cqrs.EventSchemaModeReject:
+		a.recordSchemaFailure(ctx, *evt, *failure, "")
+		return nil, fmt.Errorf("%s: %w", evt.Name, failure)
+	case cqrs.EventSchemaModeTag:
+		eventschema.Tag(evt, *failure)
+	}
+	return failure, nil
+}
+
+func (a API) recordSchemaFailure(ctx context.Context, evt event.Event, failure eventschema.Failure, id string) {
+	a.log.Warn().
+		Str("event", evt.Name).
+		Str("mode", string(failure.Mode)).
+		Strs("errors", failure.Messages()).
+		Msg("event does not match its schema")
+
+	var eventID *ulid.ULID
+	if parsed, err := ulid.Parse(id); err == nil {
+		eventID = &parsed
+	}
+	if err := a.schemas.Record(ctx, evt, failure, eventID); err != nil {
+		a.log.Error().Str("event", evt.Name).Err(err).Msg("error recording event schema failure")
+	}
+}
+
 // Invoke creates an event to invoke a specific function.
 func (a API) Invoke(w http.ResponseWriter, r *http.Request) {
 	// XXX: In OSS self hosting, check signing keys here.
@@ -30,14 +30,26 @@ type Opts struct {
 	Executor execution.Executor
 	// EventReader allows reading of events from storage.
 	EventReader EventReader
+	// EventReplayer re-delivers stored events to function triggers.
+	EventReplayer EventReplayer
 	// FunctionReader reads functions from a backing store.
 	FunctionReader cqrs.FunctionReader
 	// FunctionRunReader reads function runs, history, etc. from backing storage
 	FunctionRunReader cqrs.APIV1FunctionRunReader
+	// TraceReader reads runs and their traces from backing storage.
+	TraceReader cqrs.TraceReader
 	// JobQueueReader reads information around a function run's job queues.
 	JobQueueReader queue.JobQueueReader
 	// CancellationReadWriter reads and writes cancellations to/from a backing store.
 	CancellationReadWriter cqrs.CancellationReadWriter
+	// BulkOperationReadWriter reads and writes bulk operations to/from a backing store.
+	BulkOperationReadWriter cqrs.BulkOperationReadWriter
+	// DeadLetterReadWriter reads and writes dead letters for permanently failed runs.
+	DeadLetterReadWriter cqrs.DeadLetterReadWriter
+	// EventSchemaReadWriter reads and writes event schemas and validation failures.
+	EventSchemaReadWriter cqrs.EventSchemaReadWriter
+	// WebhookSourceReadWriter reads and writes webhook sources.
+	WebhookSourceReadWriter cqrs.WebhookSourceReadWriter
 	// QueueShardSelector determines the queue shard to use
 	QueueShardSelector redis_state.ShardSelector
 	// Broadcaster is used to handle realtime via APIv1
@@ -102,11 +114,15 @@ func (a *router) setup() {
 			r.Use(headers.ContentTypeJsonResponse())
 
 			r.Get("/events", a.getEvents)
+			r.Post("/events/replay", a.replayEvents)
 			r.Get("/events/{eventID}", a.getEvent)
 			r.Get("/events/{eventID}/runs", a.getEventRuns)
+			r.Get("/runs", a.getRuns)
 			r.Get("/runs/{runID}", a.GetFunctionRun)
+			r.Get("/runs/{runID}/trace", a.getRunTrace)
 			r.Delete("/runs/{runID}", a.cancelFunctionRun)
 			r.Get("/runs/{runID}/jobs", a.GetFunctionRunJobs)
+			r.Post("/runs/{runID}/replay", a.replayFunctionRun)
 
 			r.Get("/apps/{appName}/functions", a.GetAppFunctions) // Returns an app and all of its functions.
 
@@ -114,6 +130,32 @@ func (a *router) setup() {
 			r.Get("/cancellations", a.getCancellations)
 			r.Delete("/cancellations/{id}", a.deleteCancellation)
 
+			r.Post("/signals/{signal}", a.sendSignal)
+
+			r.Post("/bulk-operations", a.createBulkOperation)
+			r.Get("/bulk-operations", a.getBulkOperations)
+			r.Get("/bulk-operations/{id}", a.getBulkOperation)
+			r.Delete("/bulk-operations/{id}", a.stopBulkOperation)
+
+			r.Get("/dlq", a.getDeadLetters)
+			r.Delete("/dlq", a.purgeDeadLetters)
+			r.Get("/dlq/{runID}", a.getDeadLetter)
+			r.Delete("/dlq/{runID}", a.deleteDeadLetter)
+			r.Post("/dlq/{runID}/redrive", a.redriveDeadLetter)
+
+			r.Get("/event-schemas", a.getEventSchemas)
+			r.Get("/event-schemas/*", a.getEventSchema)
+			r.Put("/event-schemas/*", a.putEventSchema)
+			r.Delete("/event-schemas/*", a.deleteEventSchema)
+			r.Get("/event-schema-failures", a.getEventSchemaFailures)
+
+			r.Get("/webhook-sources", a.getWebhookSources)
+			r.Post("/webhook-sources", a.createWebhookSource)
+			r.Post("/webhook-sources/transform", a.testWebhookTransform)
+			r.Get("/webhook-sources/{id}", a.getWebhookSource)
+			r.Put("/webhook-sources/{id}", a.updateWebhookSource)
+			r.Delete("/webhook-sources/{id}", a.deleteWebhookSource)
+
 			r.Get("/prom/{env}", a.promScrape)
 		})
 	})
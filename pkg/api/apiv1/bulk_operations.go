@@ -0,0 +1,224 @@
+package apiv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/khulnasoft/inngest/pkg/run"
+	"github.com/oklog/ulid/v2"
+)
+
+type CreateBulkOperationBody struct {
+	// Kind is the action to take on each matching run:  "cancel" or "replay".
+	Kind cqrs.BulkOperationKind `json:"kind"`
+	// AppID is the client ID specified via the SDK in the app that defines the function.
+	AppID string `json:"app_id"`
+	// FunctionID is the function ID string specified in configuration via the SDK.
+	FunctionID string `json:"function_id"`
+	// From and Until bound the time that matching runs were queued.
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+	// Status filters matching runs to the given statuses.
+	Status []enums.RunStatus `json:"status,omitempty"`
+	// If is an optional CEL expression evaluated against the triggering event,
+	// eg. `event.data.account_id == "acct_123"`.
+	If *string `json:"if,omitempty"`
+}
+
+func (c CreateBulkOperationBody) Validate() error {
+	var err error
+	if c.Kind != cqrs.BulkOperationKindCancel && c.Kind != cqrs.BulkOperationKindReplay {
+		err = errors.Join(err, errors.New("kind must be one of: cancel, replay"))
+	}
+	if c.AppID == "" {
+		err = errors.Join(err, errors.New("app_id is required"))
+	}
+	if c.FunctionID == "" {
+		err = errors.Join(err, errors.New("function_id is required"))
+	}
+	if c.From.IsZero() {
+		err = errors.Join(err, errors.New("from is required"))
+	}
+	if c.Until.IsZero() {
+		err = errors.Join(err, errors.New("until is required"))
+	}
+	if c.Until.After(time.Now().Add(5 * time.Second)) {
+		err = errors.Join(err, errors.New("until must be in the past"))
+	}
+	if c.From.After(c.Until) {
+		err = errors.Join(err, errors.New("from must be before until"))
+	}
+	return err
+}
+
+// CreateBulkOperation creates a new bulk operation which is processed in the background.
+func (a API) CreateBulkOperation(ctx context.Context, opts CreateBulkOperationBody) (*cqrs.BulkOperation, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.BulkOperationReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Bulk operations are not enabled")
+	}
+
+	if opts.If != nil && *opts.If != "" {
+		if _, err := run.NewExpressionHandler(ctx, run.WithExpressionHandlerBlob(*opts.If, "\n")); err != nil {
+			return nil, publicerr.Wrapf(err, 400, "invalid expression: %s", err)
+		}
+	}
+
+	fn, err := a.opts.FunctionReader.GetFunctionByExternalID(
+		ctx,
+		auth.WorkspaceID(),
+		opts.AppID,
+		opts.FunctionID,
+	)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 404, "function not found")
+	}
+
+	now := time.Now()
+	op := cqrs.BulkOperation{
+		ID:           ulid.MustNew(ulid.Now(), rand.Reader),
+		Kind:         opts.Kind,
+		AccountID:    auth.AccountID(),
+		WorkspaceID:  auth.WorkspaceID(),
+		FunctionID:   fn.ID,
+		FunctionSlug: fn.Slug,
+		From:         opts.From,
+		Until:        opts.Until,
+		RunStatus:    opts.Status,
+		If:           opts.If,
+		Status:       cqrs.BulkOperationStatusPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := a.opts.BulkOperationReadWriter.CreateBulkOperation(ctx, op); err != nil {
+		return nil, publicerr.Wrap(err, 500, "Error creating bulk operation")
+	}
+	return &op, nil
+}
+
+func (a router) createBulkOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	opts := CreateBulkOperationBody{}
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid bulk operation request"))
+		return
+	}
+	if err := opts.Validate(); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, err.Error()))
+		return
+	}
+
+	op, err := a.API.CreateBulkOperation(ctx, opts)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	_ = WriteResponse(w, op)
+}
+
+// GetBulkOperations returns all bulk operations for the authenticated workspace.
+func (a API) GetBulkOperations(ctx context.Context) ([]cqrs.BulkOperation, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.BulkOperationReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Bulk operations are not enabled")
+	}
+
+	all, err := a.opts.BulkOperationReadWriter.BulkOperations(ctx, auth.WorkspaceID())
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Error listing bulk operations")
+	}
+	return all, nil
+}
+
+func (a router) getBulkOperations(w http.ResponseWriter, r *http.Request) {
+	ops, err := a.API.GetBulkOperations(r.Context())
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, ops)
+}
+
+// GetBulkOperation returns a single bulk operation, including its progress.
+func (a API) GetBulkOperation(ctx context.Context, id ulid.ULID) (*cqrs.BulkOperation, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.BulkOperationReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Bulk operations are not enabled")
+	}
+
+	op, err := a.opts.BulkOperationReadWriter.BulkOperation(ctx, auth.WorkspaceID(), id)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 404, "Bulk operation not found")
+	}
+	return op, nil
+}
+
+func (a router) getBulkOperation(w http.ResponseWriter, r *http.Request) {
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid bulk operation ID"))
+		return
+	}
+	op, err := a.API.GetBulkOperation(r.Context(), id)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, op)
+}
+
+// StopBulkOperation stops a bulk operation from processing any further runs.
+// Runs which have already been cancelled or replayed are unaffected.
+func (a API) StopBulkOperation(ctx context.Context, id ulid.ULID) (*cqrs.BulkOperation, error) {
+	op, err := a.GetBulkOperation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if op.Status.IsDone() {
+		return nil, publicerr.Errorf(400, "Bulk operation has already finished")
+	}
+
+	op.Status = cqrs.BulkOperationStatusStopped
+	op.UpdatedAt = time.Now()
+	err = a.opts.BulkOperationReadWriter.UpdateBulkOperation(ctx, *op)
+	if errors.Is(err, cqrs.ErrBulkOperationDone) {
+		return nil, publicerr.Wrap(err, 400, "Bulk operation has already finished")
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Error stopping bulk operation")
+	}
+	return op, nil
+}
+
+func (a router) stopBulkOperation(w http.ResponseWriter, r *http.Request) {
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid bulk operation ID"))
+		return
+	}
+	op, err := a.API.StopBulkOperation(r.Context(), id)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, op)
+}
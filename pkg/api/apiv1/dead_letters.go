@@ -0,0 +1,289 @@
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/khulnasoft/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	DefaultDeadLetters = 40
+	MaxDeadLetters     = 100
+)
+
+// DeadLetterFilter filters dead letters to a single function.
+type DeadLetterFilter struct {
+	// AppID is the client ID specified via the SDK in the app that defines the function.
+	AppID string
+	// FunctionID is the function ID string specified in configuration via the SDK.
+	FunctionID string
+}
+
+// RedriveDeadLetterResponse is returned when redriving a dead letter.
+type RedriveDeadLetterResponse struct {
+	RunID         ulid.ULID `json:"run_id"`
+	OriginalRunID ulid.ULID `json:"original_run_id"`
+	// FromStepID is the step that the run was redriven from, if the run failed
+	// within a step.
+	FromStepID *string `json:"from_step_id,omitempty"`
+}
+
+// PurgeDeadLettersResponse is returned when purging dead letters.
+type PurgeDeadLettersResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// GetDeadLetters returns dead letters for permanently failed runs in the authenticated
+// workspace, newest first.
+func (a API) GetDeadLetters(ctx context.Context, filter DeadLetterFilter, opts cqrs.GetDeadLettersOpts) ([]*cqrs.DeadLetter, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.DeadLetterReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Dead letters are not enabled")
+	}
+
+	if opts.FunctionID, err = a.deadLetterFunctionID(ctx, auth.WorkspaceID(), filter); err != nil {
+		return nil, err
+	}
+
+	dls, err := a.opts.DeadLetterReadWriter.GetDeadLetters(ctx, auth.WorkspaceID(), opts)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load dead letters")
+	}
+	return dls, nil
+}
+
+func (a router) getDeadLetters(w http.ResponseWriter, r *http.Request) {
+	opts := cqrs.GetDeadLettersOpts{}
+
+	limit, _ := strconv.Atoi(r.FormValue("limit"))
+	if limit == 0 {
+		limit = DefaultDeadLetters
+	}
+	opts.Limit = util.Bound(limit, 1, MaxDeadLetters)
+
+	if cursor := r.FormValue("cursor"); cursor != "" {
+		parsed, err := ulid.Parse(cursor)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid cursor query parameter"))
+			return
+		}
+		opts.Cursor = &parsed
+	}
+
+	dls, err := a.API.GetDeadLetters(r.Context(), DeadLetterFilter{
+		AppID:      r.FormValue("app_id"),
+		FunctionID: r.FormValue("function_id"),
+	}, opts)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, dls)
+}
+
+// GetDeadLetter returns the dead letter for a permanently failed run, including the
+// run's triggering events, final error and the step which failed.
+func (a API) GetDeadLetter(ctx context.Context, runID ulid.ULID) (*cqrs.DeadLetter, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.DeadLetterReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Dead letters are not enabled")
+	}
+
+	dl, err := a.opts.DeadLetterReadWriter.GetDeadLetter(ctx, auth.WorkspaceID(), runID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, publicerr.Wrap(err, 404, "Dead letter not found")
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load dead letter")
+	}
+	return dl, nil
+}
+
+func (a router) getDeadLetter(w http.ResponseWriter, r *http.Request) {
+	runID, err := ulid.Parse(chi.URLParam(r, "runID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid run ID: %s", chi.URLParam(r, "runID")))
+		return
+	}
+	dl, err := a.API.GetDeadLetter(r.Context(), runID)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, dl)
+}
+
+// RedriveDeadLetter schedules a new run for the dead letter's function, linked to the
+// failed run.  If the run failed within a step the new run starts from the failing step,
+// reusing the output of all prior steps;  otherwise the run is replayed from the start.
+func (a API) RedriveDeadLetter(ctx context.Context, runID ulid.ULID) (*RedriveDeadLetterResponse, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	dl, err := a.GetDeadLetter(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	fnCQRS, err := a.opts.FunctionReader.GetFunctionByInternalUUID(ctx, auth.WorkspaceID(), dl.FunctionID)
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 404, "Unable to load function for run: %s", runID)
+	}
+	fn, err := fnCQRS.InngestFunction()
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load function config")
+	}
+
+	if len(dl.Events) == 0 || len(dl.Events) != len(dl.EventIDs) {
+		return nil, publicerr.Errorf(500, "Dead letter events are missing")
+	}
+	tracked := make([]event.TrackedEvent, len(dl.Events))
+	for n, raw := range dl.Events {
+		evt, err := event.NewEvent(raw)
+		if err != nil {
+			return nil, publicerr.Wrap(err, 500, "Unable to parse dead letter event")
+		}
+		// Keep the original event IDs so that the redriven run is linked to
+		// the same events as the failed run.
+		tracked[n] = event.NewOSSTrackedEventWithID(*evt, dl.EventIDs[n])
+	}
+
+	var fromStep *execution.ScheduleRequestFromStep
+	if dl.LastStepID != nil {
+		fromStep = &execution.ScheduleRequestFromStep{StepID: *dl.LastStepID}
+	}
+
+	md, err := a.opts.Executor.Schedule(ctx, execution.ScheduleRequest{
+		Function:      *fn,
+		AppID:         fnCQRS.AppID,
+		AccountID:     auth.AccountID(),
+		WorkspaceID:   auth.WorkspaceID(),
+		Events:        tracked,
+		OriginalRunID: &dl.RunID,
+		FromStep:      fromStep,
+	})
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to redrive function run")
+	}
+
+	if err := a.opts.DeadLetterReadWriter.SetDeadLetterRedriven(ctx, auth.WorkspaceID(), dl.RunID, md.ID.RunID); err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to update dead letter")
+	}
+
+	return &RedriveDeadLetterResponse{
+		RunID:         md.ID.RunID,
+		OriginalRunID: dl.RunID,
+		FromStepID:    dl.LastStepID,
+	}, nil
+}
+
+func (a router) redriveDeadLetter(w http.ResponseWriter, r *http.Request) {
+	runID, err := ulid.Parse(chi.URLParam(r, "runID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid run ID: %s", chi.URLParam(r, "runID")))
+		return
+	}
+	res, err := a.API.RedriveDeadLetter(r.Context(), runID)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, res)
+}
+
+// PurgeDeadLetters deletes dead letters from the authenticated workspace.
+func (a API) PurgeDeadLetters(ctx context.Context, filter DeadLetterFilter, opts cqrs.PurgeDeadLettersOpts) (*PurgeDeadLettersResponse, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.DeadLetterReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Dead letters are not enabled")
+	}
+
+	if opts.FunctionID, err = a.deadLetterFunctionID(ctx, auth.WorkspaceID(), filter); err != nil {
+		return nil, err
+	}
+
+	n, err := a.opts.DeadLetterReadWriter.PurgeDeadLetters(ctx, auth.WorkspaceID(), opts)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to purge dead letters")
+	}
+	return &PurgeDeadLettersResponse{Deleted: n}, nil
+}
+
+func (a router) purgeDeadLetters(w http.ResponseWriter, r *http.Request) {
+	opts := cqrs.PurgeDeadLettersOpts{}
+	if before := r.FormValue("before"); before != "" {
+		t, err := time.Parse(time.RFC3339, before)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid before query parameter"))
+			return
+		}
+		opts.Before = &t
+	}
+
+	res, err := a.API.PurgeDeadLetters(r.Context(), DeadLetterFilter{
+		AppID:      r.FormValue("app_id"),
+		FunctionID: r.FormValue("function_id"),
+	}, opts)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, res)
+}
+
+func (a router) deleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+	runID, err := ulid.Parse(chi.URLParam(r, "runID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid run ID: %s", chi.URLParam(r, "runID")))
+		return
+	}
+	res, err := a.API.PurgeDeadLetters(r.Context(), DeadLetterFilter{}, cqrs.PurgeDeadLettersOpts{
+		RunIDs: []ulid.ULID{runID},
+	})
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	if res.Deleted == 0 {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(404, "Dead letter not found"))
+		return
+	}
+	_ = WriteResponse(w, res)
+}
+
+// deadLetterFunctionID resolves the function filter to the function's internal ID.
+func (a API) deadLetterFunctionID(ctx context.Context, wsID uuid.UUID, filter DeadLetterFilter) (*uuid.UUID, error) {
+	if filter.FunctionID == "" && filter.AppID == "" {
+		return nil, nil
+	}
+	if filter.FunctionID == "" || filter.AppID == "" {
+		return nil, publicerr.Errorf(400, "app_id and function_id must be specified together")
+	}
+	fn, err := a.opts.FunctionReader.GetFunctionByExternalID(ctx, wsID, filter.AppID, filter.FunctionID)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 404, "function not found")
+	}
+	return &fn.ID, nil
+}
@@ -0,0 +1,202 @@
+package apiv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution/runner"
+	"github.com/khulnasoft/inngest/pkg/expressions"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+)
+
+const (
+	// MaxReplayEvents is the maximum number of events replayed in a single request.
+	MaxReplayEvents = 10_000
+	// maxReplayErrors is the maximum number of errors returned when replaying events.
+	maxReplayErrors = 20
+)
+
+// ReplayEventsBody selects stored events to re-deliver to function triggers.
+type ReplayEventsBody struct {
+	// Name filters replayed events to the given event name.
+	Name string `json:"name,omitempty"`
+	// From and Until bound the time that replayed events were received.  Until
+	// defaults to now.
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+	// If is an optional CEL expression evaluated against each event,
+	// eg. `event.data.account_id == "acct_123"`.
+	If *string `json:"if,omitempty"`
+	// AppID and FunctionID limit the replay to a single function, using the IDs
+	// specified via the SDK.  Other functions triggered by the events are not run.
+	AppID      string `json:"app_id,omitempty"`
+	FunctionID string `json:"function_id,omitempty"`
+	// DryRun counts the matching events and the functions they trigger without
+	// scheduling any runs.
+	DryRun bool `json:"dry_run"`
+}
+
+func (b ReplayEventsBody) Validate() error {
+	var err error
+	if b.From.IsZero() {
+		err = errors.Join(err, errors.New("from is required"))
+	}
+	if !b.Until.IsZero() && b.From.After(b.Until) {
+		err = errors.Join(err, errors.New("from must be before until"))
+	}
+	if (b.AppID == "") != (b.FunctionID == "") {
+		err = errors.Join(err, errors.New("app_id and function_id must be specified together"))
+	}
+	return err
+}
+
+// ReplayEventsResponse summarizes an event replay.
+type ReplayEventsResponse struct {
+	DryRun bool `json:"dry_run"`
+	// Events is the number of stored events which matched the selection.
+	Events int `json:"events"`
+	// Triggers is the number of function triggers matched by the events.
+	Triggers int `json:"triggers"`
+	// Runs is the number of runs scheduled.  Functions which already ran for
+	// an event within their idempotency period are not run again and aren't
+	// counted, nor are events which were batched, debounced, rate limited or
+	// skipped.  This is zero for dry runs.
+	Runs int `json:"runs"`
+	// Functions counts the events matching each function's triggers, keyed by
+	// function slug.
+	Functions map[string]int `json:"functions"`
+	// Errors lists errors replaying individual events.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ReplayEvents re-delivers stored events to the functions whose triggers
+// currently match them, eg. to backfill a new function from historical events.
+// Events are replayed oldest first.
+func (a API) ReplayEvents(ctx context.Context, body ReplayEventsBody) (*ReplayEventsResponse, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.EventReplayer == nil {
+		return nil, publicerr.Errorf(501, "Event replay is not enabled")
+	}
+	if err := body.Validate(); err != nil {
+		return nil, publicerr.Wrap(err, 400, err.Error())
+	}
+	if body.Until.IsZero() {
+		body.Until = time.Now()
+	}
+
+	var filter expressions.BooleanEvaluator
+	if body.If != nil && *body.If != "" {
+		if filter, err = expressions.NewBooleanEvaluator(ctx, *body.If); err != nil {
+			return nil, publicerr.Wrapf(err, 400, "invalid expression: %s", err)
+		}
+	}
+
+	opts := runner.ReplayOpts{DryRun: body.DryRun}
+	if body.FunctionID != "" {
+		fn, err := a.opts.FunctionReader.GetFunctionByExternalID(ctx, auth.WorkspaceID(), body.AppID, body.FunctionID)
+		if err != nil {
+			return nil, publicerr.Wrap(err, 404, "function not found")
+		}
+		opts.FunctionID = &fn.ID
+	}
+
+	evts, err := a.replayableEvents(ctx, body, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ReplayEventsResponse{
+		DryRun:    body.DryRun,
+		Events:    len(evts),
+		Functions: map[string]int{},
+	}
+	// Events are loaded newest first.
+	for i := len(evts) - 1; i >= 0; i-- {
+		evt := evts[i]
+		tracked := event.NewOSSTrackedEventWithID(evt.Event(), evt.InternalID())
+		replayed, err := a.opts.EventReplayer.Replay(ctx, tracked, opts)
+		if err != nil && len(res.Errors) < maxReplayErrors {
+			res.Errors = append(res.Errors, fmt.Sprintf("%s: %s", evt.InternalID(), err))
+		}
+		for _, fn := range replayed.Matched {
+			res.Triggers++
+			res.Functions[fn.GetSlug()]++
+		}
+		res.Runs += len(replayed.Scheduled)
+	}
+	return res, nil
+}
+
+// replayableEvents loads all events matching the selection, newest first.
+// Internal events are never replayed.
+func (a API) replayableEvents(ctx context.Context, body ReplayEventsBody, filter expressions.BooleanEvaluator) ([]cqrs.Event, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+
+	opts := cqrs.WorkspaceEventsOpts{
+		Limit:  cqrs.MaxEvents,
+		Oldest: body.From,
+		Newest: body.Until,
+	}
+	if body.Name != "" {
+		opts.Name = &body.Name
+	}
+
+	matched := []cqrs.Event{}
+	for {
+		page, err := a.opts.EventReader.WorkspaceEvents(ctx, auth.WorkspaceID(), &opts)
+		if err != nil {
+			return nil, publicerr.Wrap(err, 500, "Unable to load events")
+		}
+
+		for _, evt := range page {
+			if evt.Event().IsInternal() {
+				continue
+			}
+			if filter != nil {
+				ok, _, err := filter.Evaluate(ctx, expressions.NewData(map[string]any{"event": evt.Event().Map()}))
+				if err != nil || !ok {
+					// Events without the fields used by the expression don't
+					// match, as with function triggers.
+					continue
+				}
+			}
+			if len(matched) == MaxReplayEvents {
+				return nil, publicerr.Errorf(400, "More than %d events match; narrow the time range, name or expression", MaxReplayEvents)
+			}
+			matched = append(matched, evt)
+		}
+
+		if len(page) < opts.Limit {
+			return matched, nil
+		}
+		cursor := page[len(page)-1].ID
+		opts.Cursor = &cursor
+	}
+}
+
+func (a router) replayEvents(w http.ResponseWriter, r *http.Request) {
+	body := ReplayEventsBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid replay request"))
+		return
+	}
+
+	res, err := a.API.ReplayEvents(r.Context(), body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, res)
+}
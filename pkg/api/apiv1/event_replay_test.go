@@ -0,0 +1,181 @@
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/api/apiv1/apiv1auth"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution/runner"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	// Events were received over the past few minutes.
+	start := now.Add(-5 * time.Minute)
+
+	events := &fakeEventReader{events: map[ulid.ULID]*cqrs.Event{}}
+	add := func(name string, n int) {
+		id := ulid.MustNew(ulid.Timestamp(start.Add(time.Duration(n)*time.Second)), nil)
+		events.events[id] = &cqrs.Event{
+			ID:          id,
+			WorkspaceID: consts.DevServerEnvId,
+			ReceivedAt:  start.Add(time.Duration(n) * time.Second),
+			EventName:   name,
+			EventData:   map[string]any{"n": n},
+		}
+	}
+	// More events than are loaded in a single page.
+	for n := 0; n < 60; n++ {
+		add("order/created", n)
+	}
+	add("user/created", 60)
+	add(event.FnFinishedName, 61)
+
+	all := inngest.Function{ID: uuid.New(), Slug: "app-all"}
+	deduped := inngest.Function{ID: uuid.New(), Slug: "app-deduped"}
+	replayer := &fakeReplayer{
+		result: runner.ReplayResult{
+			Matched: []inngest.Function{all, deduped},
+			// The second function already ran for each event.
+			Scheduled: []inngest.Function{all},
+		},
+	}
+	a := API{opts: Opts{
+		AuthFinder:     apiv1auth.NilAuthFinder,
+		EventReader:    events,
+		EventReplayer:  replayer,
+		FunctionReader: &fakeFunctionReader{fn: &cqrs.Function{ID: all.ID, Slug: all.Slug}},
+	}}
+
+	t.Run("events are replayed oldest first", func(t *testing.T) {
+		replayer.reset()
+		res, err := a.ReplayEvents(ctx, ReplayEventsBody{Name: "order/created", From: start})
+		require.NoError(t, err)
+		require.Equal(t, &ReplayEventsResponse{
+			Events:    60,
+			Triggers:  120,
+			Runs:      60,
+			Functions: map[string]int{"app-all": 60, "app-deduped": 60},
+		}, res)
+
+		require.Len(t, replayer.events, 60)
+		for n, evt := range replayer.events {
+			require.EqualValues(t, n, evt.GetEvent().Data["n"])
+		}
+		require.Nil(t, replayer.opts.FunctionID)
+	})
+
+	t.Run("internal events are not replayed", func(t *testing.T) {
+		replayer.reset()
+		res, err := a.ReplayEvents(ctx, ReplayEventsBody{From: start})
+		require.NoError(t, err)
+		require.Equal(t, 61, res.Events)
+	})
+
+	t.Run("events are filtered by the expression", func(t *testing.T) {
+		replayer.reset()
+		expr := "event.data.n >= 50"
+		res, err := a.ReplayEvents(ctx, ReplayEventsBody{Name: "order/created", From: start, If: &expr})
+		require.NoError(t, err)
+		require.Equal(t, 10, res.Events)
+	})
+
+	t.Run("dry runs are passed to the replayer", func(t *testing.T) {
+		replayer.reset()
+		res, err := a.ReplayEvents(ctx, ReplayEventsBody{Name: "user/created", From: start, DryRun: true})
+		require.NoError(t, err)
+		require.True(t, res.DryRun)
+		require.True(t, replayer.opts.DryRun)
+	})
+
+	t.Run("replays are limited to a function", func(t *testing.T) {
+		replayer.reset()
+		_, err := a.ReplayEvents(ctx, ReplayEventsBody{Name: "user/created", From: start, AppID: "app", FunctionID: "all"})
+		require.NoError(t, err)
+		require.Equal(t, all.ID, *replayer.opts.FunctionID)
+	})
+
+	t.Run("errors are returned per event", func(t *testing.T) {
+		replayer.reset()
+		replayer.err = errors.New("boom")
+		defer func() { replayer.err = nil }()
+
+		res, err := a.ReplayEvents(ctx, ReplayEventsBody{Name: "order/created", From: start})
+		require.NoError(t, err)
+		require.Len(t, res.Errors, maxReplayErrors)
+		require.Contains(t, res.Errors[0], "boom")
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := a.ReplayEvents(ctx, ReplayEventsBody{})
+		requireStatus(t, err, 400)
+
+		_, err = a.ReplayEvents(ctx, ReplayEventsBody{From: now, AppID: "app"})
+		requireStatus(t, err, 400)
+
+		expr := "event.data.n >"
+		_, err = a.ReplayEvents(ctx, ReplayEventsBody{From: now, If: &expr})
+		requireStatus(t, err, 400)
+	})
+}
+
+type fakeReplayer struct {
+	result runner.ReplayResult
+	err    error
+
+	events []event.TrackedEvent
+	opts   runner.ReplayOpts
+}
+
+func (f *fakeReplayer) reset() {
+	f.events = nil
+	f.opts = runner.ReplayOpts{}
+}
+
+func (f *fakeReplayer) Replay(ctx context.Context, tracked event.TrackedEvent, opts runner.ReplayOpts) (runner.ReplayResult, error) {
+	f.events = append(f.events, tracked)
+	f.opts = opts
+	return f.result, f.err
+}
+
+// WorkspaceEvents returns the reader's events newest first.
+func (f *fakeEventReader) WorkspaceEvents(ctx context.Context, workspaceID uuid.UUID, opts *cqrs.WorkspaceEventsOpts) ([]cqrs.Event, error) {
+	all := []cqrs.Event{}
+	for _, evt := range f.events {
+		switch {
+		case evt.WorkspaceID != workspaceID,
+			opts.Name != nil && evt.EventName != *opts.Name,
+			evt.ReceivedAt.Before(opts.Oldest),
+			evt.ReceivedAt.After(opts.Newest),
+			opts.Cursor != nil && evt.ID.Compare(*opts.Cursor) >= 0:
+			continue
+		}
+		all = append(all, *evt)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID.Compare(all[j].ID) > 0
+	})
+	if len(all) > opts.Limit {
+		all = all[:opts.Limit]
+	}
+	return all, nil
+}
+
+func (f *fakeFunctionReader) GetFunctionByExternalID(ctx context.Context, wsID uuid.UUID, appID string, functionID string) (*cqrs.Function, error) {
+	if appID+"-"+functionID != f.fn.Slug {
+		return nil, sql.ErrNoRows
+	}
+	return f.fn, nil
+}
@@ -0,0 +1,219 @@
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/eventschema"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/khulnasoft/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	DefaultEventSchemaFailures = 40
+	MaxEventSchemaFailures     = 100
+)
+
+// PutEventSchemaBody registers a JSON Schema for an event name.
+type PutEventSchemaBody struct {
+	// Schema is the JSON Schema for the event's data.
+	Schema json.RawMessage `json:"schema"`
+	// Mode determines how events which don't match the schema are handled: one
+	// of "reject", "warn" or "tag".  Defaults to "warn".
+	Mode cqrs.EventSchemaMode `json:"mode"`
+}
+
+// GetEventSchemas returns all registered event schemas.
+func (a API) GetEventSchemas(ctx context.Context) ([]*cqrs.EventSchema, error) {
+	if _, err := a.opts.AuthFinder(ctx); err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.EventSchemaReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Event schemas are not enabled")
+	}
+
+	schemas, err := a.opts.EventSchemaReadWriter.GetEventSchemas(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load event schemas")
+	}
+	return schemas, nil
+}
+
+func (a router) getEventSchemas(w http.ResponseWriter, r *http.Request) {
+	schemas, err := a.API.GetEventSchemas(r.Context())
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, schemas)
+}
+
+// GetEventSchema returns the schema registered for an event name.
+func (a API) GetEventSchema(ctx context.Context, name string) (*cqrs.EventSchema, error) {
+	if _, err := a.opts.AuthFinder(ctx); err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.EventSchemaReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Event schemas are not enabled")
+	}
+
+	schema, err := a.opts.EventSchemaReadWriter.GetEventSchema(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, publicerr.Wrapf(err, 404, "No schema registered for %s", name)
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load event schema")
+	}
+	return schema, nil
+}
+
+func (a router) getEventSchema(w http.ResponseWriter, r *http.Request) {
+	name, err := eventSchemaName(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	schema, err := a.API.GetEventSchema(r.Context(), name)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, schema)
+}
+
+// PutEventSchema registers a schema for an event name, replacing any existing
+// schema including one published by an app.
+func (a API) PutEventSchema(ctx context.Context, name string, body PutEventSchemaBody) (*cqrs.EventSchema, error) {
+	if _, err := a.opts.AuthFinder(ctx); err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.EventSchemaReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Event schemas are not enabled")
+	}
+
+	schema := cqrs.EventSchema{
+		Name:   name,
+		Schema: body.Schema,
+		Mode:   body.Mode,
+	}
+	if err := eventschema.Check(&schema); err != nil {
+		return nil, publicerr.Wrap(err, 400, err.Error())
+	}
+
+	saved, err := a.opts.EventSchemaReadWriter.UpsertEventSchema(ctx, schema)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to save event schema")
+	}
+	return saved, nil
+}
+
+func (a router) putEventSchema(w http.ResponseWriter, r *http.Request) {
+	name, err := eventSchemaName(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	body := PutEventSchemaBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid event schema request"))
+		return
+	}
+
+	schema, err := a.API.PutEventSchema(r.Context(), name, body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, schema)
+}
+
+// DeleteEventSchema deletes the schema registered for an event name.
+func (a API) DeleteEventSchema(ctx context.Context, name string) error {
+	if _, err := a.GetEventSchema(ctx, name); err != nil {
+		return err
+	}
+	if err := a.opts.EventSchemaReadWriter.DeleteEventSchemas(ctx, []string{name}); err != nil {
+		return publicerr.Wrap(err, 500, "Unable to delete event schema")
+	}
+	return nil
+}
+
+func (a router) deleteEventSchema(w http.ResponseWriter, r *http.Request) {
+	name, err := eventSchemaName(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	if err := a.API.DeleteEventSchema(r.Context(), name); err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetEventSchemaFailures returns events which failed validation, newest first.
+func (a API) GetEventSchemaFailures(ctx context.Context, opts cqrs.GetEventSchemaFailuresOpts) ([]*cqrs.EventSchemaFailure, error) {
+	if _, err := a.opts.AuthFinder(ctx); err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.EventSchemaReadWriter == nil {
+		return nil, publicerr.Errorf(501, "Event schemas are not enabled")
+	}
+
+	failures, err := a.opts.EventSchemaReadWriter.GetEventSchemaFailures(ctx, opts)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load event schema failures")
+	}
+	return failures, nil
+}
+
+func (a router) getEventSchemaFailures(w http.ResponseWriter, r *http.Request) {
+	opts := cqrs.GetEventSchemaFailuresOpts{}
+
+	limit, _ := strconv.Atoi(r.FormValue("limit"))
+	if limit == 0 {
+		limit = DefaultEventSchemaFailures
+	}
+	opts.Limit = util.Bound(limit, 1, MaxEventSchemaFailures)
+
+	if name := r.FormValue("event_name"); name != "" {
+		opts.EventName = &name
+	}
+	if cursor := r.FormValue("cursor"); cursor != "" {
+		parsed, err := ulid.Parse(cursor)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid cursor query parameter"))
+			return
+		}
+		opts.Cursor = &parsed
+	}
+
+	failures, err := a.API.GetEventSchemaFailures(r.Context(), opts)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, failures)
+}
+
+// eventSchemaName returns the event name from the route.  Event names commonly
+// contain slashes, so the name is the remainder of the path and may optionally be
+// URL encoded.
+func eventSchemaName(r *http.Request) (string, error) {
+	name, err := url.PathUnescape(chi.URLParam(r, "*"))
+	if err != nil {
+		return "", publicerr.Wrap(err, 400, "Unable to decode event name")
+	}
+	if name == "" {
+		return "", publicerr.Errorf(400, "Event name is required")
+	}
+	return name, nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution/runner"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -14,4 +16,11 @@ type EventReader interface {
 	WorkspaceEvents(ctx context.Context, workspaceID uuid.UUID, opts *cqrs.WorkspaceEventsOpts) ([]cqrs.Event, error)
 	// Find returns a specific event given an ID.
 	FindEvent(ctx context.Context, workspaceID uuid.UUID, id ulid.ULID) (*cqrs.Event, error)
+	// GetEventBatchByRunID returns the batch of events which triggered a run.
+	GetEventBatchByRunID(ctx context.Context, runID ulid.ULID) (*cqrs.EventBatch, error)
+}
+
+// EventReplayer re-delivers stored events to the functions whose triggers match.
+type EventReplayer interface {
+	Replay(ctx context.Context, tracked event.TrackedEvent, opts runner.ReplayOpts) (runner.ReplayResult, error)
 }
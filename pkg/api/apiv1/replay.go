@@ -0,0 +1,217 @@
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/oklog/ulid/v2"
+)
+
+// ReplayFunctionRunBody is the request body for replaying a function run.
+type ReplayFunctionRunBody struct {
+	// FromStepID is the hashed step ID to replay the run from.  Memoized state
+	// for every step prior to this step is copied from the original run, and
+	// all subsequent steps are discarded.  If empty, the run is replayed from
+	// the start.
+	FromStepID string `json:"from_step_id,omitempty"`
+	// StepInput optionally replaces the input of the step being replayed from.
+	// This must be a JSON array.
+	StepInput json.RawMessage `json:"step_input,omitempty"`
+	// Overrides replaces the memoized output of the given step IDs prior to
+	// FromStepID.
+	Overrides map[string]json.RawMessage `json:"overrides,omitempty"`
+}
+
+// ReplayFunctionRunResponse is returned when replaying a function run.
+type ReplayFunctionRunResponse struct {
+	RunID         ulid.ULID `json:"run_id"`
+	OriginalRunID ulid.ULID `json:"original_run_id"`
+}
+
+// ReplayFunctionRun schedules a new run of the given run's function, linked to
+// the original run, optionally from a given step with edited memoized state.
+func (a API) ReplayFunctionRun(ctx context.Context, runID ulid.ULID, body ReplayFunctionRunBody) (*ReplayFunctionRunResponse, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+
+	if body.FromStepID == "" && (len(body.Overrides) > 0 || body.StepInput != nil) {
+		return nil, publicerr.Errorf(400, "from_step_id is required when overriding step state")
+	}
+	if body.StepInput != nil {
+		if err := json.Unmarshal(body.StepInput, &[]json.RawMessage{}); err != nil {
+			return nil, publicerr.Wrap(err, 400, "step_input is not a valid JSON array")
+		}
+	}
+	for _, stepID := range slices.Sorted(maps.Keys(body.Overrides)) {
+		if !json.Valid(body.Overrides[stepID]) {
+			return nil, publicerr.Errorf(400, "Override for step %s is not valid JSON", stepID)
+		}
+	}
+
+	fr, err := a.opts.FunctionRunReader.GetFunctionRun(ctx, auth.AccountID(), auth.WorkspaceID(), runID)
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 404, "Unable to load function run: %s", runID)
+	}
+	if fr.WorkspaceID != auth.WorkspaceID() {
+		return nil, publicerr.Errorf(404, "Unable to load function run: %s", runID)
+	}
+
+	fnCQRS, err := a.opts.FunctionReader.GetFunctionByInternalUUID(ctx, auth.WorkspaceID(), fr.FunctionID)
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 404, "Unable to load function for run: %s", runID)
+	}
+	fn, err := fnCQRS.InngestFunction()
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load function config")
+	}
+
+	if err := a.validateReplaySteps(ctx, fr, body); err != nil {
+		return nil, err
+	}
+
+	evts, err := a.runEvents(ctx, auth.WorkspaceID(), fr)
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 404, "Unable to load events for run: %s", runID)
+	}
+
+	var fromStep *execution.ScheduleRequestFromStep
+	if body.FromStepID != "" {
+		fromStep = &execution.ScheduleRequestFromStep{
+			StepID:        body.FromStepID,
+			Input:         body.StepInput,
+			StepOverrides: body.Overrides,
+		}
+	}
+
+	md, err := a.opts.Executor.Schedule(ctx, execution.ScheduleRequest{
+		Function:      *fn,
+		AppID:         fnCQRS.AppID,
+		Events:        evts,
+		OriginalRunID: &fr.RunID,
+		AccountID:     auth.AccountID(),
+		WorkspaceID:   auth.WorkspaceID(),
+		FromStep:      fromStep,
+	})
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to replay function run")
+	}
+
+	return &ReplayFunctionRunResponse{
+		RunID:         md.ID.RunID,
+		OriginalRunID: fr.RunID,
+	}, nil
+}
+
+// validateReplaySteps ensures that the step to replay from and any overridden
+// steps ran in the original run, using the run's trace.  Runs without a trace
+// yet are validated when scheduling the replay.
+func (a API) validateReplaySteps(ctx context.Context, fr *cqrs.FunctionRun, body ReplayFunctionRunBody) error {
+	if body.FromStepID == "" || a.opts.TraceReader == nil {
+		return nil
+	}
+
+	tr, err := a.opts.TraceReader.GetTraceRun(ctx, cqrs.TraceRunIdentifier{RunID: fr.RunID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return publicerr.Wrap(err, 500, "Unable to load run trace")
+	}
+	spans, err := a.opts.TraceReader.GetTraceSpansByRun(ctx, cqrs.TraceRunIdentifier{
+		AccountID:   tr.AccountID,
+		WorkspaceID: tr.WorkspaceID,
+		AppID:       tr.AppID,
+		FunctionID:  tr.FunctionID,
+		TraceID:     tr.TraceID,
+		RunID:       fr.RunID,
+	})
+	if err != nil {
+		return publicerr.Wrap(err, 500, "Unable to load run trace")
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	steps := map[string]bool{}
+	for _, span := range spans {
+		if stepID := span.SpanAttributes[consts.OtelSysStepID]; stepID != "" {
+			steps[stepID] = true
+		}
+	}
+
+	if !steps[body.FromStepID] {
+		return publicerr.Errorf(400, "Step %s not found in run: %s", body.FromStepID, fr.RunID)
+	}
+	for _, stepID := range slices.Sorted(maps.Keys(body.Overrides)) {
+		if !steps[stepID] || stepID == body.FromStepID {
+			return publicerr.Errorf(400, "Override step %s must have run before step %s", stepID, body.FromStepID)
+		}
+	}
+	return nil
+}
+
+// runEvents loads the events which triggered the run, including every event in
+// the run's batch.
+func (a API) runEvents(ctx context.Context, workspaceID uuid.UUID, fr *cqrs.FunctionRun) ([]event.TrackedEvent, error) {
+	ids := []ulid.ULID{fr.EventID}
+	if fr.BatchID != nil {
+		// Batches of a single event aren't stored.
+		batch, err := a.opts.EventReader.GetEventBatchByRunID(ctx, fr.RunID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if batch != nil && len(batch.EventIDs()) > 0 {
+			ids = batch.EventIDs()
+		}
+	}
+
+	tracked := make([]event.TrackedEvent, len(ids))
+	for n, id := range ids {
+		evt, err := a.opts.EventReader.FindEvent(ctx, workspaceID, id)
+		if err != nil {
+			return nil, err
+		}
+		// Keep the original event IDs so that the replay is linked to the same
+		// events as the original run.
+		tracked[n] = event.NewOSSTrackedEventWithID(evt.Event(), evt.InternalID())
+	}
+	return tracked, nil
+}
+
+func (a router) replayFunctionRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	runID, err := ulid.Parse(chi.URLParam(r, "runID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid run ID: %s", chi.URLParam(r, "runID")))
+		return
+	}
+
+	body := ReplayFunctionRunBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid replay request"))
+		return
+	}
+
+	res, err := a.API.ReplayFunctionRun(ctx, runID, body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	_ = WriteResponse(w, res)
+}
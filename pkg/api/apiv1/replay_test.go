@@ -0,0 +1,300 @@
+package apiv1
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/api/apiv1/apiv1auth"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayFunctionRun(t *testing.T) {
+	ctx := context.Background()
+
+	fn := inngest.Function{
+		ID:       uuid.New(),
+		Name:     "fn",
+		Slug:     "fn",
+		Triggers: []inngest.Trigger{{EventTrigger: &inngest.EventTrigger{Event: "test/event"}}},
+		Steps:    []inngest.Step{{ID: "step", Name: "step", URI: "http://localhost/step"}},
+	}
+	config, err := json.Marshal(fn)
+	require.NoError(t, err)
+
+	events := &fakeEventReader{events: map[ulid.ULID]*cqrs.Event{}}
+	addEvent := func() ulid.ULID {
+		id := ulid.MustNew(ulid.Now(), rand.Reader)
+		events.events[id] = &cqrs.Event{
+			ID:          id,
+			WorkspaceID: consts.DevServerEnvId,
+			EventName:   "test/event",
+			EventData:   map[string]any{"id": id.String()},
+		}
+		return id
+	}
+	runs := &fakeRunReader{runs: map[ulid.ULID]*cqrs.FunctionRun{}}
+	addRun := func(workspaceID uuid.UUID, eventID ulid.ULID) *cqrs.FunctionRun {
+		fr := &cqrs.FunctionRun{
+			RunID:       ulid.MustNew(ulid.Now(), rand.Reader),
+			WorkspaceID: workspaceID,
+			FunctionID:  fn.ID,
+			EventID:     eventID,
+		}
+		runs.runs[fr.RunID] = fr
+		return fr
+	}
+	exec := &fakeExecutor{}
+
+	a := API{opts: Opts{
+		AuthFinder:        apiv1auth.NilAuthFinder,
+		Executor:          exec,
+		EventReader:       events,
+		FunctionReader:    &fakeFunctionReader{fn: &cqrs.Function{ID: fn.ID, AppID: uuid.New(), Config: config}},
+		FunctionRunReader: runs,
+	}}
+
+	t.Run("replays the run's event", func(t *testing.T) {
+		evtID := addEvent()
+		fr := addRun(consts.DevServerEnvId, evtID)
+
+		res, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{})
+		require.NoError(t, err)
+		require.Equal(t, fr.RunID, res.OriginalRunID)
+
+		req := exec.last()
+		require.Equal(t, fr.RunID, *req.OriginalRunID)
+		require.Nil(t, req.FromStep)
+		require.Len(t, req.Events, 1)
+		require.Equal(t, evtID, req.Events[0].GetInternalID())
+	})
+
+	t.Run("replays every event in a batch", func(t *testing.T) {
+		ids := []ulid.ULID{addEvent(), addEvent(), addEvent()}
+		fr := addRun(consts.DevServerEnvId, ids[0])
+		batchID := ulid.MustNew(ulid.Now(), rand.Reader)
+		fr.BatchID = &batchID
+		events.batches = map[ulid.ULID]*cqrs.EventBatch{
+			fr.RunID: cqrs.NewEventBatch(cqrs.WithEventBatchRunID(fr.RunID), cqrs.WithEventBatchEventIDs(ids)),
+		}
+
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{})
+		require.NoError(t, err)
+
+		req := exec.last()
+		require.Len(t, req.Events, 3)
+		for n, evt := range req.Events {
+			require.Equal(t, ids[n], evt.GetInternalID())
+			require.Equal(t, ids[n].String(), evt.GetEvent().Data["id"])
+		}
+	})
+
+	t.Run("replays batches of a single event", func(t *testing.T) {
+		evtID := addEvent()
+		fr := addRun(consts.DevServerEnvId, evtID)
+		batchID := ulid.MustNew(ulid.Now(), rand.Reader)
+		fr.BatchID = &batchID
+
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{})
+		require.NoError(t, err)
+
+		req := exec.last()
+		require.Len(t, req.Events, 1)
+		require.Equal(t, evtID, req.Events[0].GetInternalID())
+	})
+
+	t.Run("replays from a step", func(t *testing.T) {
+		fr := addRun(consts.DevServerEnvId, addEvent())
+
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-2",
+			StepInput:  json.RawMessage(`[1]`),
+			Overrides:  map[string]json.RawMessage{"step-1": json.RawMessage(`{"data":"edited"}`)},
+		})
+		require.NoError(t, err)
+
+		req := exec.last()
+		require.Equal(t, &execution.ScheduleRequestFromStep{
+			StepID:        "step-2",
+			Input:         json.RawMessage(`[1]`),
+			StepOverrides: map[string]json.RawMessage{"step-1": json.RawMessage(`{"data":"edited"}`)},
+		}, req.FromStep)
+	})
+
+	t.Run("runs in other workspaces are not found", func(t *testing.T) {
+		fr := addRun(uuid.New(), addEvent())
+		scheduled := len(exec.reqs)
+
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{})
+		requireStatus(t, err, 404)
+		require.Contains(t, err.Error(), fr.RunID.String())
+		require.Len(t, exec.reqs, scheduled)
+	})
+
+	t.Run("unknown runs are not found", func(t *testing.T) {
+		_, err := a.ReplayFunctionRun(ctx, ulid.MustNew(ulid.Now(), rand.Reader), ReplayFunctionRunBody{})
+		requireStatus(t, err, 404)
+	})
+
+	t.Run("overrides require a step", func(t *testing.T) {
+		fr := addRun(consts.DevServerEnvId, addEvent())
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			Overrides: map[string]json.RawMessage{"step-1": json.RawMessage(`{}`)},
+		})
+		requireStatus(t, err, 400)
+	})
+
+	t.Run("step input must be an array", func(t *testing.T) {
+		fr := addRun(consts.DevServerEnvId, addEvent())
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-2",
+			StepInput:  json.RawMessage(`{}`),
+		})
+		requireStatus(t, err, 400)
+
+		_, err = a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-2",
+			StepInput:  json.RawMessage(" \n[1]"),
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("overrides must be valid JSON", func(t *testing.T) {
+		fr := addRun(consts.DevServerEnvId, addEvent())
+		scheduled := len(exec.reqs)
+
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-2",
+			Overrides:  map[string]json.RawMessage{"step-1": json.RawMessage(`{"data":`)},
+		})
+		requireStatus(t, err, 400)
+		require.Contains(t, err.Error(), "step-1")
+		require.Len(t, exec.reqs, scheduled)
+	})
+
+	t.Run("steps must be in the run's trace", func(t *testing.T) {
+		fr := addRun(consts.DevServerEnvId, addEvent())
+		traces := &fakeTraceReader{
+			runs: map[ulid.ULID]*cqrs.TraceRun{
+				fr.RunID: {RunID: fr.RunID.String(), WorkspaceID: consts.DevServerEnvId},
+			},
+			spans: []*cqrs.Span{
+				{SpanAttributes: map[string]string{consts.OtelSysStepID: "step-1"}},
+				{SpanAttributes: map[string]string{consts.OtelSysStepID: "step-2"}},
+			},
+		}
+		a := a
+		a.opts.TraceReader = traces
+		scheduled := len(exec.reqs)
+
+		_, err := a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-3",
+		})
+		requireStatus(t, err, 400)
+		require.Contains(t, err.Error(), "step-3")
+
+		_, err = a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-2",
+			Overrides:  map[string]json.RawMessage{"unknown": json.RawMessage(`{}`)},
+		})
+		requireStatus(t, err, 400)
+		require.Contains(t, err.Error(), "unknown")
+
+		_, err = a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-2",
+			Overrides:  map[string]json.RawMessage{"step-2": json.RawMessage(`{}`)},
+		})
+		requireStatus(t, err, 400)
+		require.Len(t, exec.reqs, scheduled)
+
+		_, err = a.ReplayFunctionRun(ctx, fr.RunID, ReplayFunctionRunBody{
+			FromStepID: "step-2",
+			Overrides:  map[string]json.RawMessage{"step-1": json.RawMessage(`{}`)},
+		})
+		require.NoError(t, err)
+		require.Len(t, exec.reqs, scheduled+1)
+	})
+}
+
+func requireStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	perr := publicerr.Error{}
+	require.True(t, errors.As(err, &perr), "expected a public error, got %v", err)
+	require.Equal(t, status, perr.Status)
+}
+
+type fakeEventReader struct {
+	EventReader
+
+	events  map[ulid.ULID]*cqrs.Event
+	batches map[ulid.ULID]*cqrs.EventBatch
+}
+
+func (f *fakeEventReader) FindEvent(ctx context.Context, workspaceID uuid.UUID, id ulid.ULID) (*cqrs.Event, error) {
+	evt, ok := f.events[id]
+	if !ok || evt.WorkspaceID != workspaceID {
+		return nil, sql.ErrNoRows
+	}
+	return evt, nil
+}
+
+func (f *fakeEventReader) GetEventBatchByRunID(ctx context.Context, runID ulid.ULID) (*cqrs.EventBatch, error) {
+	batch, ok := f.batches[runID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return batch, nil
+}
+
+type fakeRunReader struct {
+	cqrs.APIV1FunctionRunReader
+
+	runs map[ulid.ULID]*cqrs.FunctionRun
+}
+
+func (f *fakeRunReader) GetFunctionRun(ctx context.Context, accountID, workspaceID uuid.UUID, runID ulid.ULID) (*cqrs.FunctionRun, error) {
+	fr, ok := f.runs[runID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return fr, nil
+}
+
+type fakeFunctionReader struct {
+	cqrs.FunctionReader
+
+	fn *cqrs.Function
+}
+
+func (f *fakeFunctionReader) GetFunctionByInternalUUID(ctx context.Context, wsID, fnID uuid.UUID) (*cqrs.Function, error) {
+	if fnID != f.fn.ID {
+		return nil, sql.ErrNoRows
+	}
+	return f.fn, nil
+}
+
+type fakeExecutor struct {
+	execution.Executor
+
+	reqs []execution.ScheduleRequest
+}
+
+func (e *fakeExecutor) Schedule(ctx context.Context, r execution.ScheduleRequest) (*sv2.Metadata, error) {
+	e.reqs = append(e.reqs, r)
+	return &sv2.Metadata{ID: sv2.ID{RunID: ulid.MustNew(ulid.Now(), rand.Reader)}}, nil
+}
+
+func (e *fakeExecutor) last() execution.ScheduleRequest {
+	return e.reqs[len(e.reqs)-1]
+}
@@ -3,13 +3,22 @@ package apiv1
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/dateutil"
+	"github.com/khulnasoft/inngest/pkg/enums"
 	"github.com/khulnasoft/inngest/pkg/execution"
 	"github.com/khulnasoft/inngest/pkg/execution/queue"
 	"github.com/khulnasoft/inngest/pkg/execution/state/v2"
 	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/khulnasoft/inngest/pkg/run"
+	"github.com/khulnasoft/inngest/pkg/util"
+	rpbv2 "github.com/khulnasoft/inngest/proto/gen/run/v2"
 	"github.com/oklog/ulid/v2"
 )
 
@@ -135,3 +144,253 @@ func (a router) GetFunctionRunJobs(w http.ResponseWriter, r *http.Request) {
 
 	_ = WriteCachedResponse(w, jobs, 5*time.Second)
 }
+
+const (
+	DefaultRuns = 40
+	MaxRuns     = 400
+)
+
+// GetRunsOpts filters the runs returned by GetRuns.
+type GetRunsOpts struct {
+	AppIDs      []uuid.UUID
+	FunctionIDs []uuid.UUID
+	Status      []enums.RunStatus
+	// From and Until filter runs by the time they were queued.
+	From   time.Time
+	Until  time.Time
+	Cursor string
+	Limit  int
+	// Ascending returns the oldest runs first.  By default the newest runs
+	// are returned first.
+	Ascending bool
+	// TimeField is the time which From, Until and the order apply to,
+	// allowing runs to be listed by when they started or ended.  Defaults
+	// to the time runs were queued.
+	TimeField enums.TraceRunTime
+}
+
+// GetRuns returns runs matching the given filters.  Each run includes a cursor
+// which may be used to return the next page of runs.
+func (a API) GetRuns(ctx context.Context, opts GetRunsOpts) ([]*cqrs.TraceRun, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.TraceReader == nil {
+		return nil, publicerr.Errorf(501, "Listing runs is not supported")
+	}
+
+	if opts.Until.IsZero() {
+		opts.Until = time.Now()
+	}
+	dir := enums.TraceRunOrderDesc
+	if opts.Ascending {
+		dir = enums.TraceRunOrderAsc
+	}
+
+	runs, err := a.opts.TraceReader.GetTraceRuns(ctx, cqrs.GetTraceRunOpt{
+		Filter: cqrs.GetTraceRunFilter{
+			AccountID:   auth.AccountID(),
+			WorkspaceID: auth.WorkspaceID(),
+			AppID:       opts.AppIDs,
+			FunctionID:  opts.FunctionIDs,
+			TimeField:   opts.TimeField,
+			From:        opts.From,
+			Until:       opts.Until,
+			Status:      opts.Status,
+		},
+		Order: []cqrs.GetTraceRunOrder{
+			{Field: opts.TimeField, Direction: dir},
+		},
+		Cursor: opts.Cursor,
+		Items:  uint(util.Bound(opts.Limit, 1, MaxRuns)),
+	})
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to query runs")
+	}
+	return runs, nil
+}
+
+func (a router) getRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	opts := GetRunsOpts{
+		Cursor:    r.FormValue("cursor"),
+		Ascending: r.FormValue("order") == "asc",
+	}
+
+	opts.Limit, _ = strconv.Atoi(r.FormValue("limit"))
+	if opts.Limit == 0 {
+		opts.Limit = DefaultRuns
+	}
+
+	for _, param := range []struct {
+		name string
+		ids  *[]uuid.UUID
+	}{
+		{"app_id", &opts.AppIDs},
+		{"function_id", &opts.FunctionIDs},
+	} {
+		for _, v := range r.Form[param.name] {
+			id, err := uuid.Parse(v)
+			if err != nil {
+				_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid %s query parameter", param.name))
+				return
+			}
+			*param.ids = append(*param.ids, id)
+		}
+	}
+
+	if tf := r.FormValue("time_field"); tf != "" {
+		field, err := enums.TraceRunTimeString(tf)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid time_field query parameter: %s", tf))
+			return
+		}
+		opts.TimeField = field
+	}
+
+	for _, v := range r.Form["status"] {
+		for _, s := range strings.Split(v, ",") {
+			status, err := enums.RunStatusString(strings.TrimSpace(s))
+			if err != nil {
+				_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid status query parameter: %s", s))
+				return
+			}
+			opts.Status = append(opts.Status, status)
+		}
+	}
+
+	if from := r.FormValue("from"); from != "" {
+		parsed, err := dateutil.Parse(from)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid from query parameter"))
+			return
+		}
+		opts.From = parsed
+	}
+	if until := r.FormValue("until"); until != "" {
+		parsed, err := dateutil.Parse(until)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid until query parameter"))
+			return
+		}
+		opts.Until = parsed
+	}
+
+	runs, err := a.GetRuns(ctx, opts)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, runs)
+}
+
+// RunSpan is a single span within a run's trace, eg. a step or an attempt.
+type RunSpan struct {
+	SpanID     string     `json:"span_id"`
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	StepOp     string     `json:"step_op,omitempty"`
+	StepID     *string    `json:"step_id,omitempty"`
+	Attempts   int        `json:"attempts"`
+	QueuedAt   time.Time  `json:"queued_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	DurationMS int64      `json:"duration_ms"`
+	Children   []*RunSpan `json:"children,omitempty"`
+}
+
+// GetRunTrace returns the trace for the given run as a tree of spans, rooted at
+// the function run's span.
+func (a API) GetRunTrace(ctx context.Context, runID ulid.ULID) (*RunSpan, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.TraceReader == nil {
+		return nil, publicerr.Errorf(501, "Run traces are not supported")
+	}
+
+	tr, err := a.opts.TraceReader.GetTraceRun(ctx, cqrs.TraceRunIdentifier{RunID: runID})
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 404, "Unable to load run: %s", runID)
+	}
+	if tr.WorkspaceID != auth.WorkspaceID() {
+		return nil, publicerr.Errorf(404, "Unable to load run: %s", runID)
+	}
+
+	id := cqrs.TraceRunIdentifier{
+		AccountID:   auth.AccountID(),
+		WorkspaceID: auth.WorkspaceID(),
+		AppID:       tr.AppID,
+		FunctionID:  tr.FunctionID,
+		TraceID:     tr.TraceID,
+		RunID:       runID,
+	}
+	spans, err := a.opts.TraceReader.GetTraceSpansByRun(ctx, id)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load run trace")
+	}
+	if len(spans) == 0 {
+		return nil, publicerr.Errorf(404, "No trace found for run: %s", runID)
+	}
+
+	tree, err := run.NewRunTree(run.RunTreeOpts{
+		AccountID:   id.AccountID,
+		WorkspaceID: id.WorkspaceID,
+		AppID:       id.AppID,
+		FunctionID:  id.FunctionID,
+		RunID:       runID,
+		Spans:       spans,
+	})
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to build run trace")
+	}
+	root, err := tree.ToRunSpan(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to build run trace")
+	}
+	return toRunSpan(root), nil
+}
+
+func (a router) getRunTrace(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	runID, err := ulid.Parse(chi.URLParam(r, "runID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid run ID: %s", chi.URLParam(r, "runID")))
+		return
+	}
+	trace, err := a.GetRunTrace(ctx, runID)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteCachedResponse(w, trace, 3*time.Second)
+}
+
+func toRunSpan(s *rpbv2.RunSpan) *RunSpan {
+	span := &RunSpan{
+		SpanID:     s.GetSpanId(),
+		Name:       s.GetName(),
+		Status:     s.GetStatus().String(),
+		StepID:     s.StepId,
+		Attempts:   int(s.GetAttempts()),
+		QueuedAt:   s.GetQueuedAt().AsTime(),
+		DurationMS: s.GetDurationMs(),
+	}
+	if s.StepOp != nil {
+		span.StepOp = s.GetStepOp().String()
+	}
+	if s.StartedAt != nil {
+		t := s.GetStartedAt().AsTime()
+		span.StartedAt = &t
+	}
+	if s.EndedAt != nil {
+		t := s.GetEndedAt().AsTime()
+		span.EndedAt = &t
+	}
+	for _, c := range s.GetChildren() {
+		span.Children = append(span.Children, toRunSpan(c))
+	}
+	return span
+}
@@ -0,0 +1,99 @@
+package apiv1
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/api/apiv1/apiv1auth"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRuns(t *testing.T) {
+	traces := &fakeTraceReader{}
+	h := AddRoutes(chi.NewRouter(), Opts{TraceReader: traces})
+
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	t.Run("runs are ordered by when they were queued by default", func(t *testing.T) {
+		require.Equal(t, 200, get("/runs"))
+		require.Equal(t, enums.TraceRunTimeQueuedAt, traces.opt.Filter.TimeField)
+		require.Equal(t, []cqrs.GetTraceRunOrder{
+			{Field: enums.TraceRunTimeQueuedAt, Direction: enums.TraceRunOrderDesc},
+		}, traces.opt.Order)
+	})
+
+	t.Run("runs are listed by the given time", func(t *testing.T) {
+		require.Equal(t, 200, get("/runs?time_field=ended_at&order=asc"))
+		require.Equal(t, enums.TraceRunTimeEndedAt, traces.opt.Filter.TimeField)
+		require.Equal(t, []cqrs.GetTraceRunOrder{
+			{Field: enums.TraceRunTimeEndedAt, Direction: enums.TraceRunOrderAsc},
+		}, traces.opt.Order)
+	})
+
+	t.Run("invalid times are rejected", func(t *testing.T) {
+		require.Equal(t, 400, get("/runs?time_field=finished_at"))
+	})
+}
+
+func TestGetRunTrace(t *testing.T) {
+	ctx := context.Background()
+	runID := ulid.MustNew(ulid.Now(), rand.Reader)
+	traces := &fakeTraceReader{
+		runs: map[ulid.ULID]*cqrs.TraceRun{
+			runID: {RunID: runID.String(), WorkspaceID: uuid.New()},
+		},
+	}
+	a := API{opts: Opts{AuthFinder: apiv1auth.NilAuthFinder, TraceReader: traces}}
+
+	t.Run("runs in other workspaces are not found", func(t *testing.T) {
+		_, err := a.GetRunTrace(ctx, runID)
+		requireStatus(t, err, 404)
+		require.False(t, traces.loadedSpans)
+	})
+
+	t.Run("unknown runs are not found", func(t *testing.T) {
+		_, err := a.GetRunTrace(ctx, ulid.MustNew(ulid.Now(), rand.Reader))
+		requireStatus(t, err, 404)
+	})
+}
+
+type fakeTraceReader struct {
+	cqrs.TraceReader
+
+	// opt records the options of the last query for runs.
+	opt         cqrs.GetTraceRunOpt
+	runs        map[ulid.ULID]*cqrs.TraceRun
+	spans       []*cqrs.Span
+	loadedSpans bool
+}
+
+func (f *fakeTraceReader) GetTraceRuns(ctx context.Context, opt cqrs.GetTraceRunOpt) ([]*cqrs.TraceRun, error) {
+	f.opt = opt
+	return []*cqrs.TraceRun{}, nil
+}
+
+func (f *fakeTraceReader) GetTraceRun(ctx context.Context, id cqrs.TraceRunIdentifier) (*cqrs.TraceRun, error) {
+	tr, ok := f.runs[id.RunID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return tr, nil
+}
+
+func (f *fakeTraceReader) GetTraceSpansByRun(ctx context.Context, id cqrs.TraceRunIdentifier) ([]*cqrs.Span, error) {
+	f.loadedSpans = true
+	return f.spans, nil
+}
@@ -0,0 +1,68 @@
+package apiv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+)
+
+// SendSignalBody is the request body for resuming a run waiting on a signal.
+type SendSignalBody struct {
+	// Data is the data to resume the run with.  This is returned as the
+	// output of the `step.waitForSignal` call.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// SendSignal resumes the run waiting for the given signal within the authenticated
+// workspace.
+func (a API) SendSignal(ctx context.Context, signal string, body SendSignalBody) (*execution.ResumeSignalResult, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+
+	if signal == "" {
+		return nil, publicerr.Errorf(400, "A signal name is required")
+	}
+
+	res, err := a.opts.Executor.ResumeSignal(ctx, auth.WorkspaceID(), signal, body.Data)
+	if errors.Is(err, state.ErrSignalPauseNotFound) {
+		return nil, publicerr.Wrapf(err, 404, "No run is waiting for signal: %s", signal)
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Error sending signal")
+	}
+	return res, nil
+}
+
+func (a router) sendSignal(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	signal, err := url.PathUnescape(chi.URLParam(r, "signal"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid signal name"))
+		return
+	}
+
+	body := SendSignalBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid signal request"))
+		return
+	}
+
+	res, err := a.API.SendSignal(ctx, signal, body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	_ = WriteResponse(w, res)
+}
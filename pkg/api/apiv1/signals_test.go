@@ -0,0 +1,76 @@
+package apiv1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/api/apiv1/apiv1auth"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendSignal(t *testing.T) {
+	ctx := context.Background()
+	runID := ulid.MustNew(ulid.Now(), rand.Reader)
+	exec := &fakeSignalExecutor{
+		signals: map[string]ulid.ULID{"signal": runID},
+	}
+	a := API{opts: Opts{
+		AuthFinder: apiv1auth.NilAuthFinder,
+		Executor:   exec,
+	}}
+
+	t.Run("resumes the run waiting for the signal", func(t *testing.T) {
+		res, err := a.SendSignal(ctx, "signal", SendSignalBody{Data: json.RawMessage(`{"ok":true}`)})
+		require.NoError(t, err)
+		require.Equal(t, runID, res.RunID)
+		require.Equal(t, consts.DevServerEnvId, exec.workspaceID)
+		require.JSONEq(t, `{"ok":true}`, string(exec.data))
+	})
+
+	t.Run("signals without a waiting run are not found", func(t *testing.T) {
+		_, err := a.SendSignal(ctx, "unknown", SendSignalBody{})
+		requireStatus(t, err, 404)
+		require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+	})
+
+	t.Run("a signal is required", func(t *testing.T) {
+		_, err := a.SendSignal(ctx, "", SendSignalBody{})
+		requireStatus(t, err, 400)
+	})
+
+	t.Run("executor errors", func(t *testing.T) {
+		exec.err = fmt.Errorf("unavailable")
+		defer func() { exec.err = nil }()
+		_, err := a.SendSignal(ctx, "signal", SendSignalBody{})
+		requireStatus(t, err, 500)
+	})
+}
+
+type fakeSignalExecutor struct {
+	execution.Executor
+
+	signals     map[string]ulid.ULID
+	err         error
+	workspaceID uuid.UUID
+	data        json.RawMessage
+}
+
+func (e *fakeSignalExecutor) ResumeSignal(ctx context.Context, workspaceID uuid.UUID, signalID string, data json.RawMessage) (*execution.ResumeSignalResult, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	runID, ok := e.signals[signalID]
+	if !ok {
+		return nil, state.ErrSignalPauseNotFound
+	}
+	e.workspaceID, e.data = workspaceID, data
+	return &execution.ResumeSignalResult{RunID: runID}, nil
+}
@@ -0,0 +1,340 @@
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/khulnasoft/inngest/pkg/webhooks"
+)
+
+// WebhookSource is a webhook source returned by the API.  Secrets are never
+// returned.
+type WebhookSource struct {
+	*cqrs.WebhookSource
+	// HasSecret is true if the source has a secret for verifying signatures.
+	HasSecret bool `json:"has_secret"`
+}
+
+func newWebhookSource(s *cqrs.WebhookSource) WebhookSource {
+	return WebhookSource{WebhookSource: s, HasSecret: s.Secret != ""}
+}
+
+// WebhookSourceBody creates or updates a webhook source.
+type WebhookSourceBody struct {
+	// Name is the unique name of the source.
+	Name string `json:"name"`
+	// Path is the path the source receives requests at, relative to /webhooks/.
+	// Defaults to the source's name.
+	Path string `json:"path"`
+	// Secret is the secret used to verify signatures.  When updating a source, a
+	// nil secret keeps the existing secret.
+	Secret *string `json:"secret"`
+	// Signature is the signature verification preset: one of "none",
+	// "hmac-sha256", "github", "stripe" or "standard".  Defaults to "hmac-sha256"
+	// if a secret is set, otherwise "none".
+	Signature cqrs.WebhookSignature `json:"signature"`
+	// SignatureHeader is the header containing the signature for the
+	// "hmac-sha256" preset.  Defaults to "X-Signature".
+	SignatureHeader string `json:"signature_header"`
+	// Transform is an expression which maps the request's body, headers, query
+	// and method into an event or list of events.  Defaults to sending the body
+	// as the data of a "webhook/<name>" event.
+	Transform string `json:"transform"`
+}
+
+// TestWebhookTransformBody evaluates a transform against a sample request
+// without sending any events.
+type TestWebhookTransformBody struct {
+	Transform string            `json:"transform"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	Query     map[string]string `json:"query"`
+	Body      json.RawMessage   `json:"body"`
+}
+
+// GetWebhookSources returns all webhook sources.
+func (a API) GetWebhookSources(ctx context.Context) ([]WebhookSource, error) {
+	if err := a.checkWebhookSources(ctx); err != nil {
+		return nil, err
+	}
+
+	sources, err := a.opts.WebhookSourceReadWriter.GetWebhookSources(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load webhook sources")
+	}
+	res := make([]WebhookSource, len(sources))
+	for i, s := range sources {
+		res[i] = newWebhookSource(s)
+	}
+	return res, nil
+}
+
+func (a router) getWebhookSources(w http.ResponseWriter, r *http.Request) {
+	sources, err := a.API.GetWebhookSources(r.Context())
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, sources)
+}
+
+// GetWebhookSource returns a single webhook source.
+func (a API) GetWebhookSource(ctx context.Context, id uuid.UUID) (*WebhookSource, error) {
+	if err := a.checkWebhookSources(ctx); err != nil {
+		return nil, err
+	}
+
+	s, err := a.getWebhookSource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	res := newWebhookSource(s)
+	return &res, nil
+}
+
+func (a router) getWebhookSource(w http.ResponseWriter, r *http.Request) {
+	id, err := webhookSourceID(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	source, err := a.API.GetWebhookSource(r.Context(), id)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, source)
+}
+
+// CreateWebhookSource creates a new webhook source.
+func (a API) CreateWebhookSource(ctx context.Context, body WebhookSourceBody) (*WebhookSource, error) {
+	if err := a.checkWebhookSources(ctx); err != nil {
+		return nil, err
+	}
+
+	s := cqrs.WebhookSource{
+		ID:              uuid.New(),
+		Name:            body.Name,
+		Path:            body.Path,
+		Signature:       body.Signature,
+		SignatureHeader: body.SignatureHeader,
+		Transform:       body.Transform,
+	}
+	if body.Secret != nil {
+		s.Secret = *body.Secret
+	}
+	if err := a.validateWebhookSource(ctx, &s); err != nil {
+		return nil, err
+	}
+
+	saved, err := a.opts.WebhookSourceReadWriter.InsertWebhookSource(ctx, s)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to create webhook source")
+	}
+	res := newWebhookSource(saved)
+	return &res, nil
+}
+
+func (a router) createWebhookSource(w http.ResponseWriter, r *http.Request) {
+	body := WebhookSourceBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid webhook source request"))
+		return
+	}
+	source, err := a.API.CreateWebhookSource(r.Context(), body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = WriteResponse(w, source)
+}
+
+// UpdateWebhookSource replaces a webhook source's configuration.
+func (a API) UpdateWebhookSource(ctx context.Context, id uuid.UUID, body WebhookSourceBody) (*WebhookSource, error) {
+	if err := a.checkWebhookSources(ctx); err != nil {
+		return nil, err
+	}
+
+	existing, err := a.getWebhookSource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s := cqrs.WebhookSource{
+		ID:              id,
+		Name:            body.Name,
+		Path:            body.Path,
+		Secret:          existing.Secret,
+		Signature:       body.Signature,
+		SignatureHeader: body.SignatureHeader,
+		Transform:       body.Transform,
+	}
+	if body.Secret != nil {
+		s.Secret = *body.Secret
+	}
+	if err := a.validateWebhookSource(ctx, &s); err != nil {
+		return nil, err
+	}
+
+	saved, err := a.opts.WebhookSourceReadWriter.UpdateWebhookSource(ctx, s)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to update webhook source")
+	}
+	res := newWebhookSource(saved)
+	return &res, nil
+}
+
+func (a router) updateWebhookSource(w http.ResponseWriter, r *http.Request) {
+	id, err := webhookSourceID(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	body := WebhookSourceBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid webhook source request"))
+		return
+	}
+	source, err := a.API.UpdateWebhookSource(r.Context(), id, body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, source)
+}
+
+// DeleteWebhookSource deletes a webhook source.  Requests sent to its path are
+// rejected once deleted.
+func (a API) DeleteWebhookSource(ctx context.Context, id uuid.UUID) error {
+	if err := a.checkWebhookSources(ctx); err != nil {
+		return err
+	}
+	if _, err := a.getWebhookSource(ctx, id); err != nil {
+		return err
+	}
+	if err := a.opts.WebhookSourceReadWriter.DeleteWebhookSource(ctx, id); err != nil {
+		return publicerr.Wrap(err, 500, "Unable to delete webhook source")
+	}
+	return nil
+}
+
+func (a router) deleteWebhookSource(w http.ResponseWriter, r *http.Request) {
+	id, err := webhookSourceID(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	if err := a.API.DeleteWebhookSource(r.Context(), id); err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestWebhookTransform evaluates a transform against a sample request, returning
+// the events which would be sent.
+func (a API) TestWebhookTransform(ctx context.Context, body TestWebhookTransformBody) ([]event.Event, error) {
+	if _, err := a.opts.AuthFinder(ctx); err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+
+	req := webhooks.Request{
+		Method: body.Method,
+		Header: http.Header{},
+		Query:  url.Values{},
+		Body:   body.Body,
+	}
+	if req.Method == "" {
+		req.Method = http.MethodPost
+	}
+	for k, v := range body.Headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range body.Query {
+		req.Query.Set(k, v)
+	}
+
+	evts, err := webhooks.Transform(ctx, body.Transform, req)
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 400, "Unable to transform webhook: %s", err)
+	}
+	return evts, nil
+}
+
+func (a router) testWebhookTransform(w http.ResponseWriter, r *http.Request) {
+	body := TestWebhookTransformBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid transform request"))
+		return
+	}
+	evts, err := a.API.TestWebhookTransform(r.Context(), body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, evts)
+}
+
+func (a API) checkWebhookSources(ctx context.Context) error {
+	if _, err := a.opts.AuthFinder(ctx); err != nil {
+		return publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.WebhookSourceReadWriter == nil {
+		return publicerr.Errorf(501, "Webhook sources are not enabled")
+	}
+	return nil
+}
+
+func (a API) getWebhookSource(ctx context.Context, id uuid.UUID) (*cqrs.WebhookSource, error) {
+	s, err := a.opts.WebhookSourceReadWriter.GetWebhookSource(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, publicerr.Wrapf(err, 404, "Webhook source not found: %s", id)
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load webhook source")
+	}
+	return s, nil
+}
+
+// validateWebhookSource checks the source and ensures its name and path aren't
+// used by another source.
+func (a API) validateWebhookSource(ctx context.Context, s *cqrs.WebhookSource) error {
+	if err := webhooks.Check(ctx, s); err != nil {
+		return publicerr.Wrap(err, 400, err.Error())
+	}
+
+	sources, err := a.opts.WebhookSourceReadWriter.GetWebhookSources(ctx)
+	if err != nil {
+		return publicerr.Wrap(err, 500, "Unable to load webhook sources")
+	}
+	for _, existing := range sources {
+		if existing.ID == s.ID {
+			continue
+		}
+		if existing.Name == s.Name {
+			return publicerr.Errorf(409, "A webhook source named %s already exists", s.Name)
+		}
+		if existing.Path == s.Path {
+			return publicerr.Errorf(409, "The webhook source %s already receives requests at /webhooks/%s", existing.Name, s.Path)
+		}
+	}
+	return nil
+}
+
+func webhookSourceID(r *http.Request) (uuid.UUID, error) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return uuid.UUID{}, publicerr.Wrap(err, 400, "Invalid webhook source ID")
+	}
+	return id, nil
+}
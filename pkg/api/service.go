@@ -10,7 +10,10 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/khulnasoft/inngest/pkg/config"
 	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
 	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/eventdedupe"
+	"github.com/khulnasoft/inngest/pkg/eventschema"
 	"github.com/khulnasoft/inngest/pkg/logger"
 	"github.com/khulnasoft/inngest/pkg/pubsub"
 	"github.com/khulnasoft/inngest/pkg/service"
@@ -45,6 +48,18 @@ type APIServiceOptions struct {
 	// the server will still boot but core actions such as syncing, runs, and
 	// ingesting events will not work.
 	RequireKeys bool
+
+	// Schemas validates incoming events against the JSON Schemas registered for
+	// their names.
+	Schemas *eventschema.Registry
+
+	// WebhookSources loads the webhook sources which transform requests into
+	// events.
+	WebhookSources cqrs.WebhookSourceReader
+
+	// Dedupe drops events sent with the same ID as an earlier event within the
+	// dedupe window.
+	Dedupe *eventdedupe.Deduper
 }
 
 func NewService(opts APIServiceOptions) service.Service {
@@ -53,6 +68,9 @@ func NewService(opts APIServiceOptions) service.Service {
 		mounts:         opts.Mounts,
 		localEventKeys: opts.LocalEventKeys,
 		requireKeys:    opts.RequireKeys,
+		schemas:        opts.Schemas,
+		webhookSources: opts.WebhookSources,
+		dedupe:         opts.Dedupe,
 	}
 }
 
@@ -73,6 +91,10 @@ type apiServer struct {
 	// the server will still boot but core actions such as syncing, runs, and
 	// ingesting events will not work.
 	requireKeys bool
+
+	schemas        *eventschema.Registry
+	webhookSources cqrs.WebhookSourceReader
+	dedupe         *eventdedupe.Deduper
 }
 
 func (a *apiServer) Name() string {
@@ -88,6 +110,9 @@ func (a *apiServer) Pre(ctx context.Context) error {
 		EventHandler:   a.handleEvent,
 		LocalEventKeys: a.localEventKeys,
 		RequireKeys:    a.requireKeys,
+		Schemas:        a.schemas,
+		WebhookSources: a.webhookSources,
+		Dedupe:         a.dedupe,
 	})
 	if err != nil {
 		return err
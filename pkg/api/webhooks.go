@@ -0,0 +1,95 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/coreapi/apiutil"
+	"github.com/khulnasoft/inngest/pkg/publicerr"
+	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
+	"github.com/khulnasoft/inngest/pkg/webhooks"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// ReceiveWebhook receives a request sent to a webhook source, verifying its
+// signature and transforming it into events using the source's transform.
+func (a API) ReceiveWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	if a.webhookSources == nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(501, "Webhook sources are not enabled"))
+		return
+	}
+
+	path := strings.Trim(chi.URLParam(r, "*"), "/")
+	source, err := a.webhookSources.GetWebhookSourceByPath(ctx, path)
+	if errors.Is(err, sql.ErrNoRows) {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(404, "Webhook source not found"))
+		return
+	}
+	if err != nil {
+		a.log.Error().Err(err).Str("path", path).Msg("error loading webhook source")
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 500, "Unable to load webhook source"))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, int64(consts.AbsoluteMaxEventSize)+1))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Unable to read request body"))
+		return
+	}
+	if len(body) > consts.AbsoluteMaxEventSize {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(413, "Request body exceeds the maximum size of %d bytes", consts.AbsoluteMaxEventSize))
+		return
+	}
+
+	if err := webhooks.Verify(*source, r.Header, body, time.Now()); err != nil {
+		a.log.Warn().Err(err).Str("source", source.Name).Msg("rejecting webhook; signature verification failed")
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 401, "Unable to verify webhook: %s", err))
+		return
+	}
+
+	evts, err := webhooks.Transform(ctx, source.Transform, webhooks.Request{
+		Method: r.Method,
+		Header: r.Header,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	if err != nil {
+		a.log.Warn().Err(err).Str("source", source.Name).Msg("error transforming webhook")
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Unable to transform webhook: %s", err))
+		return
+	}
+
+	// Create a new trace that may have a link to a previous one
+	ctx = itrace.UserTracer().Propagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	ids := make([]string, 0, len(evts))
+	for _, evt := range evts {
+		id, err := a.ingest(ctx, evt)
+		if err != nil {
+			w.WriteHeader(400)
+			_ = json.NewEncoder(w).Encode(apiutil.EventAPIResponse{
+				IDs:    ids,
+				Status: 400,
+				Error:  err.Error(),
+			})
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	w.WriteHeader(200)
+	_ = json.NewEncoder(w).Encode(apiutil.EventAPIResponse{
+		IDs:    ids,
+		Status: 200,
+	})
+}
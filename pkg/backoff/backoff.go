@@ -63,3 +63,56 @@ func GetLinearBackoffFunc(interval time.Duration) BackoffFunc {
 		return time.Now().Add(interval)
 	}
 }
+
+// Kind represents the strategy used to space out retries of a step.
+type Kind string
+
+const (
+	// KindExponential doubles the interval between each attempt.
+	KindExponential Kind = "exponential"
+	// KindLinear increases the interval between each attempt by the base interval.
+	KindLinear Kind = "linear"
+	// KindFixed uses the same interval between each attempt.
+	KindFixed Kind = "fixed"
+
+	// maxPolicyBackoff is the maximum delay between attempts for backoff policies.
+	maxPolicyBackoff = 12 * time.Hour
+)
+
+// IsValid returns whether the backoff kind is known.
+func (k Kind) IsValid() bool {
+	switch k {
+	case KindExponential, KindLinear, KindFixed:
+		return true
+	}
+	return false
+}
+
+// GetPolicyBackoffFunc returns a backoff function for the given kind, where
+// interval is the delay before the first retry.  A random jitter between 0 and
+// jitter is added to every delay.  Delays are capped at 12 hours.
+func GetPolicyBackoffFunc(kind Kind, interval, jitter time.Duration) BackoffFunc {
+	return func(attemptNum int) time.Time {
+		if attemptNum < 0 {
+			attemptNum = 0
+		}
+
+		dur := interval
+		switch kind {
+		case KindExponential:
+			for n := 0; n < attemptNum && dur < maxPolicyBackoff; n++ {
+				dur *= 2
+			}
+		case KindLinear:
+			dur = interval * time.Duration(attemptNum+1)
+		}
+		if dur > maxPolicyBackoff {
+			dur = maxPolicyBackoff
+		}
+
+		if jitter > 0 {
+			dur += time.Duration(rand.Int63n(int64(jitter)))
+		}
+		return time.Now().Add(dur)
+	}
+}
@@ -0,0 +1,40 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyBackoffFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     Kind
+		interval time.Duration
+		attempt  int
+		expected time.Duration
+	}{
+		{name: "exponential first retry", kind: KindExponential, interval: 10 * time.Second, attempt: 0, expected: 10 * time.Second},
+		{name: "exponential third retry", kind: KindExponential, interval: 10 * time.Second, attempt: 2, expected: 40 * time.Second},
+		{name: "exponential is capped", kind: KindExponential, interval: 10 * time.Second, attempt: 100, expected: maxPolicyBackoff},
+		{name: "linear first retry", kind: KindLinear, interval: time.Minute, attempt: 0, expected: time.Minute},
+		{name: "linear third retry", kind: KindLinear, interval: time.Minute, attempt: 2, expected: 3 * time.Minute},
+		{name: "fixed", kind: KindFixed, interval: 5 * time.Second, attempt: 7, expected: 5 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			now := time.Now()
+			at := GetPolicyBackoffFunc(test.kind, test.interval, 0)(test.attempt)
+			require.WithinDuration(t, now.Add(test.expected), at, time.Second)
+		})
+	}
+
+	t.Run("jitter is added to the delay", func(t *testing.T) {
+		now := time.Now()
+		at := GetPolicyBackoffFunc(KindFixed, time.Minute, 10*time.Second)(0)
+		require.False(t, at.Before(now.Add(time.Minute)))
+		require.True(t, at.Before(now.Add(time.Minute+11*time.Second)))
+	})
+}
@@ -6,6 +6,10 @@ import (
 	"github.com/coder/websocket"
 )
 
+// ErrAllWorkersAtCapacity is returned by the router if all healthy connections for an app
+// are handling as many requests as their workers declared they can handle.
+var ErrAllWorkersAtCapacity = fmt.Errorf("all workers are at capacity")
+
 type SocketError struct {
 	SysCode    string
 	Msg        string
@@ -51,6 +51,11 @@ type connectionHandler struct {
 	updateLock sync.Mutex
 	log        *slog.Logger
 
+	// leases stores the requests forwarded to the worker which have not received a reply yet,
+	// mapped to the time they were forwarded.
+	leases    map[string]time.Time
+	leaseLock sync.Mutex
+
 	remoteAddr string
 }
 
@@ -623,9 +628,27 @@ func (c *connectionHandler) receiveRouterMessages(ctx context.Context, appId uui
 			return
 		}
 
+		// Multiple routers may pick this connection at the same time, so capacity must be
+		// checked before accepting the request.
+		if !c.leaseRequest(data.RequestId) {
+			log.Warn("worker is at capacity, rejecting message")
+
+			err := c.svc.stateManager.RemoveInFlightRequest(ctx, c.conn.EnvID, c.conn.ConnectionId, data.RequestId)
+			if err != nil {
+				log.Error("could not remove in-flight request", "err", err)
+			}
+
+			err = c.svc.receiver.NackMessage(ctx, appId, data.RequestId, time.Now().Add(WorkerCapacityRetryDelay))
+			if err != nil {
+				log.Error("failed to nack message", "err", err)
+			}
+			return
+		}
+
 		err := c.svc.receiver.AckMessage(ctx, appId, data.RequestId, pubsub.AckSourceGateway)
 		if err != nil {
 			log.Error("failed to ack message", "err", err)
+			c.releaseRequest(data.RequestId)
 			// The executor will retry the message if it doesn't receive an ack
 			return
 		}
@@ -872,6 +895,16 @@ func (c *connectionHandler) handleSdkReply(ctx context.Context, msg *connect.Con
 		return fmt.Errorf("could not notify executor: %w", err)
 	}
 
+	c.releaseRequest(data.RequestId)
+
+	// The request is no longer in-flight for this connection. If the worker replied
+	// using a different connection, the request expires or is removed with the
+	// original connection.
+	err = c.svc.stateManager.RemoveInFlightRequest(ctx, c.conn.EnvID, c.conn.ConnectionId, data.RequestId)
+	if err != nil {
+		c.log.Error("could not remove in-flight request", "err", err, "req_id", data.RequestId)
+	}
+
 	replyAck, err := proto.Marshal(&connect.WorkerReplyAckData{
 		RequestId: data.RequestId,
 	})
@@ -889,6 +922,40 @@ func (c *connectionHandler) handleSdkReply(ctx context.Context, msg *connect.Con
 	return nil
 }
 
+// leaseRequest leases capacity of the worker for the given request. This returns false
+// if the worker is already handling as many requests as it declared.
+func (c *connectionHandler) leaseRequest(requestId string) bool {
+	c.leaseLock.Lock()
+	defer c.leaseLock.Unlock()
+
+	if c.leases == nil {
+		c.leases = map[string]time.Time{}
+	}
+
+	// Drop leases for requests which were never replied to on this connection
+	for id, leasedAt := range c.leases {
+		if time.Since(leasedAt) > state.InFlightRequestExpiry {
+			delete(c.leases, id)
+		}
+	}
+
+	max := c.conn.Data.GetMaxWorkerConcurrency()
+	if _, ok := c.leases[requestId]; !ok && max > 0 && int64(len(c.leases)) >= max {
+		return false
+	}
+
+	c.leases[requestId] = time.Now()
+	return true
+}
+
+// releaseRequest releases the capacity leased for the given request.
+func (c *connectionHandler) releaseRequest(requestId string) {
+	c.leaseLock.Lock()
+	defer c.leaseLock.Unlock()
+
+	delete(c.leases, requestId)
+}
+
 func (c *connectionHandler) updateConnStatus(status connect.ConnectionStatus) error {
 	c.updateLock.Lock()
 	defer c.updateLock.Unlock()
@@ -0,0 +1,50 @@
+package connect
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/connect/state"
+	"github.com/khulnasoft/inngest/proto/gen/connect/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLeaseRequest(t *testing.T) {
+	t.Run("requests beyond the declared capacity are rejected", func(t *testing.T) {
+		c := &connectionHandler{conn: &state.Connection{
+			Data: &connect.WorkerConnectRequestData{MaxWorkerConcurrency: proto.Int64(2)},
+		}}
+
+		require.True(t, c.leaseRequest("a"))
+		require.True(t, c.leaseRequest("b"))
+		require.False(t, c.leaseRequest("c"))
+
+		// Leasing an already leased request is idempotent
+		require.True(t, c.leaseRequest("b"))
+
+		c.releaseRequest("a")
+		require.True(t, c.leaseRequest("c"))
+	})
+
+	t.Run("expired leases are dropped", func(t *testing.T) {
+		c := &connectionHandler{conn: &state.Connection{
+			Data: &connect.WorkerConnectRequestData{MaxWorkerConcurrency: proto.Int64(1)},
+		}}
+
+		require.True(t, c.leaseRequest("a"))
+		c.leases["a"] = time.Now().Add(-state.InFlightRequestExpiry - time.Second)
+		require.True(t, c.leaseRequest("b"))
+	})
+
+	t.Run("workers without declared capacity are not limited", func(t *testing.T) {
+		c := &connectionHandler{conn: &state.Connection{
+			Data: &connect.WorkerConnectRequestData{},
+		}}
+
+		for i := 0; i < 100; i++ {
+			require.True(t, c.leaseRequest(strconv.Itoa(i)))
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/khulnasoft/inngest/pkg/logger"
 	"github.com/oklog/ulid/v2"
+	"time"
 
 	"github.com/google/uuid"
 	connpb "github.com/khulnasoft/inngest/proto/gen/connect/v1"
@@ -42,6 +43,12 @@ func (noopConnector) AckMessage(ctx context.Context, appId uuid.UUID, requestId
 	return nil
 }
 
+func (noopConnector) NackMessage(ctx context.Context, appId uuid.UUID, requestId string, retryAt time.Time) error {
+	logger.StdlibLogger(ctx).Error("using no-op connector to nack message", "request_id", requestId, "retry_at", retryAt)
+
+	return nil
+}
+
 func (noopConnector) NotifyExecutor(ctx context.Context, resp *connpb.SDKResponse) error {
 	logger.StdlibLogger(ctx).Error("using no-op connector to notify executor", "resp", resp)
 
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"sync"
 	"time"
 
@@ -50,6 +51,17 @@ const (
 	AckSourceRouter  AckSource = "router"
 )
 
+// NackError is returned by Proxy when the connect infrastructure rejected a request
+// without forwarding it to a worker, e.g. because all workers are at capacity. The
+// request should be retried at RetryAt.
+type NackError struct {
+	RetryAt time.Time
+}
+
+func (e *NackError) Error() string {
+	return fmt.Sprintf("request was rejected by connect, retry at %s", e.RetryAt.Format(time.RFC3339))
+}
+
 type ResponseNotifier interface {
 	// NotifyExecutor sends a response to the executor for a specific request.
 	NotifyExecutor(ctx context.Context, resp *connect.SDKResponse) error
@@ -72,6 +84,10 @@ type RequestReceiver interface {
 	// AckMessage sends an acknowledgment for a specific request.
 	AckMessage(ctx context.Context, appId uuid.UUID, requestId string, source AckSource) error
 
+	// NackMessage rejects a specific request which could not be forwarded to a worker. The executor
+	// will retry the request at the given time.
+	NackMessage(ctx context.Context, appId uuid.UUID, requestId string, retryAt time.Time) error
+
 	// Wait blocks and listens for incoming PubSub messages for the internal subscribers. This must be run before
 	// subscribing to any channels to ensure that the PubSub client is connected and ready to receive messages.
 	Wait(ctx context.Context) error
@@ -115,6 +131,34 @@ func (i *redisPubSubConnector) Proxy(ctx context.Context, appId uuid.UUID, data
 		return nil, fmt.Errorf("could not marshal executor request: %w", err)
 	}
 
+	// Requests may be rejected after the router acked them. A rejection cancels all subscriptions below.
+	reqCtx, cancelReq := context.WithCancel(ctx)
+	defer cancelReq()
+
+	var (
+		nack     *NackError
+		nackLock sync.Mutex
+	)
+	nacked := func() *NackError {
+		nackLock.Lock()
+		defer nackLock.Unlock()
+		return nack
+	}
+	go func() {
+		_ = i.subscribe(reqCtx, i.channelAppRequestsNack(appId, data.RequestId), func(msg string) {
+			retryAtMs, err := strconv.ParseInt(msg, 10, 64)
+			if err != nil {
+				retryAtMs = time.Now().UnixMilli()
+			}
+
+			nackLock.Lock()
+			nack = &NackError{RetryAt: time.UnixMilli(retryAtMs)}
+			nackLock.Unlock()
+
+			cancelReq()
+		}, true)
+	}()
+
 	// Await ack from router BEFORE response
 	routerAckErrChan := make(chan error)
 	var routerAcked bool
@@ -138,7 +182,7 @@ func (i *redisPubSubConnector) Proxy(ctx context.Context, appId uuid.UUID, data
 	gatewayAckErrChan := make(chan error)
 	var gatewayAcked bool
 	{
-		withAckTimeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		withAckTimeout, cancel := context.WithTimeout(reqCtx, 10*time.Second)
 		defer cancel()
 		go func() {
 			err = i.subscribe(withAckTimeout, i.channelAppRequestsAck(appId, data.RequestId, AckSourceGateway), func(msg string) {
@@ -157,7 +201,7 @@ func (i *redisPubSubConnector) Proxy(ctx context.Context, appId uuid.UUID, data
 	workerAckErrChan := make(chan error)
 	var workerAcked bool
 	{
-		withAckTimeout, cancel := context.WithTimeout(ctx, 10*time.Second)
+		withAckTimeout, cancel := context.WithTimeout(reqCtx, 10*time.Second)
 		defer cancel()
 		go func() {
 			err = i.subscribe(withAckTimeout, i.channelAppRequestsAck(appId, data.RequestId, AckSourceWorker), func(msg string) {
@@ -178,7 +222,7 @@ func (i *redisPubSubConnector) Proxy(ctx context.Context, appId uuid.UUID, data
 	var reply connect.SDKResponse
 	go func() {
 		// This may take a while: This waits until we receive the SDK response, and we allow for up to 2h in the serverless execution model
-		err = i.subscribe(ctx, i.channelAppRequestsReply(appId, data.RequestId), func(msg string) {
+		err = i.subscribe(reqCtx, i.channelAppRequestsReply(appId, data.RequestId), func(msg string) {
 			err := proto.Unmarshal([]byte(msg), &reply)
 			if err != nil {
 				// TODO This should never happen, push message into dead-letter channel and report
@@ -215,6 +259,9 @@ func (i *redisPubSubConnector) Proxy(ctx context.Context, appId uuid.UUID, data
 	{
 		err := <-gatewayAckErrChan
 		close(gatewayAckErrChan)
+		if nack := nacked(); nack != nil {
+			return nil, nack
+		}
 		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
 			return nil, fmt.Errorf("could not receive executor request ack by gateway: %w", err)
 		}
@@ -227,6 +274,9 @@ func (i *redisPubSubConnector) Proxy(ctx context.Context, appId uuid.UUID, data
 	{
 		err := <-workerAckErrChan
 		close(workerAckErrChan)
+		if nack := nacked(); nack != nil {
+			return nil, nack
+		}
 		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
 			return nil, fmt.Errorf("could not receive executor request ack by worker: %w", err)
 		}
@@ -241,6 +291,9 @@ func (i *redisPubSubConnector) Proxy(ctx context.Context, appId uuid.UUID, data
 	{
 		err := <-replyErrChan
 		close(replyErrChan)
+		if nack := nacked(); nack != nil {
+			return nil, nack
+		}
 		if err != nil {
 			return nil, fmt.Errorf("could not receive executor response: %w", err)
 		}
@@ -263,6 +316,10 @@ func (i *redisPubSubConnector) channelAppRequestsAck(appId uuid.UUID, requestId
 	return fmt.Sprintf("app_requests_ack:%s:%s:%s", appId, requestId, source)
 }
 
+func (i *redisPubSubConnector) channelAppRequestsNack(appId uuid.UUID, requestId string) string {
+	return fmt.Sprintf("app_requests_nack:%s:%s", appId, requestId)
+}
+
 func (i *redisPubSubConnector) channelAppRequestsReply(appId uuid.UUID, requestId string) string {
 	return fmt.Sprintf("app_requests_reply:%s:%s", appId, requestId)
 }
@@ -487,6 +544,23 @@ func (i *redisPubSubConnector) AckMessage(ctx context.Context, appId uuid.UUID,
 	return nil
 }
 
+// NackMessage rejects a specific request, which will be retried by the executor at the given time.
+func (i *redisPubSubConnector) NackMessage(ctx context.Context, appId uuid.UUID, requestId string, retryAt time.Time) error {
+	err := i.client.Do(
+		ctx,
+		i.client.B().
+			Publish().
+			Channel(i.channelAppRequestsNack(appId, requestId)).
+			Message(strconv.FormatInt(retryAt.UnixMilli(), 10)).
+			Build()).
+		Error()
+	if err != nil {
+		return fmt.Errorf("could not publish nack: %w", err)
+	}
+
+	return nil
+}
+
 // RouteExecutorRequest forwards an executor request to the respective gateway
 func (i *redisPubSubConnector) RouteExecutorRequest(ctx context.Context, gatewayId ulid.ULID, appId uuid.UUID, connId ulid.ULID, data *connect.GatewayExecutorRequestData) error {
 	dataBytes, err := proto.Marshal(data)
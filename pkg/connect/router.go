@@ -19,6 +19,18 @@ import (
 
 const (
 	pkgNameRouter = "connect.router"
+
+	// requestsPerCPUCore is the number of concurrent requests a worker is assumed to handle
+	// per advertised CPU core.
+	requestsPerCPUCore = 10
+
+	// minConnectionWeight is the weight of saturated connections. This is non-zero so
+	// requests are still routed if all connections are saturated.
+	minConnectionWeight = 1
+
+	// outdatedVersionWeightFactor scales the weight of connections running an older
+	// app version, so new requests prefer the latest version and older versions drain.
+	outdatedVersionWeightFactor = 0.1
 )
 
 type connectRouterSvc struct {
@@ -85,6 +97,16 @@ func (c *connectRouterSvc) Run(ctx context.Context) error {
 			// Now we're guaranteed to be the exclusive connection processing this message!
 
 			routeTo, err := c.getSuitableConnection(ctx, envId, appId, data.FunctionSlug, log)
+			if errors.Is(err, ErrAllWorkersAtCapacity) {
+				log.Warn("all connections are at capacity, rejecting request")
+
+				// Reject the request so the executor retries it later, instead of pushing it to a saturated worker
+				err = c.receiver.NackMessage(ctx, appId, data.RequestId, time.Now().Add(WorkerCapacityRetryDelay))
+				if err != nil {
+					log.Error("failed to nack message", "err", err)
+				}
+				return
+			}
 			if err != nil {
 				log.Error("could not retrieve suitable connection", "err", err)
 				return
@@ -112,6 +134,13 @@ func (c *connectRouterSvc) Run(ctx context.Context) error {
 
 			log = log.With("gateway_id", routeTo.GatewayId, "conn_id", routeTo.Id, "group_hash", routeTo.GroupId)
 
+			// Track the request as in-flight for the connection, this is removed by the gateway once
+			// the worker replies.
+			err = c.stateManager.AddInFlightRequest(ctx, envId, connId, data.RequestId)
+			if err != nil {
+				log.Error("could not track in-flight request", "err", err)
+			}
+
 			// TODO What if something goes wrong inbetween setting idempotency (claiming exclusivity) and forwarding the req?
 			// We'll potentially lose data here
 
@@ -120,6 +149,11 @@ func (c *connectRouterSvc) Run(ctx context.Context) error {
 			if err != nil {
 				// TODO Should we retry? Log error?
 				log.Error("failed to route request to gateway", "err", err)
+
+				err = c.stateManager.RemoveInFlightRequest(context.Background(), envId, connId, data.RequestId)
+				if err != nil {
+					log.Error("could not remove in-flight request", "err", err)
+				}
 				return
 			}
 
@@ -153,14 +187,19 @@ func (c *connectRouterSvc) getSuitableConnection(ctx context.Context, envId uuid
 	}
 
 	healthy := make([]*connect.ConnMetadata, 0, len(conns))
+	groups := make([]*state.WorkerGroup, 0, len(conns))
+	connIds := make([]ulid.ULID, 0, len(conns))
 	for _, conn := range conns {
-		isHealthy, err := c.isHealthy(ctx, envId, appId, fnSlug, conn, log)
+		group, isHealthy, err := c.isHealthy(ctx, envId, appId, fnSlug, conn, log)
 		if err != nil {
 			return nil, err
 		}
 
 		if isHealthy {
 			healthy = append(healthy, conn)
+			groups = append(groups, group)
+			// Connection IDs are validated in isHealthy
+			connIds = append(connIds, ulid.MustParse(conn.Id))
 		}
 	}
 
@@ -168,10 +207,37 @@ func (c *connectRouterSvc) getSuitableConnection(ctx context.Context, envId uuid
 		return nil, fmt.Errorf("no healthy connections found")
 	}
 
-	weights := make([]float64, len(healthy))
-	for i := range healthy {
-		// TODO Calculate weights based on factors like version
-		weights[i] = float64(10)
+	inFlight, err := c.stateManager.GetInFlightCounts(ctx, envId, connIds)
+	if err != nil {
+		return nil, fmt.Errorf("could not get in-flight requests: %w", err)
+	}
+
+	// Prefer the most recently synced version of the app
+	var latestSync time.Time
+	for _, group := range groups {
+		if group.SyncedAt != nil && group.SyncedAt.After(latestSync) {
+			latestSync = *group.SyncedAt
+		}
+	}
+
+	available := make([]*connect.ConnMetadata, 0, len(healthy))
+	weights := make([]float64, 0, len(healthy))
+	for i, conn := range healthy {
+		// Never route to workers which are handling as many requests as they declared
+		if conn.GetMaxWorkerConcurrency() > 0 && inFlight[i] >= conn.GetMaxWorkerConcurrency() {
+			log.Debug("connection is at capacity", "conn_id", conn.Id, "in_flight", inFlight[i], "max_worker_concurrency", conn.GetMaxWorkerConcurrency())
+			continue
+		}
+
+		syncedAt := groups[i].SyncedAt
+		isLatest := latestSync.IsZero() || (syncedAt != nil && !syncedAt.Before(latestSync))
+
+		available = append(available, conn)
+		weights = append(weights, connectionWeight(conn, inFlight[i], isLatest))
+	}
+
+	if len(available) == 0 {
+		return nil, ErrAllWorkersAtCapacity
 	}
 
 	w := sampleuv.NewWeighted(weights, c.rnd)
@@ -179,12 +245,42 @@ func (c *connectRouterSvc) getSuitableConnection(ctx context.Context, envId uuid
 	if !ok {
 		return nil, util.ErrWeightedSampleRead
 	}
-	chosen := healthy[idx]
+	chosen := available[idx]
 
 	return chosen, nil
 }
 
-func (c *connectRouterSvc) isHealthy(ctx context.Context, envId uuid.UUID, appId uuid.UUID, fnSlug string, conn *connect.ConnMetadata, log *slog.Logger) (isHealthy bool, err error) {
+// connectionWeight returns the routing weight for a healthy connection. The weight is the
+// remaining capacity of the worker, scaled down if the worker runs an outdated app version.
+func connectionWeight(conn *connect.ConnMetadata, inFlight int64, isLatestVersion bool) float64 {
+	headroom := float64(connectionCapacity(conn) - inFlight)
+	if headroom < minConnectionWeight {
+		headroom = minConnectionWeight
+	}
+
+	if !isLatestVersion {
+		headroom *= outdatedVersionWeightFactor
+	}
+
+	return headroom
+}
+
+// connectionCapacity returns the number of requests a connection is expected to handle
+// concurrently. This is the capacity declared by the worker, or an estimate based on the
+// attributes advertised by the worker.
+func connectionCapacity(conn *connect.ConnMetadata) int64 {
+	if conn.GetMaxWorkerConcurrency() > 0 {
+		return conn.GetMaxWorkerConcurrency()
+	}
+
+	cores := int64(conn.GetAttributes().GetCpuCores())
+	if cores < 1 {
+		cores = 1
+	}
+	return cores * requestsPerCPUCore
+}
+
+func (c *connectRouterSvc) isHealthy(ctx context.Context, envId uuid.UUID, appId uuid.UUID, fnSlug string, conn *connect.ConnMetadata, log *slog.Logger) (group *state.WorkerGroup, isHealthy bool, err error) {
 	defer func() {
 		if isHealthy {
 			return
@@ -211,6 +307,12 @@ func (c *connectRouterSvc) isHealthy(ctx context.Context, envId uuid.UUID, appId
 		return
 	}
 
+	if _, err = ulid.Parse(conn.Id); err != nil {
+		log.Error("connection id could not be parsed", "err", err, "conn_id", conn.Id)
+
+		return
+	}
+
 	if conn.Status != connect.ConnectionStatus_READY {
 		log.Debug("connection is not ready")
 
@@ -223,7 +325,7 @@ func (c *connectRouterSvc) isHealthy(ctx context.Context, envId uuid.UUID, appId
 		return
 	}
 
-	group, err := c.stateManager.GetWorkerGroupByHash(ctx, envId, conn.GroupId)
+	group, err = c.stateManager.GetWorkerGroupByHash(ctx, envId, conn.GroupId)
 	if err != nil {
 		log.Error("could not get worker group for connection", "group_id", conn.GroupId)
 
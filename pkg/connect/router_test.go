@@ -0,0 +1,45 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/khulnasoft/inngest/proto/gen/connect/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestConnectionWeight(t *testing.T) {
+	small := &connect.ConnMetadata{Attributes: &connect.SystemAttributes{CpuCores: 1}}
+	large := &connect.ConnMetadata{Attributes: &connect.SystemAttributes{CpuCores: 8}}
+
+	t.Run("larger workers receive a higher weight", func(t *testing.T) {
+		require.Greater(t, connectionWeight(large, 0, true), connectionWeight(small, 0, true))
+	})
+
+	t.Run("missing attributes default to a single core", func(t *testing.T) {
+		require.Equal(t, connectionWeight(small, 0, true), connectionWeight(&connect.ConnMetadata{}, 0, true))
+	})
+
+	t.Run("in-flight requests reduce the weight", func(t *testing.T) {
+		require.Equal(t, float64(80), connectionWeight(large, 0, true))
+		require.Equal(t, float64(50), connectionWeight(large, 30, true))
+	})
+
+	t.Run("saturated workers keep the minimum weight", func(t *testing.T) {
+		require.Equal(t, float64(minConnectionWeight), connectionWeight(small, 10, true))
+		require.Equal(t, float64(minConnectionWeight), connectionWeight(small, 100, true))
+	})
+
+	t.Run("declared capacity overrides the estimate", func(t *testing.T) {
+		declared := &connect.ConnMetadata{
+			Attributes:           &connect.SystemAttributes{CpuCores: 8},
+			MaxWorkerConcurrency: proto.Int64(4),
+		}
+		require.Equal(t, int64(4), connectionCapacity(declared))
+		require.Equal(t, float64(3), connectionWeight(declared, 1, true))
+	})
+
+	t.Run("outdated versions are scaled down", func(t *testing.T) {
+		require.Equal(t, 80*outdatedVersionWeightFactor, connectionWeight(large, 0, false))
+	})
+}
@@ -28,6 +28,10 @@ const (
 	GatewayHeartbeatInterval  = 5 * time.Second
 	GatewayInstrumentInterval = 20 * time.Second
 	WorkerHeartbeatInterval   = 10 * time.Second
+
+	// WorkerCapacityRetryDelay is the delay after which requests are retried if all workers
+	// were at capacity.
+	WorkerCapacityRetryDelay = 5 * time.Second
 )
 
 type gatewayOpt func(*connectGatewaySvc)
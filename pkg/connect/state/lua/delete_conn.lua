@@ -11,6 +11,7 @@ local connKey = KEYS[1]
 local groupKey = KEYS[2]
 local groupIDKey = KEYS[3]
 local indexConnectionsByAppIdKey = KEYS[4]
+local inFlightKey = KEYS[5]
 
 local connID = ARGV[1]
 local groupID = ARGV[2]
@@ -20,6 +21,9 @@ local groupID = ARGV[2]
 -- Remove the connection from the map
 redis.call("HDEL", connKey, connID)
 
+-- Requests can no longer be in-flight for a deleted connection
+redis.call("DEL", inFlightKey)
+
 -- Remove the connID from the group set, set is deleted when empty
 redis.call("SREM", groupIDKey, connID)
 
@@ -10,6 +10,7 @@ import (
 	"io/fs"
 	"log/slog"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -199,6 +200,8 @@ func (r *redisConnectionStateManager) UpsertConnection(ctx context.Context, conn
 		Attributes:      conn.Data.SystemAttributes,
 		GatewayId:       conn.GatewayId.String(),
 		LastHeartbeatAt: timestamppb.New(lastHeartbeatAt),
+
+		MaxWorkerConcurrency: conn.Data.MaxWorkerConcurrency,
 	}
 
 	isHealthy := "0"
@@ -264,6 +267,7 @@ func (r *redisConnectionStateManager) DeleteConnection(ctx context.Context, envI
 		r.groupKey(envID),
 		r.groupIDKey(envID, groupID),
 		r.connIndexByApp(envID, appID),
+		r.inFlightKey(envID, connId),
 	}
 
 	args := []string{
@@ -293,6 +297,59 @@ func (r *redisConnectionStateManager) DeleteConnection(ctx context.Context, envI
 	}
 }
 
+func (r *redisConnectionStateManager) AddInFlightRequest(ctx context.Context, envID uuid.UUID, connID ulid.ULID, requestID string) error {
+	key := r.inFlightKey(envID, connID)
+	expiry := time.Now().Add(InFlightRequestExpiry)
+
+	cmds := rueidis.Commands{
+		r.client.B().Zadd().Key(key).ScoreMember().ScoreMember(float64(expiry.UnixMilli()), requestID).Build(),
+		r.client.B().Pexpire().Key(key).Milliseconds(InFlightRequestExpiry.Milliseconds()).Build(),
+	}
+	for _, res := range r.client.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return fmt.Errorf("error adding in-flight request: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *redisConnectionStateManager) RemoveInFlightRequest(ctx context.Context, envID uuid.UUID, connID ulid.ULID, requestID string) error {
+	key := r.inFlightKey(envID, connID)
+	cmd := r.client.B().Zrem().Key(key).Member(requestID).Build()
+
+	if err := r.client.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("error removing in-flight request: %w", err)
+	}
+
+	return nil
+}
+
+func (r *redisConnectionStateManager) GetInFlightCounts(ctx context.Context, envID uuid.UUID, connIDs []ulid.ULID) ([]int64, error) {
+	if len(connIDs) == 0 {
+		return nil, nil
+	}
+
+	// Only count requests which have not expired yet
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	cmds := make(rueidis.Commands, len(connIDs))
+	for i, connID := range connIDs {
+		cmds[i] = r.client.B().Zcount().Key(r.inFlightKey(envID, connID)).Min(now).Max("+inf").Build()
+	}
+
+	counts := make([]int64, len(connIDs))
+	for i, res := range r.client.DoMulti(ctx, cmds...) {
+		count, err := res.AsInt64()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving in-flight requests: %w", err)
+		}
+		counts[i] = count
+	}
+
+	return counts, nil
+}
+
 func (r *redisConnectionStateManager) GetWorkerGroupByHash(ctx context.Context, envID uuid.UUID, hash string) (*WorkerGroup, error) {
 	key := r.groupKey(envID)
 	cmd := r.client.B().Hget().Key(key).Field(hash).Build()
@@ -350,6 +407,10 @@ func (r *redisConnectionStateManager) groupIDKey(envID uuid.UUID, groupID string
 	return fmt.Sprintf("{%s}:groups:%s", envID.String(), groupID)
 }
 
+func (r *redisConnectionStateManager) inFlightKey(envID uuid.UUID, connID ulid.ULID) string {
+	return fmt.Sprintf("{%s}:inflight:%s", envID.String(), connID.String())
+}
+
 // gatewaysHashKey returns the key for the global gateways hash.
 // Gateways are not scoped to any environment, so the Redis hash tag will be global.
 // This also means that gateways cannot be accessed in the same script as other environment-scoped keys.
@@ -0,0 +1,62 @@
+package state
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlightRequests(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	mgr := NewRedisConnectionStateManager(rc)
+	envID := uuid.New()
+	connA := ulid.MustNew(ulid.Now(), rand.Reader)
+	connB := ulid.MustNew(ulid.Now(), rand.Reader)
+
+	counts, err := mgr.GetInFlightCounts(ctx, envID, []ulid.ULID{connA, connB})
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 0}, counts)
+
+	require.NoError(t, mgr.AddInFlightRequest(ctx, envID, connA, "req-1"))
+	require.NoError(t, mgr.AddInFlightRequest(ctx, envID, connA, "req-2"))
+	// Adding the same request twice is idempotent
+	require.NoError(t, mgr.AddInFlightRequest(ctx, envID, connA, "req-2"))
+	require.NoError(t, mgr.AddInFlightRequest(ctx, envID, connB, "req-3"))
+
+	counts, err = mgr.GetInFlightCounts(ctx, envID, []ulid.ULID{connA, connB})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 1}, counts)
+
+	require.NoError(t, mgr.RemoveInFlightRequest(ctx, envID, connA, "req-1"))
+
+	counts, err = mgr.GetInFlightCounts(ctx, envID, []ulid.ULID{connA, connB})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1}, counts)
+
+	// Deleting the connection removes all in-flight requests
+	err = mgr.DeleteConnection(ctx, envID, nil, "group", connB)
+	require.ErrorIs(t, err, ConnDeletedWithGroupErr)
+
+	counts, err = mgr.GetInFlightCounts(ctx, envID, []ulid.ULID{connA, connB})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 0}, counts)
+
+	// Expired requests are no longer counted
+	r.FastForward(InFlightRequestExpiry)
+	counts, err = mgr.GetInFlightCounts(ctx, envID, []ulid.ULID{connA})
+	require.NoError(t, err)
+	require.Equal(t, []int64{0}, counts)
+}
@@ -24,10 +24,18 @@ const (
 	pkgName = "connect.state"
 )
 
+const (
+	// InFlightRequestExpiry is the maximum duration a request is considered in-flight
+	// for a connection. Requests are usually removed once the worker replies, this only
+	// guards against leaking requests when replies are lost.
+	InFlightRequestExpiry = 2 * time.Hour
+)
+
 type StateManager interface {
 	ConnectionManager
 	WorkerGroupManager
 	GatewayManager
+	InFlightRequestManager
 
 	SetRequestIdempotency(ctx context.Context, appId uuid.UUID, requestId string) error
 }
@@ -40,6 +48,16 @@ type ConnectionManager interface {
 	DeleteConnection(ctx context.Context, envID uuid.UUID, appID *uuid.UUID, groupID string, connId ulid.ULID) error
 }
 
+// InFlightRequestManager tracks requests which have been routed to a connection but
+// have not received a reply yet.
+type InFlightRequestManager interface {
+	AddInFlightRequest(ctx context.Context, envID uuid.UUID, connID ulid.ULID, requestID string) error
+	RemoveInFlightRequest(ctx context.Context, envID uuid.UUID, connID ulid.ULID, requestID string) error
+	// GetInFlightCounts returns the number of in-flight requests for each of the given
+	// connections, in the same order.
+	GetInFlightCounts(ctx context.Context, envID uuid.UUID, connIDs []ulid.ULID) ([]int64, error)
+}
+
 type WorkerGroupManager interface {
 	GetWorkerGroupByHash(ctx context.Context, envID uuid.UUID, hash string) (*WorkerGroup, error)
 	UpdateWorkerGroup(ctx context.Context, envID uuid.UUID, group *WorkerGroup) error
@@ -88,6 +106,10 @@ type WorkerGroup struct {
 	// If this is set, it's expected that this worker group is already synced
 	SyncID *uuid.UUID `json:"sync_id,omitempty"`
 
+	// SyncedAt is the time this group was synced. Groups which were synced more
+	// recently are preferred when routing requests, so older versions are drained.
+	SyncedAt *time.Time `json:"synced_at,omitempty"`
+
 	// Hash is the hashed value for the SDK attributes
 	// - AccountID
 	// - EnvID
@@ -255,8 +277,10 @@ func (c *Connection) Sync(ctx context.Context, groupManager WorkerGroupManager,
 
 	// Update the worker group to make sure it store the appropriate IDs
 	if syncReply.IsSuccess() {
+		now := time.Now()
 		c.Group.SyncID = syncReply.SyncID
 		c.Group.AppID = syncReply.AppID
+		c.Group.SyncedAt = &now
 		// Update the worker group with the syncID so it's aware that it's already sync'd before
 		// Always update the worker group for consistency, even if the context is cancelled
 		if err := groupManager.UpdateWorkerGroup(context.Background(), c.EnvID, c.Group); err != nil {
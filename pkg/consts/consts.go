@@ -57,6 +57,10 @@ const (
 	// MinRetryDuration is the soonest a retry can be scheduled.
 	MinRetryDuration = time.Second * 1
 
+	// DefaultStepBackoffInterval is the delay before the first retry of a step which
+	// declares a backoff policy without an interval.
+	DefaultStepBackoffInterval = time.Second * 10
+
 	// MinDebouncePeriod is the minimum period of time that can be used to configure a debounce.
 	MinDebouncePeriod = time.Second
 
@@ -64,6 +68,10 @@ const (
 	// lets users delay functions for up to MaxDebouncePeriod when events are received.
 	MaxDebouncePeriod = time.Hour * 24 * 7
 
+	// MaxAICacheTTL is the maximum period of time that AI gateway responses can be
+	// cached for.
+	MaxAICacheTTL = time.Hour * 24 * 7
+
 	// MaxCancellations represents the max automatic cancellation signals per function
 	MaxCancellations = 5
 
@@ -73,6 +81,15 @@ const (
 	// MaxTriggers represents the maximum number of triggers a function can have.
 	MaxTriggers = 10
 
+	// DefaultCronCatchUpWindow is how far back missed cron ticks are enqueued
+	// on startup when a catch-up policy doesn't specify a window.
+	DefaultCronCatchUpWindow = 24 * time.Hour
+	// MaxCronCatchUpWindow is the largest catch-up window a cron trigger may specify.
+	MaxCronCatchUpWindow = 7 * 24 * time.Hour
+	// MaxCronCatchUpTicks limits the number of missed ticks enqueued for a
+	// single cron trigger on startup.
+	MaxCronCatchUpTicks = 100
+
 	// MaxBatchTTL represents the maximum amount of duration the batch key will last
 	MaxBatchTTL = 10 * time.Minute
 
@@ -94,6 +111,9 @@ const (
 	// via an RPC-like call, abstracting event-driven fanout.
 	InvokeFnID          = "fn_id"
 	InvokeCorrelationId = "correlation_id"
+	// SchemaErrorsKey is the data key used to store an event's schema validation
+	// errors when its schema is registered in tag mode.
+	SchemaErrorsKey = "schema_errors"
 
 	// CancelTimeout is the maximum time a cancellation can exist
 	CancelTimeout = time.Hour * 24 * 365
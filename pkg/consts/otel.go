@@ -1,16 +1,17 @@
 package consts
 
 const (
-	OtelSpanEvent        = "event"
-	OtelSpanCron         = "cron"
-	OtelSpanBatch        = "batch"
-	OtelSpanDebounce     = "debounce"
-	OtelSpanTrigger      = "trigger"
-	OtelSpanInvoke       = "invoke"
-	OtelSpanWaitForEvent = "wait"
-	OtelSpanSleep        = "sleep"
-	OtelSpanExecute      = "execute"
-	OtelSpanRerun        = "rerun"
+	OtelSpanEvent         = "event"
+	OtelSpanCron          = "cron"
+	OtelSpanBatch         = "batch"
+	OtelSpanDebounce      = "debounce"
+	OtelSpanTrigger       = "trigger"
+	OtelSpanInvoke        = "invoke"
+	OtelSpanWaitForEvent  = "wait"
+	OtelSpanWaitForSignal = "signal"
+	OtelSpanSleep         = "sleep"
+	OtelSpanExecute       = "execute"
+	OtelSpanRerun         = "rerun"
 
 	// system attributes
 	OtelSysAccountID      = "sys.account.id"
@@ -42,6 +43,7 @@ const (
 	OtelSysFunctionOutput     = "sys.function.output"
 	OtelSysFunctionLink       = "sys.function.link"
 	OtelSysFunctionHasAI      = "sys.function.hasAI"
+	OtelSysFunctionSkipReason = "sys.function.skip.reason"
 
 	OtelSysStepID              = "sys.step.id"
 	OtelSysStepDisplayName     = "sys.step.display.name"
@@ -61,6 +63,10 @@ const (
 	OtelSysStepStack           = "sys.step.stack"
 	OtelSysStepAIRequest       = "sys.step.ai.req" // ai request metadata
 	OtelSysStepAIResponse      = "sys.step.ai.res" // ai response metadata
+	OtelSysStepAIModel         = "sys.step.ai.model"
+	OtelSysStepAITokensIn      = "sys.step.ai.tokens.in"
+	OtelSysStepAITokensOut     = "sys.step.ai.tokens.out"
+	OtelSysStepAICacheHit      = "sys.step.ai.cache.hit" // response was served from the ai gateway cache
 	OtelSysStepRunType         = "sys.step.run.type"
 	OtelSysStepPlan            = "sys.step.plan" // indicate this is a planning step
 
@@ -72,6 +78,10 @@ const (
 	OtelSysStepWaitExpression     = "sys.step.wait.expr"
 	OtelSysStepWaitMatchedEventID = "sys.step.wait.matched.event.id"
 
+	OtelSysStepSignalName    = "sys.step.signal.name"
+	OtelSysStepSignalExpires = "sys.step.signal.expires"
+	OtelSysStepSignalExpired = "sys.step.signal.expired"
+
 	OtelSysStepInvokeExpires           = "sys.step.invoke.expires"
 	OtelSysStepInvokeTargetFnID        = "sys.step.invoke.fn.id"
 	OtelSysStepInvokeTriggeringEventID = "sys.step.invoke.event.outgoing.id"
@@ -79,8 +89,13 @@ const (
 	OtelSysStepInvokeRunID             = "sys.step.invoke.run.id"
 	OtelSysStepInvokeExpired           = "sys.step.invoke.expired"
 
-	OtelSysStepRetry  = "sys.step.retry"
-	OtelSysStepDelete = "sys.step.delete"
+	OtelSysStepRetry   = "sys.step.retry"
+	OtelSysStepDelete  = "sys.step.delete"
+	OtelSysStepTimeout = "sys.step.timeout" // in milliseconds
+
+	OtelSysStepBackoffKind     = "sys.step.backoff.kind"
+	OtelSysStepBackoffInterval = "sys.step.backoff.interval"
+	OtelSysStepBackoffJitter   = "sys.step.backoff.jitter"
 
 	OtelSysCronTimestamp = "sys.cron.timestamp"
 	OtelSysCronExpr      = "sys.cron.expr"
@@ -74,6 +74,8 @@ func MakeFunctionRun(f *cqrs.FunctionRun) *FunctionRun {
 		Status:     &status,
 		Cron:       f.Cron,
 	}
+	version := int(f.FunctionVersion)
+	r.FunctionVersion = &version
 	if len(f.Output) > 0 {
 		str := string(f.Output)
 		r.Output = &str
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/function"
 	"github.com/khulnasoft/inngest/pkg/history_reader"
 	ulid "github.com/oklog/ulid/v2"
 )
@@ -146,6 +147,7 @@ type FunctionRun struct {
 	HistoryItemOutput *string                      `json:"historyItemOutput,omitempty"`
 	EventID           string                       `json:"eventID"`
 	Cron              *string                      `json:"cron,omitempty"`
+	FunctionVersion   *int                         `json:"functionVersion,omitempty"`
 }
 
 type FunctionRunQuery struct {
@@ -154,25 +156,26 @@ type FunctionRunQuery struct {
 }
 
 type FunctionRunV2 struct {
-	ID             ulid.ULID         `json:"id"`
-	AppID          uuid.UUID         `json:"appID"`
-	App            *cqrs.App         `json:"app"`
-	FunctionID     uuid.UUID         `json:"functionID"`
-	Function       *Function         `json:"function"`
-	TraceID        string            `json:"traceID"`
-	QueuedAt       time.Time         `json:"queuedAt"`
-	StartedAt      *time.Time        `json:"startedAt,omitempty"`
-	EndedAt        *time.Time        `json:"endedAt,omitempty"`
-	Status         FunctionRunStatus `json:"status"`
-	SourceID       *string           `json:"sourceID,omitempty"`
-	TriggerIDs     []ulid.ULID       `json:"triggerIDs"`
-	EventName      *string           `json:"eventName,omitempty"`
-	IsBatch        bool              `json:"isBatch"`
-	BatchCreatedAt *time.Time        `json:"batchCreatedAt,omitempty"`
-	CronSchedule   *string           `json:"cronSchedule,omitempty"`
-	Output         *string           `json:"output,omitempty"`
-	Trace          *RunTraceSpan     `json:"trace,omitempty"`
-	HasAi          bool              `json:"hasAI"`
+	ID              ulid.ULID         `json:"id"`
+	AppID           uuid.UUID         `json:"appID"`
+	App             *cqrs.App         `json:"app"`
+	FunctionID      uuid.UUID         `json:"functionID"`
+	Function        *Function         `json:"function"`
+	TraceID         string            `json:"traceID"`
+	QueuedAt        time.Time         `json:"queuedAt"`
+	StartedAt       *time.Time        `json:"startedAt,omitempty"`
+	EndedAt         *time.Time        `json:"endedAt,omitempty"`
+	Status          FunctionRunStatus `json:"status"`
+	SourceID        *string           `json:"sourceID,omitempty"`
+	TriggerIDs      []ulid.ULID       `json:"triggerIDs"`
+	EventName       *string           `json:"eventName,omitempty"`
+	IsBatch         bool              `json:"isBatch"`
+	BatchCreatedAt  *time.Time        `json:"batchCreatedAt,omitempty"`
+	CronSchedule    *string           `json:"cronSchedule,omitempty"`
+	Output          *string           `json:"output,omitempty"`
+	Trace           *RunTraceSpan     `json:"trace,omitempty"`
+	HasAi           bool              `json:"hasAI"`
+	FunctionVersion *int              `json:"functionVersion,omitempty"`
 }
 
 type FunctionRunV2Edge struct {
@@ -189,6 +192,12 @@ type FunctionTrigger struct {
 	Value string               `json:"value"`
 }
 
+type FunctionVersionDiff struct {
+	From    *function.FunctionVersion `json:"from"`
+	To      *function.FunctionVersion `json:"to"`
+	Changes []*function.ConfigChange  `json:"changes"`
+}
+
 type InvokeStepInfo struct {
 	TriggeringEventID ulid.ULID  `json:"triggeringEventID"`
 	FunctionID        string     `json:"functionID"`
@@ -215,6 +224,19 @@ type PageInfo struct {
 type RerunFromStepInput struct {
 	StepID string  `json:"stepID"`
 	Input  *string `json:"input,omitempty"`
+	// Replaces the memoized output of steps that ran before stepID.
+	StepOverrides []*RerunStepOverrideInput `json:"stepOverrides,omitempty"`
+}
+
+type RerunStepOverrideInput struct {
+	StepID string `json:"stepID"`
+	Output string `json:"output"`
+}
+
+type RollbackFunctionInput struct {
+	FunctionID uuid.UUID `json:"functionID"`
+	Version    uint      `json:"version"`
+	Pin        bool      `json:"pin"`
 }
 
 type RunStepInfo struct {
@@ -557,6 +579,49 @@ func (e ConnectV1WorkerConnectionsOrderByField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type EventSchemaMode string
+
+const (
+	EventSchemaModeReject EventSchemaMode = "REJECT"
+	EventSchemaModeWarn   EventSchemaMode = "WARN"
+	EventSchemaModeTag    EventSchemaMode = "TAG"
+)
+
+var AllEventSchemaMode = []EventSchemaMode{
+	EventSchemaModeReject,
+	EventSchemaModeWarn,
+	EventSchemaModeTag,
+}
+
+func (e EventSchemaMode) IsValid() bool {
+	switch e {
+	case EventSchemaModeReject, EventSchemaModeWarn, EventSchemaModeTag:
+		return true
+	}
+	return false
+}
+
+func (e EventSchemaMode) String() string {
+	return string(e)
+}
+
+func (e *EventSchemaMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EventSchemaMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EventSchemaMode", str)
+	}
+	return nil
+}
+
+func (e EventSchemaMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type EventStatus string
 
 const (
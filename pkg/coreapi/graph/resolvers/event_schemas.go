@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/khulnasoft/inngest/pkg/coreapi/graph/models"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+)
+
+func (r *queryResolver) EventSchemas(ctx context.Context) ([]*cqrs.EventSchema, error) {
+	return r.Data.GetEventSchemas(ctx)
+}
+
+func (r *queryResolver) EventSchema(ctx context.Context, name string) (*cqrs.EventSchema, error) {
+	s, err := r.Data.GetEventSchema(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return s, err
+}
+
+func (r *queryResolver) EventSchemaFailures(ctx context.Context, eventName *string, first int, after *ulid.ULID) ([]*cqrs.EventSchemaFailure, error) {
+	return r.Data.GetEventSchemaFailures(ctx, cqrs.GetEventSchemaFailuresOpts{
+		EventName: eventName,
+		Cursor:    after,
+		Limit:     first,
+	})
+}
+
+func (r *eventSchemaResolver) Schema(ctx context.Context, obj *cqrs.EventSchema) (string, error) {
+	return string(obj.Schema), nil
+}
+
+func (r *eventSchemaResolver) Mode(ctx context.Context, obj *cqrs.EventSchema) (models.EventSchemaMode, error) {
+	return models.EventSchemaMode(strings.ToUpper(string(obj.Mode))), nil
+}
+
+func (r *eventSchemaResolver) App(ctx context.Context, obj *cqrs.EventSchema) (*cqrs.App, error) {
+	if obj.AppID == nil {
+		return nil, nil
+	}
+	app, err := r.Data.GetAppByID(ctx, *obj.AppID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return app, err
+}
+
+func (r *eventSchemaResolver) Failures(ctx context.Context, obj *cqrs.EventSchema, first int) ([]*cqrs.EventSchemaFailure, error) {
+	return r.Data.GetEventSchemaFailures(ctx, cqrs.GetEventSchemaFailuresOpts{
+		EventName: &obj.Name,
+		Limit:     first,
+	})
+}
+
+func (r *eventSchemaFailureResolver) Mode(ctx context.Context, obj *cqrs.EventSchemaFailure) (models.EventSchemaMode, error) {
+	return models.EventSchemaMode(strings.ToUpper(string(obj.Mode))), nil
+}
+
+func (r *eventSchemaFailureResolver) Event(ctx context.Context, obj *cqrs.EventSchemaFailure) (string, error) {
+	return string(obj.Event), nil
+}
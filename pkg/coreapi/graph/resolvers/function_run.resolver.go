@@ -323,6 +323,16 @@ func (r *mutationResolver) Rerun(
 
 			fromStepReq.Input = json.RawMessage(*fromStep.Input)
 		}
+
+		if len(fromStep.StepOverrides) > 0 {
+			fromStepReq.StepOverrides = map[string]json.RawMessage{}
+			for _, o := range fromStep.StepOverrides {
+				if !json.Valid([]byte(o.Output)) {
+					return zero, fmt.Errorf("output for step %s is not valid JSON", o.StepID)
+				}
+				fromStepReq.StepOverrides[o.StepID] = json.RawMessage(o.Output)
+			}
+		}
 	}
 
 	identifier, err := r.Executor.Schedule(ctx, execution.ScheduleRequest{
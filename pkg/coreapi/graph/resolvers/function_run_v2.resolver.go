@@ -2,6 +2,8 @@ package resolvers
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/khulnasoft/inngest/pkg/consts"
@@ -43,3 +45,16 @@ func (r *functionRunV2Resolver) Trace(ctx context.Context, fn *models.FunctionRu
 		},
 	)
 }
+
+func (r *functionRunV2Resolver) FunctionVersion(ctx context.Context, fn *models.FunctionRunV2) (*int, error) {
+	run, err := r.Data.GetFunctionRun(ctx, consts.DevServerAccountId, consts.DevServerEnvId, fn.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The run hasn't been scheduled yet.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving function run: %w", err)
+	}
+	version := int(run.FunctionVersion)
+	return &version, nil
+}
@@ -0,0 +1,158 @@
+package resolvers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/coreapi/graph/models"
+	"github.com/khulnasoft/inngest/pkg/function"
+)
+
+func (r *queryResolver) FunctionVersions(ctx context.Context, functionID uuid.UUID) ([]*function.FunctionVersion, error) {
+	return r.Data.GetFunctionVersions(ctx, functionID)
+}
+
+func (r *queryResolver) FunctionVersionDiff(ctx context.Context, functionID uuid.UUID, from uint, to uint) (*models.FunctionVersionDiff, error) {
+	a, err := r.getFunctionVersion(ctx, functionID, from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.getFunctionVersion(ctx, functionID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := function.DiffConfigs(a.Config, b.Config)
+	if err != nil {
+		return nil, err
+	}
+	diff := &models.FunctionVersionDiff{
+		From:    a,
+		To:      b,
+		Changes: make([]*function.ConfigChange, len(changes)),
+	}
+	for n := range changes {
+		diff.Changes[n] = &changes[n]
+	}
+	return diff, nil
+}
+
+func (r *functionVersionResolver) Runs(ctx context.Context, v *function.FunctionVersion, first int) ([]*models.FunctionRun, error) {
+	fnID, err := uuid.Parse(v.FunctionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid function ID: %w", err)
+	}
+
+	ids, err := r.Data.GetFunctionVersionRunIDs(ctx, fnID, v.Version, first)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*models.FunctionRun, 0, len(ids))
+	for _, id := range ids {
+		run, err := r.Data.GetFunctionRun(ctx, consts.DevServerAccountId, consts.DevServerEnvId, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving function run: %w", err)
+		}
+		runs = append(runs, models.MakeFunctionRun(run))
+	}
+	return runs, nil
+}
+
+func (r *mutationResolver) RollbackFunction(ctx context.Context, input models.RollbackFunctionInput) (*function.FunctionVersion, error) {
+	target, err := r.getFunctionVersion(ctx, input.FunctionID, input.Version)
+	if err != nil {
+		return nil, err
+	}
+	if target.Draft() {
+		return nil, fmt.Errorf("function version %d has never been live", input.Version)
+	}
+
+	tx, err := r.Data.WithTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	live := target
+	if !target.Live() {
+		// Versions are immutable, so the earlier config is made live as a new
+		// version.
+		v, err := tx.InsertFunctionVersion(ctx, input.FunctionID, target.Config)
+		if err != nil {
+			return nil, err
+		}
+		if live, err = tx.SetLiveFunctionVersion(ctx, input.FunctionID, v.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	var pin *uint
+	if input.Pin {
+		pin = &live.Version
+	}
+	if err := tx.PinFunctionVersion(ctx, input.FunctionID, pin); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	live.Pinned = input.Pin
+	return live, nil
+}
+
+func (r *mutationResolver) UnpinFunction(ctx context.Context, functionID uuid.UUID) (*function.FunctionVersion, error) {
+	tx, err := r.Data.WithTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := tx.PinFunctionVersion(ctx, functionID, nil); err != nil {
+		return nil, err
+	}
+
+	versions, err := tx.GetFunctionVersions(ctx, functionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("function has no versions")
+	}
+
+	// Configs synced while the function was pinned are kept as drafts, so the
+	// latest synced config becomes live.
+	latest := versions[0]
+	latest.Pinned = false
+	if latest.Draft() {
+		if latest, err = tx.SetLiveFunctionVersion(ctx, functionID, latest.Version); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, v := range versions {
+			if v.Live() {
+				latest = v
+				break
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	latest.Pinned = false
+	return latest, nil
+}
+
+func (r *Resolver) getFunctionVersion(ctx context.Context, fnID uuid.UUID, version uint) (*function.FunctionVersion, error) {
+	v, err := r.Data.GetFunctionVersion(ctx, fnID, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("function version %d not found", version)
+	}
+	return v, err
+}
@@ -57,5 +57,7 @@ func (r *queryResolver) FunctionRun(ctx context.Context, query models.FunctionRu
 		BatchID:    run.BatchID,
 		Status:     &status,
 		Output:     run.Output,
+
+		FunctionVersion: &run.WorkflowVersion,
 	}, nil
 }
@@ -42,6 +42,16 @@ func (r *Resolver) FunctionRun() generated.FunctionRunResolver { return &functio
 
 func (r *Resolver) FunctionRunV2() generated.FunctionRunV2Resolver { return &functionRunV2Resolver{r} }
 
+func (r *Resolver) FunctionVersion() generated.FunctionVersionResolver {
+	return &functionVersionResolver{r}
+}
+
+func (r *Resolver) EventSchema() generated.EventSchemaResolver { return &eventSchemaResolver{r} }
+
+func (r *Resolver) EventSchemaFailure() generated.EventSchemaFailureResolver {
+	return &eventSchemaFailureResolver{r}
+}
+
 func (r *Resolver) App() generated.AppResolver { return &appResolver{r} }
 
 func (r *Resolver) Function() generated.FunctionResolver { return &functionResolver{r} }
@@ -67,6 +77,9 @@ type appResolver struct{ *Resolver }
 
 type functionRunResolver struct{ *Resolver }
 type functionRunV2Resolver struct{ *Resolver }
+type functionVersionResolver struct{ *Resolver }
+type eventSchemaResolver struct{ *Resolver }
+type eventSchemaFailureResolver struct{ *Resolver }
 type connectV1workerConnectionConnResolver struct{ *Resolver }
 type connectV1workerConnectionResolver struct{ *Resolver }
 type functionResolver struct{ *Resolver }
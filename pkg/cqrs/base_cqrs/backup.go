@@ -0,0 +1,444 @@
+package base_cqrs
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+)
+
+const (
+	// BackupFormatVersion is the version of the archive layout written by Export.
+	BackupFormatVersion = 1
+
+	backupManifestFile = "manifest.json"
+	backupTablesDir    = "tables"
+
+	// backupInsertBatch is the number of rows inserted per statement on import.
+	backupInsertBatch = 100
+
+	migrationsTable = "migrations"
+)
+
+// BackupManifest describes the contents of an archive created by Export.
+type BackupManifest struct {
+	// Version is the archive's format version.
+	Version int `json:"version"`
+	// Driver is the database driver the archive was exported from, either
+	// "sqlite" or "postgres".
+	Driver string `json:"driver"`
+	// SchemaVersion is the migration version of the exported database.
+	SchemaVersion uint `json:"schema_version"`
+	// CreatedAt is when the archive was created.
+	CreatedAt time.Time `json:"created_at"`
+	// Tables maps each exported table to its number of rows.
+	Tables map[string]int `json:"tables"`
+}
+
+// Export writes every table in the database to w as a gzipped tar archive.  The
+// archive contains a manifest.json followed by a tables/<name>.jsonl file per table,
+// each line of which is a row.
+//
+// This includes apps, functions, events, history, traces and the latest queue and
+// state snapshots taken by the server, so the server should be stopped before
+// exporting for the archive to hold its most recent queue and state.
+func Export(ctx context.Context, db *sql.DB, driver string, w io.Writer) (*BackupManifest, error) {
+	// Read all tables within a single transaction so that the archive is a
+	// consistent snapshot of the database.
+	opts := &sql.TxOptions{ReadOnly: true}
+	if driver == "postgres" {
+		opts.Isolation = sql.LevelRepeatableRead
+	}
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error starting export transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := listTables(ctx, tx, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &BackupManifest{
+		Version:       BackupFormatVersion,
+		Driver:        driver,
+		SchemaVersion: version,
+		CreatedAt:     time.Now().UTC(),
+		Tables:        map[string]int{},
+	}
+
+	// Tar headers need each file's size up front, so tables are written to
+	// temporary files before being added to the archive after the manifest.
+	files := map[string]*os.File{}
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	for _, table := range tables {
+		f, err := os.CreateTemp("", "inngest-export-*.jsonl")
+		if err != nil {
+			return nil, fmt.Errorf("error creating temporary file: %w", err)
+		}
+		files[table] = f
+
+		n, err := exportTable(ctx, tx, driver, table, f)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Tables[table] = n
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	byt, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeTarFile(tw, backupManifestFile, int64(len(byt)), manifest.CreatedAt, bytes.NewReader(byt)); err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		f := files[table]
+		size, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		name := path.Join(backupTablesDir, table+".jsonl")
+		if err := writeTarFile(tw, name, size, manifest.CreatedAt, f); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("error writing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("error writing archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// Import restores an archive created by Export into the database.  The database
+// must be empty, use the same driver as the exported database, and be migrated to
+// at least the archive's schema version.  All rows are imported within a single
+// transaction.
+func Import(ctx context.Context, db *sql.DB, driver string, r io.Reader) (*BackupManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("error reading archive: %w", err)
+	}
+	if hdr.Name != backupManifestFile {
+		return nil, fmt.Errorf("invalid archive: expected %s, found %s", backupManifestFile, hdr.Name)
+	}
+	manifest := &BackupManifest{}
+	if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("invalid archive manifest: %w", err)
+	}
+	if manifest.Version != BackupFormatVersion {
+		return nil, fmt.Errorf("unsupported archive version: %d", manifest.Version)
+	}
+	if manifest.Driver != driver {
+		return nil, fmt.Errorf("archive was exported from %s and cannot be imported into %s", manifest.Driver, driver)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting import transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.SchemaVersion > version {
+		return nil, fmt.Errorf("archive schema version %d is newer than the database's version %d; upgrade before importing", manifest.SchemaVersion, version)
+	}
+
+	tables, err := listTables(ctx, tx, driver)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		query, args, err := sq.Dialect(dialect(driver)).From(table).Select(sq.COUNT("*")).ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		var count int
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+			return nil, fmt.Errorf("error counting rows in %s: %w", table, err)
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("database is not empty: table %s has %d rows", table, count)
+		}
+	}
+
+	imported := map[string]int{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading archive: %w", err)
+		}
+
+		dir, file := path.Split(hdr.Name)
+		if path.Clean(dir) != backupTablesDir || !strings.HasSuffix(file, ".jsonl") {
+			return nil, fmt.Errorf("invalid archive: unexpected file %s", hdr.Name)
+		}
+		table := strings.TrimSuffix(file, ".jsonl")
+		if _, ok := manifest.Tables[table]; !ok {
+			return nil, fmt.Errorf("invalid archive: table %s is not in the manifest", table)
+		}
+		if !slices.Contains(tables, table) {
+			return nil, fmt.Errorf("table %s does not exist in the database", table)
+		}
+
+		n, err := importTable(ctx, tx, driver, table, tr)
+		if err != nil {
+			return nil, err
+		}
+		imported[table] = n
+	}
+
+	for table, n := range manifest.Tables {
+		if imported[table] != n {
+			return nil, fmt.Errorf("invalid archive: expected %d rows in %s, found %d", n, table, imported[table])
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing import: %w", err)
+	}
+	return manifest, nil
+}
+
+func exportTable(ctx context.Context, tx *sql.Tx, driver, table string, w io.Writer) (int, error) {
+	query, args, err := sq.Dialect(dialect(driver)).From(table).ToSQL()
+	if err != nil {
+		return 0, err
+	}
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("error reading %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	vals := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return 0, fmt.Errorf("error reading %s: %w", table, err)
+		}
+		row := make(map[string]any, len(cols))
+		for i, col := range cols {
+			row[col] = encodeBackupValue(vals[i])
+		}
+		if err := enc.Encode(row); err != nil {
+			return 0, fmt.Errorf("error writing %s: %w", table, err)
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error reading %s: %w", table, err)
+	}
+	return n, bw.Flush()
+}
+
+func importTable(ctx context.Context, tx *sql.Tx, driver, table string, r io.Reader) (int, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	n := 0
+	batch := []any{}
+	insert := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		query, args, err := sq.Dialect(dialect(driver)).Insert(table).Prepared(true).Rows(batch...).ToSQL()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error importing %s: %w", table, err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		raw := map[string]any{}
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid row in %s: %w", table, err)
+		}
+
+		row := make(sq.Record, len(raw))
+		for col, v := range raw {
+			if row[col], err = decodeBackupValue(v); err != nil {
+				return 0, fmt.Errorf("invalid value for %s.%s: %w", table, col, err)
+			}
+		}
+		batch = append(batch, row)
+		n++
+
+		if len(batch) == backupInsertBatch {
+			if err := insert(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, insert()
+}
+
+// encodeBackupValue converts a scanned column value into a JSON value.  Bytes and
+// times are wrapped in an object so that they're restored with their original
+// types, as plain JSON can't distinguish them from strings.
+func encodeBackupValue(v any) any {
+	switch val := v.(type) {
+	case []byte:
+		return map[string]string{"bytes": base64.StdEncoding.EncodeToString(val)}
+	case time.Time:
+		return map[string]string{"time": val.Format(time.RFC3339Nano)}
+	default:
+		return v
+	}
+}
+
+// decodeBackupValue reverses encodeBackupValue for a value decoded from JSON.
+func decodeBackupValue(v any) (any, error) {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i, nil
+		}
+		return val.Float64()
+	case map[string]any:
+		if b, ok := val["bytes"].(string); ok {
+			return base64.StdEncoding.DecodeString(b)
+		}
+		if t, ok := val["time"].(string); ok {
+			return time.Parse(time.RFC3339Nano, t)
+		}
+		return nil, fmt.Errorf("unknown value: %v", val)
+	default:
+		return v, nil
+	}
+}
+
+// schemaVersion returns the database's current migration version.
+func schemaVersion(ctx context.Context, tx *sql.Tx) (uint, error) {
+	var version uint
+	err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT version FROM %s LIMIT 1", migrationsTable)).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("error reading schema version: %w", err)
+	}
+	return version, nil
+}
+
+// listTables returns the names of all tables in the database, other than the
+// migrations table and the database's own internal tables.
+func listTables(ctx context.Context, tx *sql.Tx, driver string) ([]string, error) {
+	query := "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+	if driver == "postgres" {
+		query = "SELECT tablename FROM pg_tables WHERE schemaname = current_schema()"
+	}
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("error listing tables: %w", err)
+		}
+		if name == migrationsTable {
+			continue
+		}
+		tables = append(tables, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error listing tables: %w", err)
+	}
+
+	slices.Sort(tables)
+	return tables, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, size int64, modTime time.Time, r io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    size,
+		ModTime: modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("error writing archive: %w", err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("error writing archive: %w", err)
+	}
+	return nil
+}
+
+// dialect returns the goqu dialect for the given driver.
+func dialect(driver string) string {
+	if driver == "postgres" {
+		return "postgres"
+	}
+	return "sqlite3"
+}
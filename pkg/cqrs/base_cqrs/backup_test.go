@@ -0,0 +1,120 @@
+package base_cqrs
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	cm := NewCQRS(db, "sqlite")
+
+	app, err := cm.UpsertApp(ctx, cqrs.UpsertAppParams{
+		ID:          uuid.New(),
+		Name:        "backup-app",
+		SdkLanguage: "go",
+		SdkVersion:  "0.7.0",
+		Metadata:    "{}",
+		Url:         "http://localhost:3000/api/inngest",
+		Method:      "serve",
+	})
+	require.NoError(t, err)
+
+	dl := cqrs.DeadLetter{
+		RunID:        ulid.MustNew(ulid.Now(), rand.Reader),
+		AccountID:    uuid.New(),
+		WorkspaceID:  uuid.New(),
+		AppID:        app.ID,
+		FunctionID:   uuid.New(),
+		FunctionSlug: "backup-app-fn",
+		EventIDs:     []ulid.ULID{ulid.MustNew(ulid.Now(), rand.Reader)},
+		Events:       []json.RawMessage{json.RawMessage(`{"name":"test/event","data":{}}`)},
+		Error:        json.RawMessage(`{"name":"Error","message":"boom"}`),
+		FailedAt:     time.Now().Truncate(time.Millisecond),
+	}
+	require.NoError(t, cm.InsertDeadLetter(ctx, dl))
+
+	_, err = cm.InsertQueueSnapshot(ctx, cqrs.InsertQueueSnapshotParams{
+		Snapshot: cqrs.QueueSnapshot{
+			"{queue}:key": cqrs.SnapshotValue{Type: "string", Value: "value"},
+		},
+	})
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	exported, err := Export(ctx, db, "sqlite", buf)
+	require.NoError(t, err)
+	require.Equal(t, BackupFormatVersion, exported.Version)
+	require.NotZero(t, exported.SchemaVersion)
+	require.GreaterOrEqual(t, exported.Tables["apps"], 1)
+	require.NotContains(t, exported.Tables, migrationsTable)
+	archive := buf.Bytes()
+
+	target := newEmptyDB(t, "backup-import")
+	imported, err := Import(ctx, target, "sqlite", bytes.NewReader(archive))
+	require.NoError(t, err)
+	require.Equal(t, exported.Tables, imported.Tables)
+
+	t.Run("restores rows with their types", func(t *testing.T) {
+		tm := NewCQRS(target, "sqlite")
+
+		restoredApp, err := tm.GetAppByID(ctx, app.ID)
+		require.NoError(t, err)
+		require.Equal(t, app.Name, restoredApp.Name)
+		require.Equal(t, app.Url, restoredApp.Url)
+
+		restoredDL, err := tm.GetDeadLetter(ctx, dl.WorkspaceID, dl.RunID)
+		require.NoError(t, err)
+		require.Equal(t, dl.FunctionSlug, restoredDL.FunctionSlug)
+		require.JSONEq(t, string(dl.Error), string(restoredDL.Error))
+		require.True(t, dl.FailedAt.Equal(restoredDL.FailedAt))
+
+		snapshot, err := tm.GetLatestQueueSnapshot(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, snapshot)
+		require.Equal(t, "value", (*snapshot)["{queue}:key"].Value)
+	})
+
+	t.Run("re-exporting produces the same rows", func(t *testing.T) {
+		reexported, err := Export(ctx, target, "sqlite", &bytes.Buffer{})
+		require.NoError(t, err)
+		require.Equal(t, exported.Tables, reexported.Tables)
+	})
+
+	t.Run("refuses to import into a non-empty database", func(t *testing.T) {
+		_, err := Import(ctx, target, "sqlite", bytes.NewReader(archive))
+		require.ErrorContains(t, err, "database is not empty")
+	})
+
+	t.Run("refuses to import into another driver", func(t *testing.T) {
+		_, err := Import(ctx, newEmptyDB(t, "backup-driver"), "postgres", bytes.NewReader(archive))
+		require.ErrorContains(t, err, "cannot be imported into postgres")
+	})
+
+	t.Run("refuses invalid archives", func(t *testing.T) {
+		_, err := Import(ctx, newEmptyDB(t, "backup-invalid"), "sqlite", bytes.NewReader([]byte("nope")))
+		require.ErrorContains(t, err, "error reading archive")
+	})
+}
+
+// newEmptyDB returns a new, migrated in-memory SQLite database which is separate
+// from the database shared by New.
+func newEmptyDB(t *testing.T, name string) *sql.DB {
+	db, err := sql.Open("sqlite", "file:"+name+"?mode=memory&cache=shared")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, up(db, BaseCQRSOptions{InMemory: true}))
+	return db
+}
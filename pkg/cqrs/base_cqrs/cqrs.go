@@ -73,12 +73,17 @@ func (w wrapper) isPostgres() bool {
 	return w.driver == "postgres"
 }
 
-func (w wrapper) dialect() string {
-	if w.isPostgres() {
-		return "postgres"
+// conn returns the wrapper's transaction if it's scoped to one, otherwise the
+// database.
+func (w wrapper) conn() cqrs.DBWriter {
+	if w.tx != nil {
+		return w.tx
 	}
+	return w.db
+}
 
-	return "sqlite3"
+func (w wrapper) dialect() string {
+	return dialect(w.driver)
 }
 
 // LoadFunction implements the state.FunctionLoader interface.
@@ -117,8 +122,10 @@ func (w wrapper) WithTx(ctx context.Context) (cqrs.TxManager, error) {
 	}
 
 	return &wrapper{
-		q:  q,
-		tx: tx,
+		driver: w.driver,
+		q:      q,
+		db:     w.db,
+		tx:     tx,
 	}, nil
 }
 
@@ -397,14 +404,32 @@ func (w wrapper) GetFunctionByExternalID(ctx context.Context, wsID uuid.UUID, ap
 	f := func(ctx context.Context) (*sqlc.Function, error) {
 		return w.q.GetFunctionBySlug(ctx, fnSlug)
 	}
-	return copyInto(ctx, f, &cqrs.Function{})
+	return copyFunction(ctx, f)
 }
 
 func (w wrapper) GetFunctionByInternalUUID(ctx context.Context, wsID, fnID uuid.UUID) (*cqrs.Function, error) {
 	f := func(ctx context.Context) (*sqlc.Function, error) {
 		return w.q.GetFunctionByID(ctx, fnID)
 	}
-	return copyInto(ctx, f, &cqrs.Function{})
+	return copyFunction(ctx, f)
+}
+
+// copyFunction copies a single function row, including its archived time which
+// copier doesn't convert from sql.NullTime.
+func copyFunction(ctx context.Context, f func(context.Context) (*sqlc.Function, error)) (*cqrs.Function, error) {
+	row, err := f(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fn := &cqrs.Function{}
+	if err := copier.CopyWithOption(fn, row, copier.Option{DeepCopy: true}); err != nil {
+		return nil, err
+	}
+	fn.ArchivedAt = time.Time{}
+	if row.ArchivedAt.Valid {
+		fn.ArchivedAt = row.ArchivedAt.Time
+	}
+	return fn, nil
 }
 
 func (w wrapper) GetFunctions(ctx context.Context) ([]*cqrs.Function, error) {
@@ -0,0 +1,59 @@
+package base_cqrs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+)
+
+const tableCronSchedules = "cron_schedules"
+
+func (w wrapper) GetCronLastFired(ctx context.Context, fnID uuid.UUID, spec string) (*time.Time, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableCronSchedules).
+		Select("last_fired_at").
+		Where(sq.C("function_id").Eq(fnID.String()), sq.C("cron").Eq(spec)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	var ms int64
+	err = w.db.QueryRowContext(ctx, query, args...).Scan(&ms)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	at := time.UnixMilli(ms)
+	return &at, nil
+}
+
+func (w wrapper) SetCronLastFired(ctx context.Context, fnID uuid.UUID, spec string, at time.Time) error {
+	// Only move the last fired time forwards, so that late catch-up ticks never
+	// cause later ticks to be enqueued again.
+	greatest := "MAX"
+	if w.isPostgres() {
+		greatest = "GREATEST"
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).Insert(tableCronSchedules).Rows(sq.Record{
+		"function_id":   fnID.String(),
+		"cron":          spec,
+		"last_fired_at": at.UnixMilli(),
+	}).OnConflict(sq.DoUpdate("function_id, cron", sq.Record{
+		"last_fired_at": sq.L(fmt.Sprintf("%s(%s.last_fired_at, excluded.last_fired_at)", greatest, tableCronSchedules)),
+	})).Prepared(true).ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = w.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,46 @@
+package base_cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronLastFired(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	cm := NewCQRS(db, "sqlite")
+
+	fnID := uuid.New()
+	spec := "CRON_TZ=Europe/Berlin 0 2 * * *"
+
+	last, err := cm.GetCronLastFired(ctx, fnID, spec)
+	require.NoError(t, err)
+	require.Nil(t, last)
+
+	at := time.Now().Truncate(time.Minute)
+	require.NoError(t, cm.SetCronLastFired(ctx, fnID, spec, at))
+	last, err = cm.GetCronLastFired(ctx, fnID, spec)
+	require.NoError(t, err)
+	require.True(t, at.Equal(*last))
+
+	// Earlier times never move the last fired time backwards.
+	require.NoError(t, cm.SetCronLastFired(ctx, fnID, spec, at.Add(-time.Hour)))
+	last, err = cm.GetCronLastFired(ctx, fnID, spec)
+	require.NoError(t, err)
+	require.True(t, at.Equal(*last))
+
+	require.NoError(t, cm.SetCronLastFired(ctx, fnID, spec, at.Add(time.Hour)))
+	last, err = cm.GetCronLastFired(ctx, fnID, spec)
+	require.NoError(t, err)
+	require.True(t, at.Add(time.Hour).Equal(*last))
+
+	// Schedules are tracked independently.
+	last, err = cm.GetCronLastFired(ctx, fnID, "CRON_TZ=UTC 0 2 * * *")
+	require.NoError(t, err)
+	require.Nil(t, last)
+}
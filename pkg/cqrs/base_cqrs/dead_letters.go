@@ -0,0 +1,266 @@
+package base_cqrs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	tableDeadLetters = "dead_letters"
+
+	defaultDeadLetterLimit = 40
+)
+
+var deadLetterColumns = []any{
+	"run_id",
+	"account_id",
+	"workspace_id",
+	"app_id",
+	"function_id",
+	"function_slug",
+	"event_ids",
+	"events",
+	"error",
+	"last_step_id",
+	"last_step_name",
+	"original_run_id",
+	"failed_at",
+	"redrive_run_id",
+	"redriven_at",
+}
+
+func (w wrapper) InsertDeadLetter(ctx context.Context, dl cqrs.DeadLetter) error {
+	eventIDs, err := json.Marshal(dl.EventIDs)
+	if err != nil {
+		return fmt.Errorf("error marshalling dead letter event IDs: %w", err)
+	}
+	events, err := json.Marshal(dl.Events)
+	if err != nil {
+		return fmt.Errorf("error marshalling dead letter events: %w", err)
+	}
+
+	var originalRunID *string
+	if dl.OriginalRunID != nil {
+		id := dl.OriginalRunID.String()
+		originalRunID = &id
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).Insert(tableDeadLetters).Rows(sq.Record{
+		"run_id":          dl.RunID.String(),
+		"account_id":      dl.AccountID.String(),
+		"workspace_id":    dl.WorkspaceID.String(),
+		"app_id":          dl.AppID.String(),
+		"function_id":     dl.FunctionID.String(),
+		"function_slug":   dl.FunctionSlug,
+		"event_ids":       eventIDs,
+		"events":          events,
+		"error":           []byte(dl.Error),
+		"last_step_id":    dl.LastStepID,
+		"last_step_name":  dl.LastStepName,
+		"original_run_id": originalRunID,
+		"failed_at":       dl.FailedAt.UnixMilli(),
+	}).OnConflict(sq.DoNothing()).Prepared(true).ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = w.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (w wrapper) SetDeadLetterRedriven(ctx context.Context, wsID uuid.UUID, runID ulid.ULID, redriveRunID ulid.ULID) error {
+	query, args, err := sq.Dialect(w.dialect()).Update(tableDeadLetters).Set(sq.Record{
+		"redrive_run_id": redriveRunID.String(),
+		"redriven_at":    time.Now().UnixMilli(),
+	}).Where(
+		sq.C("workspace_id").Eq(wsID.String()),
+		sq.C("run_id").Eq(runID.String()),
+	).Prepared(true).ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = w.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (w wrapper) PurgeDeadLetters(ctx context.Context, wsID uuid.UUID, opts cqrs.PurgeDeadLettersOpts) (int64, error) {
+	filter := []sq.Expression{sq.C("workspace_id").Eq(wsID.String())}
+	if opts.FunctionID != nil {
+		filter = append(filter, sq.C("function_id").Eq(opts.FunctionID.String()))
+	}
+	if len(opts.RunIDs) > 0 {
+		ids := make([]string, len(opts.RunIDs))
+		for n, id := range opts.RunIDs {
+			ids[n] = id.String()
+		}
+		filter = append(filter, sq.C("run_id").In(ids))
+	}
+	if opts.Before != nil {
+		filter = append(filter, sq.C("failed_at").Lt(opts.Before.UnixMilli()))
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).Delete(tableDeadLetters).Where(filter...).Prepared(true).ToSQL()
+	if err != nil {
+		return 0, err
+	}
+	res, err := w.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (w wrapper) GetDeadLetter(ctx context.Context, wsID uuid.UUID, runID ulid.ULID) (*cqrs.DeadLetter, error) {
+	query, args, err := sq.Dialect(w.dialect()).From(tableDeadLetters).Select(deadLetterColumns...).Where(
+		sq.C("workspace_id").Eq(wsID.String()),
+		sq.C("run_id").Eq(runID.String()),
+	).Prepared(true).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+	return scanDeadLetter(rows)
+}
+
+func (w wrapper) GetDeadLetters(ctx context.Context, wsID uuid.UUID, opts cqrs.GetDeadLettersOpts) ([]*cqrs.DeadLetter, error) {
+	filter := []sq.Expression{sq.C("workspace_id").Eq(wsID.String())}
+	if opts.FunctionID != nil {
+		filter = append(filter, sq.C("function_id").Eq(opts.FunctionID.String()))
+	}
+	if opts.Cursor != nil {
+		filter = append(filter, sq.C("run_id").Lt(opts.Cursor.String()))
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultDeadLetterLimit
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).From(tableDeadLetters).Select(deadLetterColumns...).
+		Where(filter...).
+		Order(sq.C("run_id").Desc()).
+		Limit(uint(limit)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []*cqrs.DeadLetter{}
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, dl)
+	}
+	return res, rows.Err()
+}
+
+func scanDeadLetter(rows *sql.Rows) (*cqrs.DeadLetter, error) {
+	var (
+		runID, accountID, wsID, appID, fnID string
+		eventIDs, events, errByt            []byte
+		originalRunID, redriveRunID         sql.NullString
+		lastStepID, lastStepName            sql.NullString
+		failedAt                            int64
+		redrivenAt                          sql.NullInt64
+		dl                                  = &cqrs.DeadLetter{}
+		err                                 error
+	)
+
+	if err := rows.Scan(
+		&runID,
+		&accountID,
+		&wsID,
+		&appID,
+		&fnID,
+		&dl.FunctionSlug,
+		&eventIDs,
+		&events,
+		&errByt,
+		&lastStepID,
+		&lastStepName,
+		&originalRunID,
+		&failedAt,
+		&redriveRunID,
+		&redrivenAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if dl.RunID, err = ulid.Parse(runID); err != nil {
+		return nil, err
+	}
+	if dl.AccountID, err = uuid.Parse(accountID); err != nil {
+		return nil, err
+	}
+	if dl.WorkspaceID, err = uuid.Parse(wsID); err != nil {
+		return nil, err
+	}
+	if dl.AppID, err = uuid.Parse(appID); err != nil {
+		return nil, err
+	}
+	if dl.FunctionID, err = uuid.Parse(fnID); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(eventIDs, &dl.EventIDs); err != nil {
+		return nil, fmt.Errorf("error unmarshalling dead letter event IDs: %w", err)
+	}
+	if err := json.Unmarshal(events, &dl.Events); err != nil {
+		return nil, fmt.Errorf("error unmarshalling dead letter events: %w", err)
+	}
+	if len(errByt) > 0 {
+		dl.Error = errByt
+	}
+	if lastStepID.Valid {
+		dl.LastStepID = &lastStepID.String
+	}
+	if lastStepName.Valid {
+		dl.LastStepName = &lastStepName.String
+	}
+	if originalRunID.Valid {
+		id, err := ulid.Parse(originalRunID.String)
+		if err != nil {
+			return nil, err
+		}
+		dl.OriginalRunID = &id
+	}
+	if redriveRunID.Valid {
+		id, err := ulid.Parse(redriveRunID.String)
+		if err != nil {
+			return nil, err
+		}
+		dl.RedriveRunID = &id
+	}
+	if redrivenAt.Valid {
+		at := time.UnixMilli(redrivenAt.Int64)
+		dl.RedrivenAt = &at
+	}
+	dl.FailedAt = time.UnixMilli(failedAt)
+	return dl, nil
+}
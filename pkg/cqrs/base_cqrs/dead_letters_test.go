@@ -0,0 +1,107 @@
+package base_cqrs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	cm := NewCQRS(db, "sqlite")
+
+	wsID := uuid.New()
+	fnA, fnB := uuid.New(), uuid.New()
+	stepID := "step-hash"
+	stepName := "charge card"
+
+	insert := func(fnID uuid.UUID, failedAt time.Time) cqrs.DeadLetter {
+		dl := cqrs.DeadLetter{
+			RunID:        ulid.MustNew(ulid.Timestamp(failedAt), rand.Reader),
+			AccountID:    uuid.New(),
+			WorkspaceID:  wsID,
+			AppID:        uuid.New(),
+			FunctionID:   fnID,
+			FunctionSlug: "app-fn",
+			EventIDs:     []ulid.ULID{ulid.MustNew(ulid.Now(), rand.Reader)},
+			Events:       []json.RawMessage{json.RawMessage(`{"name":"test/event","data":{}}`)},
+			Error:        json.RawMessage(`{"name":"Error","message":"boom"}`),
+			LastStepID:   &stepID,
+			LastStepName: &stepName,
+			FailedAt:     failedAt,
+		}
+		require.NoError(t, cm.InsertDeadLetter(ctx, dl))
+		// Inserting the same run twice is a no-op.
+		require.NoError(t, cm.InsertDeadLetter(ctx, dl))
+		return dl
+	}
+
+	now := time.Now()
+	old := insert(fnA, now.Add(-time.Hour))
+	a := insert(fnA, now.Add(-time.Second))
+	b := insert(fnB, now)
+
+	t.Run("lists dead letters newest first", func(t *testing.T) {
+		dls, err := cm.GetDeadLetters(ctx, wsID, cqrs.GetDeadLettersOpts{})
+		require.NoError(t, err)
+		require.Len(t, dls, 3)
+		require.Equal(t, b.RunID, dls[0].RunID)
+		require.Equal(t, old.RunID, dls[2].RunID)
+
+		dls, err = cm.GetDeadLetters(ctx, wsID, cqrs.GetDeadLettersOpts{FunctionID: &fnA, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, dls, 1)
+		require.Equal(t, a.RunID, dls[0].RunID)
+
+		dls, err = cm.GetDeadLetters(ctx, wsID, cqrs.GetDeadLettersOpts{FunctionID: &fnA, Cursor: &a.RunID})
+		require.NoError(t, err)
+		require.Len(t, dls, 1)
+		require.Equal(t, old.RunID, dls[0].RunID)
+	})
+
+	t.Run("loads and redrives a dead letter", func(t *testing.T) {
+		dl, err := cm.GetDeadLetter(ctx, wsID, a.RunID)
+		require.NoError(t, err)
+		require.Equal(t, a.EventIDs, dl.EventIDs)
+		require.JSONEq(t, string(a.Events[0]), string(dl.Events[0]))
+		require.JSONEq(t, string(a.Error), string(dl.Error))
+		require.Equal(t, stepID, *dl.LastStepID)
+		require.Equal(t, stepName, *dl.LastStepName)
+		require.Nil(t, dl.RedriveRunID)
+
+		redriveID := ulid.MustNew(ulid.Now(), rand.Reader)
+		require.NoError(t, cm.SetDeadLetterRedriven(ctx, wsID, a.RunID, redriveID))
+		dl, err = cm.GetDeadLetter(ctx, wsID, a.RunID)
+		require.NoError(t, err)
+		require.Equal(t, redriveID, *dl.RedriveRunID)
+		require.NotNil(t, dl.RedrivenAt)
+
+		_, err = cm.GetDeadLetter(ctx, uuid.New(), a.RunID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("purges dead letters", func(t *testing.T) {
+		before := time.Now().Add(-time.Minute)
+		n, err := cm.PurgeDeadLetters(ctx, wsID, cqrs.PurgeDeadLettersOpts{Before: &before})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, n)
+
+		n, err = cm.PurgeDeadLetters(ctx, wsID, cqrs.PurgeDeadLettersOpts{RunIDs: []ulid.ULID{b.RunID}})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, n)
+
+		n, err = cm.PurgeDeadLetters(ctx, wsID, cqrs.PurgeDeadLettersOpts{})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, n)
+	})
+}
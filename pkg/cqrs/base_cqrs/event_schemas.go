@@ -0,0 +1,248 @@
+package base_cqrs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	tableEventSchemas        = "event_schemas"
+	tableEventSchemaFailures = "event_schema_failures"
+
+	defaultEventSchemaFailureLimit = 40
+)
+
+var eventSchemaColumns = []any{
+	"name",
+	"schema",
+	"mode",
+	"app_id",
+	"created_at",
+	"updated_at",
+}
+
+var eventSchemaFailureColumns = []any{
+	"id",
+	"event_name",
+	"mode",
+	"event_id",
+	"event",
+	"errors",
+	"received_at",
+}
+
+func (w wrapper) GetEventSchemas(ctx context.Context) ([]*cqrs.EventSchema, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableEventSchemas).
+		Select(eventSchemaColumns...).
+		Order(sq.C("name").Asc()).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []*cqrs.EventSchema{}
+	for rows.Next() {
+		s, err := scanEventSchema(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+func (w wrapper) GetEventSchema(ctx context.Context, name string) (*cqrs.EventSchema, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableEventSchemas).
+		Select(eventSchemaColumns...).
+		Where(sq.C("name").Eq(name)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return scanEventSchema(w.conn().QueryRowContext(ctx, query, args...))
+}
+
+func (w wrapper) UpsertEventSchema(ctx context.Context, s cqrs.EventSchema) (*cqrs.EventSchema, error) {
+	now := time.Now().Truncate(time.Millisecond)
+	s.UpdatedAt = now
+	if existing, err := w.GetEventSchema(ctx, s.Name); err == nil {
+		s.CreatedAt = existing.CreatedAt
+	} else {
+		s.CreatedAt = now
+	}
+
+	var appID *string
+	if s.AppID != nil {
+		id := s.AppID.String()
+		appID = &id
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).Insert(tableEventSchemas).Rows(sq.Record{
+		"name":       s.Name,
+		"schema":     []byte(s.Schema),
+		"mode":       string(s.Mode),
+		"app_id":     appID,
+		"created_at": s.CreatedAt.UnixMilli(),
+		"updated_at": s.UpdatedAt.UnixMilli(),
+	}).OnConflict(sq.DoUpdate("name", sq.Record{
+		"schema":     []byte(s.Schema),
+		"mode":       string(s.Mode),
+		"app_id":     appID,
+		"updated_at": s.UpdatedAt.UnixMilli(),
+	})).Prepared(true).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (w wrapper) DeleteEventSchemas(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	query, args, err := sq.Dialect(w.dialect()).
+		Delete(tableEventSchemas).
+		Where(sq.C("name").In(names)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = w.conn().ExecContext(ctx, query, args...)
+	return err
+}
+
+func (w wrapper) InsertEventSchemaFailure(ctx context.Context, f cqrs.EventSchemaFailure) error {
+	errs, err := json.Marshal(f.Errors)
+	if err != nil {
+		return fmt.Errorf("error marshalling event schema errors: %w", err)
+	}
+
+	var eventID *string
+	if f.EventID != nil {
+		id := f.EventID.String()
+		eventID = &id
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).Insert(tableEventSchemaFailures).Rows(sq.Record{
+		"id":          f.ID.String(),
+		"event_name":  f.EventName,
+		"mode":        string(f.Mode),
+		"event_id":    eventID,
+		"event":       []byte(f.Event),
+		"errors":      errs,
+		"received_at": f.ReceivedAt.UnixMilli(),
+	}).Prepared(true).ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = w.conn().ExecContext(ctx, query, args...)
+	return err
+}
+
+func (w wrapper) GetEventSchemaFailures(ctx context.Context, opts cqrs.GetEventSchemaFailuresOpts) ([]*cqrs.EventSchemaFailure, error) {
+	filter := []sq.Expression{}
+	if opts.EventName != nil {
+		filter = append(filter, sq.C("event_name").Eq(*opts.EventName))
+	}
+	if opts.Cursor != nil {
+		filter = append(filter, sq.C("id").Lt(opts.Cursor.String()))
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultEventSchemaFailureLimit
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableEventSchemaFailures).
+		Select(eventSchemaFailureColumns...).
+		Where(filter...).
+		Order(sq.C("id").Desc()).
+		Limit(uint(limit)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []*cqrs.EventSchemaFailure{}
+	for rows.Next() {
+		var (
+			f          = &cqrs.EventSchemaFailure{}
+			id, mode   string
+			eventID    sql.NullString
+			errs       []byte
+			receivedAt int64
+		)
+		if err := rows.Scan(&id, &f.EventName, &mode, &eventID, &f.Event, &errs, &receivedAt); err != nil {
+			return nil, err
+		}
+		if f.ID, err = ulid.Parse(id); err != nil {
+			return nil, err
+		}
+		if eventID.Valid {
+			id, err := ulid.Parse(eventID.String)
+			if err != nil {
+				return nil, err
+			}
+			f.EventID = &id
+		}
+		if err := json.Unmarshal(errs, &f.Errors); err != nil {
+			return nil, fmt.Errorf("error unmarshalling event schema errors: %w", err)
+		}
+		f.Mode = cqrs.EventSchemaMode(mode)
+		f.ReceivedAt = time.UnixMilli(receivedAt)
+		res = append(res, f)
+	}
+	return res, rows.Err()
+}
+
+func scanEventSchema(row interface{ Scan(...any) error }) (*cqrs.EventSchema, error) {
+	var (
+		s                    cqrs.EventSchema
+		mode                 string
+		appID                sql.NullString
+		createdAt, updatedAt int64
+	)
+	if err := row.Scan(&s.Name, &s.Schema, &mode, &appID, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	if appID.Valid {
+		id, err := uuid.Parse(appID.String)
+		if err != nil {
+			return nil, err
+		}
+		s.AppID = &id
+	}
+	s.Mode = cqrs.EventSchemaMode(mode)
+	s.CreatedAt = time.UnixMilli(createdAt)
+	s.UpdatedAt = time.UnixMilli(updatedAt)
+	return &s, nil
+}
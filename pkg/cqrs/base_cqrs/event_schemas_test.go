@@ -0,0 +1,104 @@
+package base_cqrs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSchemas(t *testing.T) {
+	ctx := context.Background()
+	cm := NewCQRS(newEmptyDB(t, "event-schemas"), "sqlite")
+
+	_, err := cm.GetEventSchema(ctx, "user/signup")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	appID := uuid.New()
+	created, err := cm.UpsertEventSchema(ctx, cqrs.EventSchema{
+		Name:   "user/signup",
+		Schema: json.RawMessage(`{"type":"object"}`),
+		Mode:   cqrs.EventSchemaModeReject,
+		AppID:  &appID,
+	})
+	require.NoError(t, err)
+
+	_, err = cm.UpsertEventSchema(ctx, cqrs.EventSchema{
+		Name:   "order/created",
+		Schema: json.RawMessage(`{"type":"object"}`),
+		Mode:   cqrs.EventSchemaModeWarn,
+	})
+	require.NoError(t, err)
+
+	t.Run("upserting replaces the schema", func(t *testing.T) {
+		time.Sleep(2 * time.Millisecond)
+		updated, err := cm.UpsertEventSchema(ctx, cqrs.EventSchema{
+			Name:   "user/signup",
+			Schema: json.RawMessage(`{"required":["id"]}`),
+			Mode:   cqrs.EventSchemaModeTag,
+		})
+		require.NoError(t, err)
+
+		s, err := cm.GetEventSchema(ctx, "user/signup")
+		require.NoError(t, err)
+		require.JSONEq(t, `{"required":["id"]}`, string(s.Schema))
+		require.Equal(t, cqrs.EventSchemaModeTag, s.Mode)
+		require.Nil(t, s.AppID)
+		require.True(t, created.CreatedAt.Equal(s.CreatedAt))
+		require.True(t, updated.UpdatedAt.Equal(s.UpdatedAt))
+		require.True(t, s.UpdatedAt.After(s.CreatedAt))
+	})
+
+	t.Run("schemas are listed by name", func(t *testing.T) {
+		schemas, err := cm.GetEventSchemas(ctx)
+		require.NoError(t, err)
+		require.Len(t, schemas, 2)
+		require.Equal(t, "order/created", schemas[0].Name)
+		require.Equal(t, "user/signup", schemas[1].Name)
+	})
+
+	t.Run("failures are listed newest first", func(t *testing.T) {
+		eventID := ulid.MustNew(ulid.Now(), rand.Reader)
+		for i, name := range []string{"user/signup", "order/created", "user/signup"} {
+			f := cqrs.EventSchemaFailure{
+				ID:         ulid.MustNew(ulid.Now()+uint64(i), rand.Reader),
+				EventName:  name,
+				Mode:       cqrs.EventSchemaModeWarn,
+				Event:      json.RawMessage(`{"name":"` + name + `","data":{}}`),
+				Errors:     []string{"data.id: is required"},
+				ReceivedAt: time.Now(),
+			}
+			if i == 2 {
+				f.EventID = &eventID
+			}
+			require.NoError(t, cm.InsertEventSchemaFailure(ctx, f))
+		}
+
+		name := "user/signup"
+		failures, err := cm.GetEventSchemaFailures(ctx, cqrs.GetEventSchemaFailuresOpts{EventName: &name})
+		require.NoError(t, err)
+		require.Len(t, failures, 2)
+		require.Equal(t, &eventID, failures[0].EventID)
+		require.Nil(t, failures[1].EventID)
+		require.Equal(t, []string{"data.id: is required"}, failures[0].Errors)
+
+		page, err := cm.GetEventSchemaFailures(ctx, cqrs.GetEventSchemaFailuresOpts{Cursor: &failures[0].ID, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, "order/created", page[0].EventName)
+	})
+
+	t.Run("deleting schemas", func(t *testing.T) {
+		require.NoError(t, cm.DeleteEventSchemas(ctx, []string{"user/signup", "order/created"}))
+		schemas, err := cm.GetEventSchemas(ctx)
+		require.NoError(t, err)
+		require.Empty(t, schemas)
+	})
+}
@@ -0,0 +1,280 @@
+package base_cqrs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/function"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+)
+
+const tableFunctionVersions = "function_versions"
+
+var functionVersionColumns = []any{
+	"function_id",
+	"version",
+	"config",
+	"pinned",
+	"valid_from",
+	"valid_to",
+	"created_at",
+}
+
+func (w wrapper) GetFunctionVersions(ctx context.Context, fnID uuid.UUID) ([]*function.FunctionVersion, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableFunctionVersions).
+		Select(functionVersionColumns...).
+		Where(sq.C("function_id").Eq(fnID.String())).
+		Order(sq.C("version").Desc()).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := []*function.FunctionVersion{}
+	for rows.Next() {
+		v, err := scanFunctionVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func (w wrapper) GetFunctionVersion(ctx context.Context, fnID uuid.UUID, version uint) (*function.FunctionVersion, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableFunctionVersions).
+		Select(functionVersionColumns...).
+		Where(sq.C("function_id").Eq(fnID.String()), sq.C("version").Eq(version)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return scanFunctionVersion(w.conn().QueryRowContext(ctx, query, args...))
+}
+
+func (w wrapper) InsertFunctionVersion(ctx context.Context, fnID uuid.UUID, config string) (*function.FunctionVersion, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableFunctionVersions).
+		Select(sq.COALESCE(sq.MAX("version"), 0)).
+		Where(sq.C("function_id").Eq(fnID.String())).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	var latest uint
+	if err := w.conn().QueryRowContext(ctx, query, args...).Scan(&latest); err != nil {
+		return nil, err
+	}
+
+	fn := inngest.Function{}
+	if err := json.Unmarshal([]byte(config), &fn); err != nil {
+		return nil, fmt.Errorf("error unmarshalling function config: %w", err)
+	}
+	fn.FunctionVersion = int(latest + 1)
+	byt, err := json.Marshal(fn)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling function config: %w", err)
+	}
+
+	v := &function.FunctionVersion{
+		FunctionID: fnID.String(),
+		Version:    latest + 1,
+		Config:     string(byt),
+		Function:   fn,
+		CreatedAt:  time.Now().Truncate(time.Millisecond),
+	}
+	v.UpdatedAt = v.CreatedAt
+
+	query, args, err = sq.Dialect(w.dialect()).Insert(tableFunctionVersions).Rows(sq.Record{
+		"function_id": v.FunctionID,
+		"version":     v.Version,
+		"config":      v.Config,
+		"pinned":      false,
+		"created_at":  v.CreatedAt.UnixMilli(),
+	}).Prepared(true).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (w wrapper) SetLiveFunctionVersion(ctx context.Context, fnID uuid.UUID, version uint) (*function.FunctionVersion, error) {
+	v, err := w.GetFunctionVersion(ctx, fnID, version)
+	if err != nil {
+		return nil, err
+	}
+	if !v.Draft() {
+		return nil, fmt.Errorf("function version %d has already been live", version)
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+
+	// End the current live version's window.
+	query, args, err := sq.Dialect(w.dialect()).
+		Update(tableFunctionVersions).
+		Set(sq.Record{"valid_to": now.UnixMilli()}).
+		Where(
+			sq.C("function_id").Eq(fnID.String()),
+			sq.C("valid_from").IsNotNull(),
+			sq.C("valid_to").IsNull(),
+		).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	query, args, err = sq.Dialect(w.dialect()).
+		Update(tableFunctionVersions).
+		Set(sq.Record{"valid_from": now.UnixMilli()}).
+		Where(sq.C("function_id").Eq(fnID.String()), sq.C("version").Eq(version)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	_, err = w.UpdateFunctionConfig(ctx, cqrs.UpdateFunctionConfigParams{
+		ID:     fnID,
+		Config: v.Config,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error updating function config: %w", err)
+	}
+
+	v.ValidFrom = &now
+	v.UpdatedAt = now
+	return v, nil
+}
+
+func (w wrapper) PinFunctionVersion(ctx context.Context, fnID uuid.UUID, version *uint) error {
+	if version != nil {
+		// Ensure the version exists before unpinning the current version.
+		if _, err := w.GetFunctionVersion(ctx, fnID, *version); err != nil {
+			return err
+		}
+	}
+
+	query, args, err := sq.Dialect(w.dialect()).
+		Update(tableFunctionVersions).
+		Set(sq.Record{"pinned": false}).
+		Where(sq.C("function_id").Eq(fnID.String()), sq.C("pinned").IsTrue()).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+	if version == nil {
+		return nil
+	}
+
+	query, args, err = sq.Dialect(w.dialect()).
+		Update(tableFunctionVersions).
+		Set(sq.Record{"pinned": true}).
+		Where(sq.C("function_id").Eq(fnID.String()), sq.C("version").Eq(*version)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = w.conn().ExecContext(ctx, query, args...)
+	return err
+}
+
+func (w wrapper) GetFunctionVersionRunIDs(ctx context.Context, fnID uuid.UUID, version uint, limit int) ([]ulid.ULID, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From("function_runs").
+		Select("run_id").
+		Where(sq.C("function_id").Eq(fnID.String()), sq.C("function_version").Eq(version)).
+		Order(sq.C("run_started_at").Desc()).
+		Limit(uint(limit)).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []ulid.ULID{}
+	for rows.Next() {
+		var id ulid.ULID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func scanFunctionVersion(row interface{ Scan(...any) error }) (*function.FunctionVersion, error) {
+	var (
+		v                  function.FunctionVersion
+		validFrom, validTo sql.NullInt64
+		createdAt          int64
+	)
+	err := row.Scan(
+		&v.FunctionID,
+		&v.Version,
+		&v.Config,
+		&v.Pinned,
+		&validFrom,
+		&validTo,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(v.Config), &v.Function); err != nil {
+		return nil, fmt.Errorf("error unmarshalling function config: %w", err)
+	}
+
+	v.CreatedAt = time.UnixMilli(createdAt)
+	v.UpdatedAt = v.CreatedAt
+	if validFrom.Valid {
+		t := time.UnixMilli(validFrom.Int64)
+		v.ValidFrom = &t
+		v.UpdatedAt = t
+	}
+	if validTo.Valid {
+		t := time.UnixMilli(validTo.Int64)
+		v.ValidTo = &t
+		v.UpdatedAt = t
+	}
+	return &v, nil
+}
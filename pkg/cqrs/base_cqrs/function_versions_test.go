@@ -0,0 +1,113 @@
+package base_cqrs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionVersions(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	cm := NewCQRS(db, "sqlite")
+
+	fnID := uuid.New()
+	config := func(key string) string {
+		return fmt.Sprintf(`{"id":%q,"slug":"fn","name":"Versioned","concurrency":[{"limit":1,"key":%q}]}`, fnID, key)
+	}
+	v1Config := config("event.data.user_id")
+	_, err = cm.InsertFunction(ctx, cqrs.InsertFunctionParams{
+		ID:        fnID,
+		AppID:     uuid.New(),
+		Name:      "Versioned",
+		Slug:      "fn",
+		Config:    v1Config,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	v1, err := cm.InsertFunctionVersion(ctx, fnID, v1Config)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, v1.Version)
+	require.Equal(t, 1, v1.Function.FunctionVersion)
+	require.True(t, v1.Draft())
+
+	v1, err = cm.SetLiveFunctionVersion(ctx, fnID, 1)
+	require.NoError(t, err)
+	require.True(t, v1.Live())
+
+	_, err = cm.SetLiveFunctionVersion(ctx, fnID, 1)
+	require.ErrorContains(t, err, "already been live")
+
+	t.Run("new live versions end the previous version's window", func(t *testing.T) {
+		tx, err := cm.WithTx(ctx)
+		require.NoError(t, err)
+		v2, err := tx.InsertFunctionVersion(ctx, fnID, config("event.data.account_id"))
+		require.NoError(t, err)
+		_, err = tx.SetLiveFunctionVersion(ctx, fnID, v2.Version)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit(ctx))
+
+		versions, err := cm.GetFunctionVersions(ctx, fnID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.EqualValues(t, 2, versions[0].Version)
+		require.True(t, versions[0].Live())
+		require.False(t, versions[1].Live())
+		require.NotNil(t, versions[1].ValidTo)
+
+		fn, err := cm.GetFunctionByInternalUUID(ctx, uuid.Nil, fnID)
+		require.NoError(t, err)
+		f, err := fn.InngestFunction()
+		require.NoError(t, err)
+		require.Equal(t, 2, f.FunctionVersion)
+		require.Equal(t, "event.data.account_id", *f.Concurrency.Limits[0].Key)
+	})
+
+	t.Run("pins a single version", func(t *testing.T) {
+		one, two := uint(1), uint(2)
+		require.NoError(t, cm.PinFunctionVersion(ctx, fnID, &one))
+		require.NoError(t, cm.PinFunctionVersion(ctx, fnID, &two))
+
+		versions, err := cm.GetFunctionVersions(ctx, fnID)
+		require.NoError(t, err)
+		require.True(t, versions[0].Pinned)
+		require.False(t, versions[1].Pinned)
+
+		require.NoError(t, cm.PinFunctionVersion(ctx, fnID, nil))
+		v, err := cm.GetFunctionVersion(ctx, fnID, 2)
+		require.NoError(t, err)
+		require.False(t, v.Pinned)
+
+		missing := uint(10)
+		require.ErrorIs(t, cm.PinFunctionVersion(ctx, fnID, &missing), sql.ErrNoRows)
+	})
+
+	t.Run("lists runs using a version", func(t *testing.T) {
+		runID := ulid.MustNew(ulid.Now(), rand.Reader)
+		require.NoError(t, cm.InsertFunctionRun(ctx, cqrs.FunctionRun{
+			RunID:           runID,
+			RunStartedAt:    time.Now(),
+			FunctionID:      fnID,
+			FunctionVersion: 1,
+			EventID:         ulid.MustNew(ulid.Now(), rand.Reader),
+		}))
+
+		ids, err := cm.GetFunctionVersionRunIDs(ctx, fnID, 1, 10)
+		require.NoError(t, err)
+		require.Equal(t, []ulid.ULID{runID}, ids)
+
+		ids, err = cm.GetFunctionVersionRunIDs(ctx, fnID, 2, 10)
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+}
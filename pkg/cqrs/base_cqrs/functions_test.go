@@ -0,0 +1,52 @@
+package base_cqrs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFunctionByInternalUUIDArchived(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	cm := NewCQRS(db, "sqlite")
+
+	app, err := cm.UpsertApp(ctx, cqrs.UpsertAppParams{
+		ID:          uuid.New(),
+		Name:        "app",
+		SdkLanguage: "go",
+		SdkVersion:  "1.0.0",
+		Url:         "http://localhost:3000/api/inngest",
+	})
+	require.NoError(t, err)
+
+	fnID := uuid.New()
+	_, err = cm.InsertFunction(ctx, cqrs.InsertFunctionParams{
+		ID:        fnID,
+		AppID:     app.ID,
+		Name:      "fn",
+		Slug:      "app-fn",
+		Config:    "{}",
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	fn, err := cm.GetFunctionByInternalUUID(ctx, consts.DevServerEnvId, fnID)
+	require.NoError(t, err)
+	require.True(t, fn.ArchivedAt.IsZero())
+
+	require.NoError(t, cm.DeleteFunctionsByIDs(ctx, []uuid.UUID{fnID}))
+	fn, err = cm.GetFunctionByInternalUUID(ctx, consts.DevServerEnvId, fnID)
+	require.NoError(t, err)
+	require.False(t, fn.ArchivedAt.IsZero())
+
+	_, err = cm.GetFunctionByInternalUUID(ctx, consts.DevServerEnvId, uuid.New())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS state_pauses;
+DROP TABLE IF EXISTS state_idempotency;
+DROP TABLE IF EXISTS state_steps;
+DROP TABLE IF EXISTS state_runs;
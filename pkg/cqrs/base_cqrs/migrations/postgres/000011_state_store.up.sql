@@ -0,0 +1,52 @@
+CREATE TABLE state_runs (
+    run_id CHAR(26) PRIMARY KEY,
+    account_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    status smallint NOT NULL,
+    metadata BYTEA NOT NULL,
+    events BYTEA NOT NULL,
+    event_size BIGINT NOT NULL,
+    state_size BIGINT NOT NULL,
+    step_count INT NOT NULL,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_state_runs_account_id ON state_runs (account_id);
+CREATE INDEX idx_state_runs_workspace_id ON state_runs (workspace_id);
+CREATE INDEX idx_state_runs_function_id ON state_runs (function_id);
+
+CREATE TABLE state_steps (
+    run_id CHAR(26) NOT NULL,
+    step_id VARCHAR NOT NULL,
+    output BYTEA,
+    input BYTEA,
+    position INT,
+    PRIMARY KEY (run_id, step_id)
+);
+
+CREATE TABLE state_idempotency (
+    account_id UUID NOT NULL,
+    key VARCHAR NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (account_id, key)
+);
+
+CREATE TABLE state_pauses (
+    id UUID PRIMARY KEY,
+    workspace_id UUID NOT NULL,
+    run_id CHAR(26) NOT NULL,
+    incoming VARCHAR NOT NULL,
+    event VARCHAR,
+    invoke_correlation_id VARCHAR,
+    signal_id VARCHAR,
+    pause BYTEA NOT NULL,
+    created_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    leased_until BIGINT
+);
+
+CREATE INDEX idx_state_pauses_event ON state_pauses (workspace_id, event);
+CREATE INDEX idx_state_pauses_run_id ON state_pauses (run_id);
+CREATE INDEX idx_state_pauses_invoke ON state_pauses (workspace_id, invoke_correlation_id);
+CREATE UNIQUE INDEX idx_state_pauses_signal ON state_pauses (workspace_id, signal_id);
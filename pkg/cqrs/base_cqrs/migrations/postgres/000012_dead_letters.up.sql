@@ -0,0 +1,20 @@
+CREATE TABLE dead_letters (
+    run_id CHAR(26) PRIMARY KEY,
+    account_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    app_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    function_slug VARCHAR NOT NULL,
+    event_ids BYTEA NOT NULL,
+    events BYTEA NOT NULL,
+    error BYTEA,
+    last_step_id VARCHAR,
+    last_step_name VARCHAR,
+    original_run_id CHAR(26),
+    failed_at BIGINT NOT NULL,
+    redrive_run_id CHAR(26),
+    redriven_at BIGINT
+);
+
+CREATE INDEX idx_dead_letters_workspace_id ON dead_letters (workspace_id, run_id);
+CREATE INDEX idx_dead_letters_function_id ON dead_letters (function_id, run_id);
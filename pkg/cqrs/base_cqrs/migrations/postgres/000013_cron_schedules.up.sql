@@ -0,0 +1,6 @@
+CREATE TABLE cron_schedules (
+    function_id UUID NOT NULL,
+    cron VARCHAR NOT NULL,
+    last_fired_at BIGINT NOT NULL,
+    PRIMARY KEY (function_id, cron)
+);
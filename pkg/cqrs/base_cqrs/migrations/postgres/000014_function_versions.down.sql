@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_function_runs_function_version;
+DROP TABLE IF EXISTS function_versions;
@@ -0,0 +1,12 @@
+CREATE TABLE function_versions (
+    function_id UUID NOT NULL,
+    version INT NOT NULL,
+    config TEXT NOT NULL,
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    valid_from BIGINT,
+    valid_to BIGINT,
+    created_at BIGINT NOT NULL,
+    PRIMARY KEY (function_id, version)
+);
+
+CREATE INDEX idx_function_runs_function_version ON function_runs (function_id, function_version);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_schema_failures;
+DROP TABLE IF EXISTS event_schemas;
@@ -0,0 +1,20 @@
+CREATE TABLE event_schemas (
+    name TEXT PRIMARY KEY,
+    schema BYTEA NOT NULL,
+    mode TEXT NOT NULL,
+    app_id UUID,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
+
+CREATE TABLE event_schema_failures (
+    id CHAR(26) PRIMARY KEY,
+    event_name TEXT NOT NULL,
+    mode TEXT NOT NULL,
+    event_id CHAR(26),
+    event BYTEA NOT NULL,
+    errors BYTEA NOT NULL,
+    received_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_event_schema_failures_event_name ON event_schema_failures (event_name, id);
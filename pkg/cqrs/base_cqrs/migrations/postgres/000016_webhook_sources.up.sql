@@ -0,0 +1,11 @@
+CREATE TABLE webhook_sources (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    path TEXT NOT NULL UNIQUE,
+    secret TEXT NOT NULL DEFAULT '',
+    signature TEXT NOT NULL,
+    signature_header TEXT NOT NULL DEFAULT '',
+    transform TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
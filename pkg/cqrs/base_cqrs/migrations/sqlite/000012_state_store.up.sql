@@ -0,0 +1,52 @@
+CREATE TABLE state_runs (
+    run_id CHAR(26) PRIMARY KEY,
+    account_id CHAR(36) NOT NULL,
+    workspace_id CHAR(36) NOT NULL,
+    function_id CHAR(36) NOT NULL,
+    status INT NOT NULL,
+    metadata BLOB NOT NULL,
+    events BLOB NOT NULL,
+    event_size INT NOT NULL,
+    state_size INT NOT NULL,
+    step_count INT NOT NULL,
+    created_at INT NOT NULL
+);
+
+CREATE INDEX idx_state_runs_account_id ON state_runs (account_id);
+CREATE INDEX idx_state_runs_workspace_id ON state_runs (workspace_id);
+CREATE INDEX idx_state_runs_function_id ON state_runs (function_id);
+
+CREATE TABLE state_steps (
+    run_id CHAR(26) NOT NULL,
+    step_id VARCHAR NOT NULL,
+    output BLOB,
+    input BLOB,
+    position INT,
+    PRIMARY KEY (run_id, step_id)
+);
+
+CREATE TABLE state_idempotency (
+    account_id CHAR(36) NOT NULL,
+    key VARCHAR NOT NULL,
+    expires_at INT NOT NULL,
+    PRIMARY KEY (account_id, key)
+);
+
+CREATE TABLE state_pauses (
+    id CHAR(36) PRIMARY KEY,
+    workspace_id CHAR(36) NOT NULL,
+    run_id CHAR(26) NOT NULL,
+    incoming VARCHAR NOT NULL,
+    event VARCHAR,
+    invoke_correlation_id VARCHAR,
+    signal_id VARCHAR,
+    pause BLOB NOT NULL,
+    created_at INT NOT NULL,
+    expires_at INT NOT NULL,
+    leased_until INT
+);
+
+CREATE INDEX idx_state_pauses_event ON state_pauses (workspace_id, event);
+CREATE INDEX idx_state_pauses_run_id ON state_pauses (run_id);
+CREATE INDEX idx_state_pauses_invoke ON state_pauses (workspace_id, invoke_correlation_id);
+CREATE UNIQUE INDEX idx_state_pauses_signal ON state_pauses (workspace_id, signal_id);
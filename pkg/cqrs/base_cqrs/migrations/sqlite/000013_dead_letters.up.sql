@@ -0,0 +1,20 @@
+CREATE TABLE dead_letters (
+    run_id CHAR(26) PRIMARY KEY,
+    account_id CHAR(36) NOT NULL,
+    workspace_id CHAR(36) NOT NULL,
+    app_id CHAR(36) NOT NULL,
+    function_id CHAR(36) NOT NULL,
+    function_slug VARCHAR NOT NULL,
+    event_ids BLOB NOT NULL,
+    events BLOB NOT NULL,
+    error BLOB,
+    last_step_id VARCHAR,
+    last_step_name VARCHAR,
+    original_run_id CHAR(26),
+    failed_at INT NOT NULL,
+    redrive_run_id CHAR(26),
+    redriven_at INT
+);
+
+CREATE INDEX idx_dead_letters_workspace_id ON dead_letters (workspace_id, run_id);
+CREATE INDEX idx_dead_letters_function_id ON dead_letters (function_id, run_id);
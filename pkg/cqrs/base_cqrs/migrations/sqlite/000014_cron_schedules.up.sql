@@ -0,0 +1,6 @@
+CREATE TABLE cron_schedules (
+    function_id CHAR(36) NOT NULL,
+    cron VARCHAR NOT NULL,
+    last_fired_at INT NOT NULL,
+    PRIMARY KEY (function_id, cron)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE function_versions (
+    function_id CHAR(36) NOT NULL,
+    version INT NOT NULL,
+    config VARCHAR NOT NULL,
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    valid_from INT,
+    valid_to INT,
+    created_at INT NOT NULL,
+    PRIMARY KEY (function_id, version)
+);
+
+CREATE INDEX idx_function_runs_function_version ON function_runs (function_id, function_version);
@@ -0,0 +1,20 @@
+CREATE TABLE event_schemas (
+    name VARCHAR PRIMARY KEY,
+    schema BLOB NOT NULL,
+    mode VARCHAR NOT NULL,
+    app_id CHAR(36),
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL
+);
+
+CREATE TABLE event_schema_failures (
+    id CHAR(26) PRIMARY KEY,
+    event_name VARCHAR NOT NULL,
+    mode VARCHAR NOT NULL,
+    event_id CHAR(26),
+    event BLOB NOT NULL,
+    errors BLOB NOT NULL,
+    received_at INT NOT NULL
+);
+
+CREATE INDEX idx_event_schema_failures_event_name ON event_schema_failures (event_name, id);
@@ -0,0 +1,11 @@
+CREATE TABLE webhook_sources (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR NOT NULL UNIQUE,
+    path VARCHAR NOT NULL UNIQUE,
+    secret VARCHAR NOT NULL DEFAULT '',
+    signature VARCHAR NOT NULL,
+    signature_header VARCHAR NOT NULL DEFAULT '',
+    transform VARCHAR NOT NULL,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL
+);
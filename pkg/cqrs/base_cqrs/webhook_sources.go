@@ -0,0 +1,165 @@
+package base_cqrs
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+)
+
+const tableWebhookSources = "webhook_sources"
+
+var webhookSourceColumns = []any{
+	"id",
+	"name",
+	"path",
+	"secret",
+	"signature",
+	"signature_header",
+	"transform",
+	"created_at",
+	"updated_at",
+}
+
+func (w wrapper) GetWebhookSources(ctx context.Context) ([]*cqrs.WebhookSource, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableWebhookSources).
+		Select(webhookSourceColumns...).
+		Order(sq.C("name").Asc()).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []*cqrs.WebhookSource{}
+	for rows.Next() {
+		s, err := scanWebhookSource(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+func (w wrapper) GetWebhookSource(ctx context.Context, id uuid.UUID) (*cqrs.WebhookSource, error) {
+	return w.getWebhookSource(ctx, sq.C("id").Eq(id.String()))
+}
+
+func (w wrapper) GetWebhookSourceByPath(ctx context.Context, path string) (*cqrs.WebhookSource, error) {
+	return w.getWebhookSource(ctx, sq.C("path").Eq(path))
+}
+
+func (w wrapper) getWebhookSource(ctx context.Context, filter sq.Expression) (*cqrs.WebhookSource, error) {
+	query, args, err := sq.Dialect(w.dialect()).
+		From(tableWebhookSources).
+		Select(webhookSourceColumns...).
+		Where(filter).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookSource(w.conn().QueryRowContext(ctx, query, args...))
+}
+
+func (w wrapper) InsertWebhookSource(ctx context.Context, s cqrs.WebhookSource) (*cqrs.WebhookSource, error) {
+	s.CreatedAt = time.Now().Truncate(time.Millisecond)
+	s.UpdatedAt = s.CreatedAt
+
+	query, args, err := sq.Dialect(w.dialect()).Insert(tableWebhookSources).Rows(sq.Record{
+		"id":               s.ID.String(),
+		"name":             s.Name,
+		"path":             s.Path,
+		"secret":           s.Secret,
+		"signature":        string(s.Signature),
+		"signature_header": s.SignatureHeader,
+		"transform":        s.Transform,
+		"created_at":       s.CreatedAt.UnixMilli(),
+		"updated_at":       s.UpdatedAt.UnixMilli(),
+	}).Prepared(true).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (w wrapper) UpdateWebhookSource(ctx context.Context, s cqrs.WebhookSource) (*cqrs.WebhookSource, error) {
+	existing, err := w.GetWebhookSource(ctx, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.CreatedAt = existing.CreatedAt
+	s.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	query, args, err := sq.Dialect(w.dialect()).Update(tableWebhookSources).Set(sq.Record{
+		"name":             s.Name,
+		"path":             s.Path,
+		"secret":           s.Secret,
+		"signature":        string(s.Signature),
+		"signature_header": s.SignatureHeader,
+		"transform":        s.Transform,
+		"updated_at":       s.UpdatedAt.UnixMilli(),
+	}).Where(sq.C("id").Eq(s.ID.String())).Prepared(true).ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.conn().ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (w wrapper) DeleteWebhookSource(ctx context.Context, id uuid.UUID) error {
+	query, args, err := sq.Dialect(w.dialect()).
+		Delete(tableWebhookSources).
+		Where(sq.C("id").Eq(id.String())).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return err
+	}
+	_, err = w.conn().ExecContext(ctx, query, args...)
+	return err
+}
+
+func scanWebhookSource(row interface{ Scan(...any) error }) (*cqrs.WebhookSource, error) {
+	var (
+		s                    cqrs.WebhookSource
+		id, signature        string
+		createdAt, updatedAt int64
+		err                  error
+	)
+	if err := row.Scan(
+		&id,
+		&s.Name,
+		&s.Path,
+		&s.Secret,
+		&signature,
+		&s.SignatureHeader,
+		&s.Transform,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if s.ID, err = uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	s.Signature = cqrs.WebhookSignature(signature)
+	s.CreatedAt = time.UnixMilli(createdAt)
+	s.UpdatedAt = time.UnixMilli(updatedAt)
+	return &s, nil
+}
@@ -0,0 +1,106 @@
+package base_cqrs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSources(t *testing.T) {
+	ctx := context.Background()
+	cm := NewCQRS(newEmptyDB(t, "webhook-sources"), "sqlite")
+
+	_, err := cm.GetWebhookSourceByPath(ctx, "stripe")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	created, err := cm.InsertWebhookSource(ctx, cqrs.WebhookSource{
+		ID:        uuid.New(),
+		Name:      "stripe",
+		Path:      "billing/stripe",
+		Secret:    "whsec_test",
+		Signature: cqrs.WebhookSignatureStripe,
+		Transform: `{"name": "stripe/" + body.type, "data": body.data}`,
+	})
+	require.NoError(t, err)
+
+	_, err = cm.InsertWebhookSource(ctx, cqrs.WebhookSource{
+		ID:        uuid.New(),
+		Name:      "github",
+		Path:      "github",
+		Secret:    "secret",
+		Signature: cqrs.WebhookSignatureGitHub,
+		Transform: `{"name": "github/push", "data": body}`,
+	})
+	require.NoError(t, err)
+
+	t.Run("names and paths are unique", func(t *testing.T) {
+		_, err := cm.InsertWebhookSource(ctx, cqrs.WebhookSource{
+			ID:        uuid.New(),
+			Name:      "stripe",
+			Path:      "other",
+			Signature: cqrs.WebhookSignatureNone,
+		})
+		require.Error(t, err)
+
+		_, err = cm.InsertWebhookSource(ctx, cqrs.WebhookSource{
+			ID:        uuid.New(),
+			Name:      "other",
+			Path:      "billing/stripe",
+			Signature: cqrs.WebhookSignatureNone,
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("sources are loaded by ID and path", func(t *testing.T) {
+		s, err := cm.GetWebhookSource(ctx, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, created, s)
+
+		s, err = cm.GetWebhookSourceByPath(ctx, "billing/stripe")
+		require.NoError(t, err)
+		require.Equal(t, created.ID, s.ID)
+		require.Equal(t, "whsec_test", s.Secret)
+		require.Equal(t, cqrs.WebhookSignatureStripe, s.Signature)
+	})
+
+	t.Run("sources are listed by name", func(t *testing.T) {
+		sources, err := cm.GetWebhookSources(ctx)
+		require.NoError(t, err)
+		require.Len(t, sources, 2)
+		require.Equal(t, "github", sources[0].Name)
+		require.Equal(t, "stripe", sources[1].Name)
+	})
+
+	t.Run("updating keeps the creation time", func(t *testing.T) {
+		time.Sleep(2 * time.Millisecond)
+		update := *created
+		update.Path = "stripe"
+		update.Signature = cqrs.WebhookSignatureHMACSHA256
+		update.SignatureHeader = "X-Stripe-Signature"
+		updated, err := cm.UpdateWebhookSource(ctx, update)
+		require.NoError(t, err)
+		require.True(t, updated.UpdatedAt.After(created.UpdatedAt))
+
+		s, err := cm.GetWebhookSourceByPath(ctx, "stripe")
+		require.NoError(t, err)
+		require.Equal(t, created.CreatedAt, s.CreatedAt)
+		require.Equal(t, "X-Stripe-Signature", s.SignatureHeader)
+
+		_, err = cm.GetWebhookSourceByPath(ctx, "billing/stripe")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = cm.UpdateWebhookSource(ctx, cqrs.WebhookSource{ID: uuid.New(), Name: "missing"})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("deleting removes the source", func(t *testing.T) {
+		require.NoError(t, cm.DeleteWebhookSource(ctx, created.ID))
+		_, err := cm.GetWebhookSource(ctx, created.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,108 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/oklog/ulid/v2"
+)
+
+// ErrBulkOperationDone is returned when updating a bulk operation which has
+// already finished, eg. one which was stopped whilst being processed.
+var ErrBulkOperationDone = fmt.Errorf("bulk operation has already finished")
+
+// BulkOperationKind represents the action taken on each run matched by a bulk
+// operation.
+type BulkOperationKind string
+
+const (
+	// BulkOperationKindCancel cancels each matching run.
+	BulkOperationKindCancel BulkOperationKind = "cancel"
+	// BulkOperationKindReplay replays each matching run from the start.
+	BulkOperationKindReplay BulkOperationKind = "replay"
+)
+
+// BulkOperationStatus represents the progress of a bulk operation.
+type BulkOperationStatus string
+
+const (
+	BulkOperationStatusPending   BulkOperationStatus = "pending"
+	BulkOperationStatusRunning   BulkOperationStatus = "running"
+	BulkOperationStatusCompleted BulkOperationStatus = "completed"
+	BulkOperationStatusFailed    BulkOperationStatus = "failed"
+	BulkOperationStatusStopped   BulkOperationStatus = "stopped"
+)
+
+// IsDone returns whether the bulk operation will no longer process runs.
+func (s BulkOperationStatus) IsDone() bool {
+	switch s {
+	case BulkOperationStatusCompleted, BulkOperationStatusFailed, BulkOperationStatusStopped:
+		return true
+	}
+	return false
+}
+
+type BulkOperationReadWriter interface {
+	BulkOperationReader
+	BulkOperationWriter
+}
+
+// BulkOperationReader loads bulk operations from a backing store.
+type BulkOperationReader interface {
+	// BulkOperations returns all bulk operations for a given workspace.
+	BulkOperations(ctx context.Context, wsID uuid.UUID) ([]BulkOperation, error)
+	// BulkOperation returns a single bulk operation for a given workspace.
+	BulkOperation(ctx context.Context, wsID uuid.UUID, id ulid.ULID) (*BulkOperation, error)
+	// PendingBulkOperations returns all bulk operations across every workspace
+	// which have yet to finish processing.
+	PendingBulkOperations(ctx context.Context) ([]BulkOperation, error)
+}
+
+type BulkOperationWriter interface {
+	// CreateBulkOperation writes a new bulk operation to the backing store.
+	CreateBulkOperation(ctx context.Context, op BulkOperation) error
+	// UpdateBulkOperation updates the status and progress of a bulk operation.
+	// Finished operations are never overwritten;  updating one returns
+	// ErrBulkOperationDone.
+	UpdateBulkOperation(ctx context.Context, op BulkOperation) error
+}
+
+// BulkOperation represents a cancellation or replay of many function runs matching
+// the given filters.  Bulk operations are processed asynchronously in the background,
+// with progress recorded on the operation as runs are processed.
+type BulkOperation struct {
+	ID          ulid.ULID         `json:"id"`
+	Kind        BulkOperationKind `json:"kind"`
+	AccountID   uuid.UUID         `json:"account_id"`
+	WorkspaceID uuid.UUID         `json:"environment_id"`
+	// FunctionID represents the function's internal ID.
+	FunctionID uuid.UUID `json:"function_internal_id"`
+	// FunctionSlug represents the function's external ID as defined in the SDK.
+	FunctionSlug string `json:"function_id"`
+	// From and Until bound the time that matching runs were queued.
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+	// RunStatus filters matching runs to the given statuses.  If empty, runs
+	// of any status match.
+	RunStatus []enums.RunStatus `json:"run_status,omitempty"`
+	// If is an optional CEL expression evaluated against the triggering event.
+	If *string `json:"if,omitempty"`
+
+	Status    BulkOperationStatus `json:"status"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	// Cursor is the pagination cursor of the last processed run, allowing
+	// processing to resume after restarts.
+	Cursor string `json:"cursor,omitempty"`
+	// Processed is the total number of runs processed.
+	Processed int `json:"processed"`
+	// Succeeded is the number of runs successfully cancelled or replayed.
+	Succeeded int `json:"succeeded"`
+	// Failed is the number of runs which could not be cancelled or replayed.
+	Failed int `json:"failed"`
+	// Error records why the operation failed, if the status is failed.
+	Error *string `json:"error,omitempty"`
+}
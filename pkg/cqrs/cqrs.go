@@ -36,6 +36,21 @@ type Manager interface {
 	// Connection history
 	ConnectionHistoryReadWriter
 
+	// Dead letters for permanently failed runs
+	DeadLetterReadWriter
+
+	// Cron schedules' last fired times
+	CronReadWriter
+
+	// Immutable versions of each function's config
+	FunctionVersionReadWriter
+
+	// JSON Schemas for event names, and events which failed validation
+	EventSchemaReadWriter
+
+	// Webhook sources which transform requests into events
+	WebhookSourceReadWriter
+
 	// Scoped allows creating a new manager using a transaction.
 	WithTx(ctx context.Context) (TxManager, error)
 }
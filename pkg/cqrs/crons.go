@@ -0,0 +1,19 @@
+package cqrs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CronReadWriter records when each function's cron schedules last fired, so
+// that ticks missed while the server was down can be caught up on startup.
+type CronReadWriter interface {
+	// GetCronLastFired returns the time the given schedule last fired for the
+	// function, or nil if it has never fired.
+	GetCronLastFired(ctx context.Context, fnID uuid.UUID, spec string) (*time.Time, error)
+	// SetCronLastFired records that the schedule fired at the given time.  Times
+	// earlier than the stored time are ignored.
+	SetCronLastFired(ctx context.Context, fnID uuid.UUID, spec string, at time.Time) error
+}
@@ -0,0 +1,82 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+type DeadLetterReadWriter interface {
+	DeadLetterReader
+	DeadLetterWriter
+}
+
+// DeadLetterReader loads dead letters from a backing store.
+type DeadLetterReader interface {
+	// GetDeadLetters returns dead letters for the given workspace, newest first.
+	GetDeadLetters(ctx context.Context, wsID uuid.UUID, opts GetDeadLettersOpts) ([]*DeadLetter, error)
+	// GetDeadLetter returns the dead letter for the given failed run.
+	GetDeadLetter(ctx context.Context, wsID uuid.UUID, runID ulid.ULID) (*DeadLetter, error)
+}
+
+type DeadLetterWriter interface {
+	// InsertDeadLetter records a permanently failed run.
+	InsertDeadLetter(ctx context.Context, dl DeadLetter) error
+	// SetDeadLetterRedriven records that the dead letter was redriven as a new run.
+	SetDeadLetterRedriven(ctx context.Context, wsID uuid.UUID, runID ulid.ULID, redriveRunID ulid.ULID) error
+	// PurgeDeadLetters deletes all dead letters matching the given options, returning
+	// the number of dead letters deleted.
+	PurgeDeadLetters(ctx context.Context, wsID uuid.UUID, opts PurgeDeadLettersOpts) (int64, error)
+}
+
+type GetDeadLettersOpts struct {
+	// FunctionID filters dead letters to the given function's internal ID.
+	FunctionID *uuid.UUID
+	// Cursor returns dead letters for runs failing before the given run ID.
+	Cursor *ulid.ULID
+	// Limit is the maximum number of dead letters returned.
+	Limit int
+}
+
+type PurgeDeadLettersOpts struct {
+	// FunctionID purges dead letters for the given function's internal ID.
+	FunctionID *uuid.UUID
+	// RunIDs purges the dead letters for the given failed runs.
+	RunIDs []ulid.ULID
+	// Before purges dead letters for runs which failed before the given time.
+	Before *time.Time
+}
+
+// DeadLetter records a run which permanently failed after exhausting its retries,
+// allowing the run to be inspected and redriven.
+type DeadLetter struct {
+	// RunID is the ID of the failed run.
+	RunID       ulid.ULID `json:"run_id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	WorkspaceID uuid.UUID `json:"environment_id"`
+	AppID       uuid.UUID `json:"app_id"`
+	// FunctionID represents the function's internal ID.
+	FunctionID uuid.UUID `json:"function_internal_id"`
+	// FunctionSlug represents the function's external ID as defined in the SDK.
+	FunctionSlug string `json:"function_id"`
+	// EventIDs are the internal IDs of the events which triggered the run.
+	EventIDs []ulid.ULID `json:"event_ids"`
+	// Events are the events which triggered the run.
+	Events []json.RawMessage `json:"events"`
+	// Error is the final error of the run.
+	Error json.RawMessage `json:"error"`
+	// LastStepID is the hashed ID of the step which failed, if the run failed
+	// within a step.
+	LastStepID *string `json:"last_step_id,omitempty"`
+	// LastStepName is the name of the step which failed.
+	LastStepName *string `json:"last_step_name,omitempty"`
+	// OriginalRunID is the ID of the run that the failed run replayed, if any.
+	OriginalRunID *ulid.ULID `json:"original_run_id,omitempty"`
+	FailedAt      time.Time  `json:"failed_at"`
+	// RedriveRunID is the ID of the run created by the most recent redrive.
+	RedriveRunID *ulid.ULID `json:"redrive_run_id,omitempty"`
+	RedrivenAt   *time.Time `json:"redriven_at,omitempty"`
+}
@@ -0,0 +1,91 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// EventSchemaMode determines how events which don't match their schema are
+// handled by the event API.
+type EventSchemaMode string
+
+const (
+	// EventSchemaModeReject rejects events which don't match their schema.
+	EventSchemaModeReject EventSchemaMode = "reject"
+	// EventSchemaModeWarn accepts events which don't match their schema, recording
+	// the failure.
+	EventSchemaModeWarn EventSchemaMode = "warn"
+	// EventSchemaModeTag accepts events which don't match their schema, recording
+	// the failure and adding the validation errors to the event's data.
+	EventSchemaModeTag EventSchemaMode = "tag"
+)
+
+func (m EventSchemaMode) Valid() bool {
+	switch m {
+	case EventSchemaModeReject, EventSchemaModeWarn, EventSchemaModeTag:
+		return true
+	}
+	return false
+}
+
+// EventSchemaReadWriter stores the JSON Schemas registered for event names, along
+// with events which failed validation.
+type EventSchemaReadWriter interface {
+	// GetEventSchemas returns all registered schemas, ordered by event name.
+	GetEventSchemas(ctx context.Context) ([]*EventSchema, error)
+	// GetEventSchema returns the schema for the given event name, or sql.ErrNoRows
+	// if no schema is registered.
+	GetEventSchema(ctx context.Context, name string) (*EventSchema, error)
+	// UpsertEventSchema registers the schema for its event name, replacing any
+	// existing schema.
+	UpsertEventSchema(ctx context.Context, s EventSchema) (*EventSchema, error)
+	// DeleteEventSchemas deletes the schemas for the given event names.
+	DeleteEventSchemas(ctx context.Context, names []string) error
+	// InsertEventSchemaFailure records an event which failed validation.
+	InsertEventSchemaFailure(ctx context.Context, f EventSchemaFailure) error
+	// GetEventSchemaFailures returns validation failures, newest first.
+	GetEventSchemaFailures(ctx context.Context, opts GetEventSchemaFailuresOpts) ([]*EventSchemaFailure, error)
+}
+
+// EventSchema is a JSON Schema which the data of every event with the given name
+// is validated against.
+type EventSchema struct {
+	// Name is the event name the schema applies to.
+	Name string `json:"name"`
+	// Schema is the JSON Schema for the event's data.
+	Schema json.RawMessage `json:"schema"`
+	// Mode determines how events which don't match the schema are handled.
+	Mode EventSchemaMode `json:"mode"`
+	// AppID is the app which registered the schema when syncing, or nil if the
+	// schema was registered via the API.
+	AppID     *uuid.UUID `json:"app_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// EventSchemaFailure records an event which didn't match its schema.
+type EventSchemaFailure struct {
+	ID        ulid.ULID       `json:"id"`
+	EventName string          `json:"event_name"`
+	Mode      EventSchemaMode `json:"mode"`
+	// EventID is the event's internal ID, or nil if the event was rejected.
+	EventID *ulid.ULID `json:"event_id,omitempty"`
+	// Event is the event as it was received.
+	Event json.RawMessage `json:"event"`
+	// Errors are the reasons the event didn't match its schema.
+	Errors     []string  `json:"errors"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+type GetEventSchemaFailuresOpts struct {
+	// EventName filters failures to the given event name.
+	EventName *string
+	// Cursor returns failures received before the failure with the given ID.
+	Cursor *ulid.ULID
+	// Limit is the maximum number of failures returned.
+	Limit int
+}
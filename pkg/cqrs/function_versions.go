@@ -0,0 +1,32 @@
+package cqrs
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/function"
+	"github.com/oklog/ulid/v2"
+)
+
+// FunctionVersionReadWriter stores every config synced for a function as an
+// immutable version, recording the window in which each version was live.
+type FunctionVersionReadWriter interface {
+	// GetFunctionVersions returns all versions of a function, newest first.
+	GetFunctionVersions(ctx context.Context, fnID uuid.UUID) ([]*function.FunctionVersion, error)
+	// GetFunctionVersion returns a single version of a function, or sql.ErrNoRows
+	// if the version doesn't exist.
+	GetFunctionVersion(ctx context.Context, fnID uuid.UUID, version uint) (*function.FunctionVersion, error)
+	// InsertFunctionVersion stores the config as a new draft version of the
+	// function, setting the config's function version to the new version.
+	InsertFunctionVersion(ctx context.Context, fnID uuid.UUID, config string) (*function.FunctionVersion, error)
+	// SetLiveFunctionVersion makes a draft version the function's live version,
+	// ending the current live version's window and updating the function's
+	// config.
+	SetLiveFunctionVersion(ctx context.Context, fnID uuid.UUID, version uint) (*function.FunctionVersion, error)
+	// PinFunctionVersion pins the function to the given version, or unpins the
+	// function if version is nil.
+	PinFunctionVersion(ctx context.Context, fnID uuid.UUID, version *uint) error
+	// GetFunctionVersionRunIDs returns the IDs of the most recent runs which used
+	// the given version of the function.
+	GetFunctionVersionRunIDs(ctx context.Context, fnID uuid.UUID, version uint, limit int) ([]ulid.ULID, error)
+}
@@ -16,6 +16,9 @@ type Function struct {
 	Name      string          `json:"name"`
 	Config    json.RawMessage `json:"config"`
 	CreatedAt time.Time       `json:"created_at"`
+	// ArchivedAt is set when the function has been removed from its app.  It's
+	// only loaded for single functions, as lists exclude archived functions.
+	ArchivedAt time.Time `json:"archived_at,omitempty"`
 }
 
 func (f Function) InngestFunction() (*inngest.Function, error) {
@@ -133,6 +133,8 @@ func (s *Span) StepOpCode() enums.Opcode {
 			return enums.OpcodeInvokeFunction
 		case enums.OpcodeWaitForEvent.String():
 			return enums.OpcodeWaitForEvent
+		case enums.OpcodeWaitForSignal.String():
+			return enums.OpcodeWaitForSignal
 		case enums.OpcodeStepPlanned.String():
 			return enums.OpcodeStepPlanned
 		case enums.OpcodeAIGateway.String():
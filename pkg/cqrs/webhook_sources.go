@@ -0,0 +1,86 @@
+package cqrs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSignature is a preset for verifying the signature of requests sent to a
+// webhook source.
+type WebhookSignature string
+
+const (
+	// WebhookSignatureNone accepts all requests without verifying a signature.
+	WebhookSignatureNone WebhookSignature = "none"
+	// WebhookSignatureHMACSHA256 verifies a hex encoded HMAC-SHA256 of the body,
+	// optionally prefixed with "sha256=", sent in a configurable header.
+	WebhookSignatureHMACSHA256 WebhookSignature = "hmac-sha256"
+	// WebhookSignatureGitHub verifies GitHub's X-Hub-Signature-256 header.
+	WebhookSignatureGitHub WebhookSignature = "github"
+	// WebhookSignatureStripe verifies Stripe's timestamped Stripe-Signature header.
+	WebhookSignatureStripe WebhookSignature = "stripe"
+	// WebhookSignatureStandard verifies the webhook-id, webhook-timestamp and
+	// webhook-signature headers defined by the Standard Webhooks specification.
+	WebhookSignatureStandard WebhookSignature = "standard"
+)
+
+func (s WebhookSignature) Valid() bool {
+	switch s {
+	case WebhookSignatureNone,
+		WebhookSignatureHMACSHA256,
+		WebhookSignatureGitHub,
+		WebhookSignatureStripe,
+		WebhookSignatureStandard:
+		return true
+	}
+	return false
+}
+
+// WebhookSourceReadWriter stores named webhook sources, which transform arbitrary
+// HTTP requests into events.
+type WebhookSourceReadWriter interface {
+	WebhookSourceReader
+
+	// InsertWebhookSource creates a new webhook source.
+	InsertWebhookSource(ctx context.Context, s WebhookSource) (*WebhookSource, error)
+	// UpdateWebhookSource replaces the webhook source with the same ID.
+	UpdateWebhookSource(ctx context.Context, s WebhookSource) (*WebhookSource, error)
+	// DeleteWebhookSource deletes the webhook source with the given ID.
+	DeleteWebhookSource(ctx context.Context, id uuid.UUID) error
+}
+
+type WebhookSourceReader interface {
+	// GetWebhookSources returns all webhook sources, ordered by name.
+	GetWebhookSources(ctx context.Context) ([]*WebhookSource, error)
+	// GetWebhookSource returns the webhook source with the given ID, or
+	// sql.ErrNoRows if it doesn't exist.
+	GetWebhookSource(ctx context.Context, id uuid.UUID) (*WebhookSource, error)
+	// GetWebhookSourceByPath returns the webhook source receiving requests at the
+	// given path, or sql.ErrNoRows if it doesn't exist.
+	GetWebhookSourceByPath(ctx context.Context, path string) (*WebhookSource, error)
+}
+
+// WebhookSource receives requests from a third party at its own URL path,
+// transforming each request into zero or more events.
+type WebhookSource struct {
+	ID uuid.UUID `json:"id"`
+	// Name is the unique name of the source, eg. "stripe".
+	Name string `json:"name"`
+	// Path is the unique path the source receives requests at, relative to
+	// /webhooks/.
+	Path string `json:"path"`
+	// Secret is the secret used to verify request signatures.
+	Secret string `json:"-"`
+	// Signature is the preset used to verify request signatures.
+	Signature WebhookSignature `json:"signature"`
+	// SignatureHeader is the header containing the signature for the hmac-sha256
+	// preset.
+	SignatureHeader string `json:"signature_header,omitempty"`
+	// Transform is an expression which maps the request's body, headers and
+	// query into an event or list of events.
+	Transform string    `json:"transform"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
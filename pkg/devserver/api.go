@@ -20,6 +20,8 @@ import (
 	"github.com/khulnasoft/inngest/pkg/api/tel"
 	"github.com/khulnasoft/inngest/pkg/consts"
 	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/eventschema"
+	"github.com/khulnasoft/inngest/pkg/function"
 	"github.com/khulnasoft/inngest/pkg/headers"
 	"github.com/khulnasoft/inngest/pkg/inngest"
 	"github.com/khulnasoft/inngest/pkg/inngest/log"
@@ -297,32 +299,32 @@ func (a devapi) register(ctx context.Context, r sdk.RegisterRequest) (*cqrs.Sync
 			return nil, publicerr.Wrap(err, 500, "Error marshalling function")
 		}
 
-		if _, err := tx.GetFunctionByInternalUUID(ctx, consts.DevServerEnvId, fn.ID); err == nil {
-			// Update the function config.
-			_, err = tx.UpdateFunctionConfig(ctx, cqrs.UpdateFunctionConfigParams{
-				ID:     fn.ID,
-				Config: string(config),
+		if _, err := tx.GetFunctionByInternalUUID(ctx, consts.DevServerEnvId, fn.ID); err != nil {
+			_, err = tx.InsertFunction(ctx, cqrs.InsertFunctionParams{
+				ID:        fn.ID,
+				Name:      fn.Name,
+				Slug:      fn.Slug,
+				AppID:     appID,
+				Config:    string(config),
+				CreatedAt: time.Now(),
 			})
 			if err != nil {
-				return nil, publicerr.Wrap(err, 500, "Error updating function config")
+				err = fmt.Errorf("Function %s is invalid: %w", fn.Slug, err)
+				return nil, publicerr.Wrap(err, 500, "Error saving function")
 			}
-			continue
 		}
 
-		_, err = tx.InsertFunction(ctx, cqrs.InsertFunctionParams{
-			ID:        fn.ID,
-			Name:      fn.Name,
-			Slug:      fn.Slug,
-			AppID:     appID,
-			Config:    string(config),
-			CreatedAt: time.Now(),
-		})
-		if err != nil {
-			err = fmt.Errorf("Function %s is invalid: %w", fn.Slug, err)
-			return nil, publicerr.Wrap(err, 500, "Error saving function")
+		// Keep the previous config as an immutable version, updating the
+		// function's config to its live version.
+		if err := syncFunctionVersion(ctx, tx, fn.ID, string(config)); err != nil {
+			return nil, publicerr.Wrap(err, 500, "Error updating function config")
 		}
 	}
 
+	if err = syncEventSchemas(ctx, tx, appID, r.EventSchemas); err != nil {
+		return nil, publicerr.Wrapf(err, 400, "Invalid event schema: %s", err)
+	}
+
 	reply := &cqrs.SyncReply{
 		OK:       true,
 		Modified: true,
@@ -347,6 +349,96 @@ func (a devapi) register(ctx context.Context, r sdk.RegisterRequest) (*cqrs.Sync
 	return reply, nil
 }
 
+// syncFunctionVersion stores a synced function config as a new version if it differs
+// from the function's latest version.  The new version is made live unless the
+// function is pinned to an earlier version, in which case it's kept as a draft.
+func syncFunctionVersion(ctx context.Context, tx cqrs.TxManager, fnID uuid.UUID, config string) error {
+	versions, err := tx.GetFunctionVersions(ctx, fnID)
+	if err != nil {
+		return fmt.Errorf("error loading function versions: %w", err)
+	}
+
+	var (
+		live   *function.FunctionVersion
+		pinned bool
+	)
+	for _, v := range versions {
+		if v.Live() {
+			live = v
+		}
+		pinned = pinned || v.Pinned
+	}
+
+	changed := len(versions) == 0
+	if !changed {
+		same, err := function.SameConfig(versions[0].Config, config)
+		if err != nil {
+			return err
+		}
+		changed = !same
+	}
+
+	if changed {
+		v, err := tx.InsertFunctionVersion(ctx, fnID, config)
+		if err != nil {
+			return fmt.Errorf("error saving function version: %w", err)
+		}
+		if !pinned || live == nil {
+			_, err = tx.SetLiveFunctionVersion(ctx, fnID, v.Version)
+			return err
+		}
+	}
+
+	if live == nil {
+		return nil
+	}
+	// Ensure the function uses its live version's config, eg. if it was removed
+	// from the app and added back.
+	_, err = tx.UpdateFunctionConfig(ctx, cqrs.UpdateFunctionConfigParams{
+		ID:     fnID,
+		Config: live.Config,
+	})
+	return err
+}
+
+// syncEventSchemas registers the event schemas published by an app, deleting any
+// schemas the app previously published which are no longer present.  Schemas for
+// the same event name registered via the API or by another app are replaced.
+func syncEventSchemas(ctx context.Context, tx cqrs.TxManager, appID uuid.UUID, schemas []sdk.EventSchema) error {
+	seen := map[string]struct{}{}
+	for _, s := range schemas {
+		if _, ok := seen[s.Name]; ok {
+			return fmt.Errorf("duplicate schema for event %s", s.Name)
+		}
+		seen[s.Name] = struct{}{}
+
+		schema := cqrs.EventSchema{
+			Name:   s.Name,
+			Schema: s.Schema,
+			Mode:   cqrs.EventSchemaMode(s.Mode),
+			AppID:  &appID,
+		}
+		if err := eventschema.Check(&schema); err != nil {
+			return err
+		}
+		if _, err := tx.UpsertEventSchema(ctx, schema); err != nil {
+			return fmt.Errorf("error saving event schema: %w", err)
+		}
+	}
+
+	existing, err := tx.GetEventSchemas(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading event schemas: %w", err)
+	}
+	deletes := []string{}
+	for _, s := range existing {
+		if _, ok := seen[s.Name]; !ok && s.AppID != nil && *s.AppID == appID {
+			deletes = append(deletes, s.Name)
+		}
+	}
+	return tx.DeleteEventSchemas(ctx, deletes)
+}
+
 func (a devapi) OTLPTrace(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
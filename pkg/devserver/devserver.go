@@ -31,8 +31,11 @@ import (
 	"github.com/khulnasoft/inngest/pkg/deploy"
 	"github.com/khulnasoft/inngest/pkg/enums"
 	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/eventdedupe"
+	"github.com/khulnasoft/inngest/pkg/eventschema"
 	"github.com/khulnasoft/inngest/pkg/execution"
 	"github.com/khulnasoft/inngest/pkg/execution/batch"
+	"github.com/khulnasoft/inngest/pkg/execution/bulk"
 	"github.com/khulnasoft/inngest/pkg/execution/debounce"
 	"github.com/khulnasoft/inngest/pkg/execution/driver"
 	"github.com/khulnasoft/inngest/pkg/execution/driver/httpdriver"
@@ -42,6 +45,8 @@ import (
 	"github.com/khulnasoft/inngest/pkg/execution/ratelimit"
 	"github.com/khulnasoft/inngest/pkg/execution/realtime"
 	"github.com/khulnasoft/inngest/pkg/execution/runner"
+	"github.com/khulnasoft/inngest/pkg/execution/singleton"
+	"github.com/khulnasoft/inngest/pkg/execution/sink"
 	"github.com/khulnasoft/inngest/pkg/execution/state"
 	"github.com/khulnasoft/inngest/pkg/execution/state/redis_state"
 	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
@@ -54,6 +59,7 @@ import (
 	"github.com/khulnasoft/inngest/pkg/service"
 	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
 	"github.com/khulnasoft/inngest/pkg/testapi"
+	"github.com/khulnasoft/inngest/pkg/util/aigateway"
 	"github.com/khulnasoft/inngest/pkg/util/awsgateway"
 	"github.com/redis/rueidis"
 	"go.opentelemetry.io/otel/propagation"
@@ -95,6 +101,22 @@ type StartOpts struct {
 	RequireKeys bool `json:"require_keys"`
 
 	ConnectGatewayPort int `json:"connectGatewayPort"`
+
+	// EventDedupeWindow is the window in which events sent with the same ID are
+	// dropped as duplicates.  Zero disables deduplication.
+	EventDedupeWindow time.Duration `json:"event_dedupe_window"`
+
+	// LifecycleSinks are URIs of sinks which function and step lifecycle events
+	// are exported to, eg. "https://example.com/webhook", "nats://host:4222/subject"
+	// or "kafka://broker:9092/topic".
+	LifecycleSinks []string `json:"lifecycle_sinks"`
+	// LifecycleSinkSigningKey signs requests sent to lifecycle webhook sinks.
+	LifecycleSinkSigningKey string `json:"lifecycle_sink_signing_key"`
+	// AITokenBudget limits the AI gateway tokens used by each environment within
+	// AITokenBudgetPeriod.  Zero disables the limit.
+	AITokenBudget int64 `json:"ai_token_budget"`
+	// AITokenBudgetPeriod is the period AITokenBudget resets after.
+	AITokenBudgetPeriod time.Duration `json:"ai_token_budget_period"`
 }
 
 // Create and start a new dev server.  The dev server is used during (surprise surprise)
@@ -184,8 +206,11 @@ func start(ctx context.Context, opts StartOpts) error {
 	}
 	smv2 := redis_state.MustRunServiceV2(sm)
 
-	// Create a new broadcaster which lets us broadcast realtime messages.
-	broadcaster := realtime.NewInProcessBroadcaster()
+	// Create a new broadcaster which lets us broadcast realtime messages.  Messages
+	// are kept in-memory so that subscribers can resume after reconnecting.
+	broadcaster := realtime.NewInProcessBroadcaster(
+		realtime.WithHistory(realtime.NewInMemoryHistory(realtime.HistoryOpts{})),
+	)
 
 	queueOpts := []redis_state.QueueOpt{
 		redis_state.WithRunMode(redis_state.QueueRunMode{
@@ -273,6 +298,7 @@ func start(ctx context.Context, opts StartOpts) error {
 	rq := redis_state.NewQueue(queueShard, queueOpts...)
 
 	rl := ratelimit.New(ctx, unshardedRc, "{ratelimit}:")
+	sn := singleton.New(unshardedRc, "{singleton}:")
 
 	batcher := batch.NewRedisBatchManager(shardedClient.Batch(), rq)
 	debouncer := debounce.NewRedisDebouncer(unshardedClient.Debounce(), queueShard, rq)
@@ -305,6 +331,37 @@ func start(ctx context.Context, opts StartOpts) error {
 
 	hmw := memory_writer.NewWriter(ctx, memory_writer.WriterOptions{DumpToFile: false})
 
+	listeners := []execution.LifecycleListener{
+		history.NewLifecycleListener(
+			nil,
+			hd,
+			hmw,
+		),
+		Lifecycle{
+			Cqrs:       dbcqrs,
+			Pb:         pb,
+			EventTopic: opts.Config.EventStream.Service.Concrete.TopicName(),
+		},
+		run.NewTraceLifecycleListener(nil),
+	}
+	if len(opts.LifecycleSinks) > 0 {
+		l, exporter, err := sink.NewLifecycleListenerFromURIs(ctx, opts.LifecycleSinks, sink.OpenOpts{
+			WebhookSigningKey: opts.LifecycleSinkSigningKey,
+		})
+		if err != nil {
+			return err
+		}
+		// Send buffered lifecycle events once every service has stopped.
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sink.DefaultCloseTimeout)
+			defer cancel()
+			if err := exporter.Close(ctx); err != nil {
+				logger.From(ctx).Error().Err(err).Msg("error closing lifecycle sinks")
+			}
+		}()
+		listeners = append(listeners, l)
+	}
+
 	exec, err := executor.NewExecutor(
 		executor.WithStateManager(smv2),
 		executor.WithPauseManager(sm),
@@ -316,18 +373,20 @@ func start(ctx context.Context, opts StartOpts) error {
 		executor.WithLogger(logger.From(ctx)),
 		executor.WithFunctionLoader(loader),
 		executor.WithRealtimePublisher(broadcaster),
-		executor.WithLifecycleListeners(
-			history.NewLifecycleListener(
-				nil,
-				hd,
-				hmw,
-			),
-			Lifecycle{
-				Cqrs:       dbcqrs,
-				Pb:         pb,
-				EventTopic: opts.Config.EventStream.Service.Concrete.TopicName(),
+		executor.WithLifecycleListeners(listeners...),
+		executor.WithAIGatewayCache(aigateway.NewRedisCache(unshardedRc)),
+		executor.WithAITokenBudgets(
+			aigateway.NewRedisBudgets(unshardedRc),
+			func(ctx context.Context, envID uuid.UUID) *aigateway.Budget {
+				if opts.AITokenBudget <= 0 || opts.AITokenBudgetPeriod <= 0 {
+					return nil
+				}
+				return &aigateway.Budget{
+					Scope:  "env",
+					Limit:  opts.AITokenBudget,
+					Period: opts.AITokenBudgetPeriod,
+				}
 			},
-			run.NewTraceLifecycleListener(nil),
 		),
 		executor.WithStepLimits(func(id sv2.ID) int {
 			if override, hasOverride := stepLimitOverrides[id.FunctionID.String()]; hasOverride {
@@ -348,6 +407,7 @@ func start(ctx context.Context, opts StartOpts) error {
 		executor.WithInvokeFailHandler(getInvokeFailHandler(ctx, pb, opts.Config.EventStream.Service.Concrete.TopicName())),
 		executor.WithSendingEventHandler(getSendingEventHandler(ctx, pb, opts.Config.EventStream.Service.Concrete.TopicName())),
 		executor.WithDebouncer(debouncer),
+		executor.WithSingletonManager(sn),
 		executor.WithBatcher(batcher),
 		executor.WithAssignedQueueShard(queueShard),
 		executor.WithShardSelector(shardSelector),
@@ -357,17 +417,6 @@ func start(ctx context.Context, opts StartOpts) error {
 		return err
 	}
 
-	// Create an executor.
-	executorSvc := executor.NewService(
-		opts.Config,
-		executor.WithExecutionManager(dbcqrs),
-		executor.WithState(sm),
-		executor.WithServiceQueue(rq),
-		executor.WithServiceExecutor(exec),
-		executor.WithServiceBatcher(batcher),
-		executor.WithServiceDebouncer(debouncer),
-	)
-
 	runner := runner.NewService(
 		opts.Config,
 		runner.WithCQRS(dbcqrs),
@@ -382,6 +431,18 @@ func start(ctx context.Context, opts StartOpts) error {
 		runner.WithPublisher(pb),
 	)
 
+	// Create an executor.
+	executorSvc := executor.NewService(
+		opts.Config,
+		executor.WithExecutionManager(dbcqrs),
+		executor.WithState(sm),
+		executor.WithServiceQueue(rq),
+		executor.WithServiceExecutor(exec),
+		executor.WithServiceBatcher(batcher),
+		executor.WithServiceDebouncer(debouncer),
+		executor.WithServiceCronHandler(runner.FireCron),
+	)
+
 	// The devserver embeds the event API.
 	ds := NewService(opts, runner, dbcqrs, pb, stepLimitOverrides, stateSizeLimitOverrides, unshardedRc, hmw, nil)
 	// embed the tracker
@@ -394,21 +455,35 @@ func start(ctx context.Context, opts StartOpts) error {
 	// registering functions.
 	devAPI := NewDevAPI(ds)
 
+	// Bulk operations cancel or replay many runs in the background.
+	bulkOps := bulk.NewRedisReadWriter(unshardedRc, "")
+	bulkSvc := bulk.NewService(bulk.Opts{
+		Store:    bulkOps,
+		Data:     ds.Data,
+		Executor: ds.Executor,
+	})
+
 	devAPI.Route("/v1", func(r chi.Router) {
 		// Add the V1 API to our dev server API.
 		cache := cache.New[[]byte](freecachestore.NewFreecache(freecache.NewCache(1024 * 1024)))
 		caching := apiv1.NewCacheMiddleware(cache)
 
 		apiv1.AddRoutes(r, apiv1.Opts{
-			CachingMiddleware:  caching,
-			EventReader:        ds.Data,
-			FunctionReader:     ds.Data,
-			FunctionRunReader:  ds.Data,
-			JobQueueReader:     ds.Queue.(queue.JobQueueReader),
-			Executor:           ds.Executor,
-			QueueShardSelector: shardSelector,
-			Broadcaster:        broadcaster,
-			RealtimeJWTSecret:  consts.DevServerRealtimeJWTSecret,
+			CachingMiddleware:       caching,
+			EventReader:             ds.Data,
+			EventReplayer:           runner,
+			FunctionReader:          ds.Data,
+			FunctionRunReader:       ds.Data,
+			TraceReader:             ds.Data,
+			JobQueueReader:          ds.Queue.(queue.JobQueueReader),
+			Executor:                ds.Executor,
+			QueueShardSelector:      shardSelector,
+			Broadcaster:             broadcaster,
+			RealtimeJWTSecret:       consts.DevServerRealtimeJWTSecret,
+			BulkOperationReadWriter: bulkOps,
+			DeadLetterReadWriter:    ds.Data,
+			EventSchemaReadWriter:   ds.Data,
+			WebhookSourceReadWriter: ds.Data,
 		})
 	})
 
@@ -481,9 +556,15 @@ func start(ctx context.Context, opts StartOpts) error {
 		Config:         ds.Opts.Config,
 		Mounts:         mounts,
 		LocalEventKeys: opts.EventKeys,
+		Schemas:        eventschema.NewRegistry(ds.Data),
+		WebhookSources: ds.Data,
+		Dedupe: eventdedupe.New(
+			eventdedupe.NewRedisStore(unshardedRc, "{dedupe}:"),
+			eventdedupe.StaticWindow(opts.EventDedupeWindow),
+		),
 	})
 
-	svcs := []service.Service{ds, runner, executorSvc, ds.Apiservice}
+	svcs := []service.Service{ds, runner, executorSvc, ds.Apiservice, bulkSvc}
 	svcs = append(svcs, connGateway, connRouter)
 	return service.StartAll(ctx, svcs...)
 }
@@ -2,12 +2,16 @@ package devserver
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/khulnasoft/inngest/pkg/cqrs"
 	"github.com/khulnasoft/inngest/pkg/event"
 	"github.com/khulnasoft/inngest/pkg/execution"
 	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	statev1 "github.com/khulnasoft/inngest/pkg/execution/state"
 	"github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/logger"
 	"github.com/khulnasoft/inngest/pkg/pubsub"
 	"github.com/oklog/ulid/v2"
 )
@@ -27,13 +31,14 @@ func (l Lifecycle) OnFunctionScheduled(
 	_ []event.TrackedEvent,
 ) {
 	_ = l.Cqrs.InsertFunctionRun(ctx, cqrs.FunctionRun{
-		RunID:         md.ID.RunID,
-		RunStartedAt:  ulid.Time(md.ID.RunID.Time()),
-		FunctionID:    md.ID.FunctionID,
-		EventID:       md.Config.EventID(),
-		Cron:          md.Config.CronSchedule(),
-		OriginalRunID: md.Config.OriginalRunID,
-		WorkspaceID:   md.ID.Tenant.EnvID,
+		RunID:           md.ID.RunID,
+		RunStartedAt:    ulid.Time(md.ID.RunID.Time()),
+		FunctionID:      md.ID.FunctionID,
+		FunctionVersion: int64(md.Config.FunctionVersion),
+		EventID:         md.Config.EventID(),
+		Cron:            md.Config.CronSchedule(),
+		OriginalRunID:   md.Config.OriginalRunID,
+		WorkspaceID:     md.ID.Tenant.EnvID,
 	})
 
 	if md.Config.BatchID != nil {
@@ -55,3 +60,49 @@ func (l Lifecycle) OnFunctionScheduled(
 		}
 	}
 }
+
+// OnFunctionFinished records permanently failed runs as dead letters, allowing the runs
+// to be inspected and redriven.
+func (l Lifecycle) OnFunctionFinished(
+	ctx context.Context,
+	md state.Metadata,
+	item queue.Item,
+	evts []json.RawMessage,
+	resp statev1.DriverResponse,
+) {
+	if resp.Err == nil {
+		return
+	}
+
+	errByt, _ := json.Marshal(resp.StandardError())
+	dl := cqrs.DeadLetter{
+		RunID:         md.ID.RunID,
+		AccountID:     md.ID.Tenant.AccountID,
+		WorkspaceID:   md.ID.Tenant.EnvID,
+		AppID:         md.ID.Tenant.AppID,
+		FunctionID:    md.ID.FunctionID,
+		FunctionSlug:  md.Config.FunctionSlug(),
+		EventIDs:      md.Config.EventIDs,
+		Events:        evts,
+		Error:         errByt,
+		OriginalRunID: md.Config.OriginalRunID,
+		FailedAt:      time.Now(),
+	}
+
+	// Record the step that failed so that the run can be redriven from the step.
+	if edge, err := queue.GetEdge(item); err == nil && edge != nil {
+		switch {
+		case item.Kind == queue.KindEdgeError && edge.Edge.Outgoing != "":
+			dl.LastStepID = &edge.Edge.Outgoing
+		case edge.Edge.IncomingGeneratorStep != "":
+			dl.LastStepID = &edge.Edge.IncomingGeneratorStep
+			if edge.Edge.IncomingGeneratorStepName != "" {
+				dl.LastStepName = &edge.Edge.IncomingGeneratorStepName
+			}
+		}
+	}
+
+	if err := l.Cqrs.InsertDeadLetter(ctx, dl); err != nil {
+		logger.StdlibLogger(ctx).Error("error recording dead letter", "error", err, "run_id", md.ID.RunID.String())
+	}
+}
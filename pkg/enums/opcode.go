@@ -14,5 +14,6 @@ const (
 	OpcodeSleep
 	OpcodeWaitForEvent
 	OpcodeInvokeFunction
-	OpcodeAIGateway // AI gateway inference call
+	OpcodeAIGateway     // AI gateway inference call
+	OpcodeWaitForSignal // Waits for a named signal to be sent via the API
 )
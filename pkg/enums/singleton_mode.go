@@ -0,0 +1,14 @@
+//go:generate go run github.com/dmarkham/enumer -trimprefix=SingletonMode -type=SingletonMode -json -text -gqlgen
+
+package enums
+
+type SingletonMode int
+
+const (
+	// SingletonModeSkip represents the default SingletonMode 0, which skips new runs
+	// while a run with the same singleton key is in progress.
+	SingletonModeSkip SingletonMode = iota
+	// SingletonModeCancel cancels the in-progress run with the same singleton key
+	// and starts the new run.
+	SingletonModeCancel
+)
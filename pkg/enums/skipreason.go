@@ -10,4 +10,8 @@ const (
 
 	// SkipReasonFunctionPaused indicates that the function was paused.
 	SkipReasonFunctionPaused
+
+	// SkipReasonSingleton indicates that another run with the same singleton key
+	// was already in progress.
+	SkipReasonSingleton
 )
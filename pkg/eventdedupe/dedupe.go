@@ -0,0 +1,143 @@
+// Package eventdedupe drops events sent more than once with the same ID within a
+// window, eg. when a producer retries a request which timed out.
+package eventdedupe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/logger"
+	"github.com/khulnasoft/inngest/pkg/telemetry/metrics"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	pkgName = "eventdedupe.inngest"
+
+	// DefaultWindow is the window in which events with the same ID are
+	// deduplicated if no window is configured.
+	DefaultWindow = 24 * time.Hour
+
+	// pendingWait is the maximum time a duplicate waits for the original event
+	// to be handled so that the original's internal ID can be returned.
+	pendingWait = 5 * time.Second
+	// pendingPoll is the interval at which a duplicate checks whether the
+	// original event has been handled.
+	pendingPoll = 50 * time.Millisecond
+	// pendingTTL is how long an ID is reserved while its event is handled.  The
+	// reservation is only kept for the full window once the event is handled, so
+	// a process crashing mid-event doesn't block retries for the window.
+	pendingTTL = 30 * time.Second
+)
+
+// ErrPending is returned when an event with the same ID is still being handled.
+var ErrPending = errors.New("an event with the same ID is already being processed")
+
+// Store reserves event IDs within an environment.
+type Store interface {
+	// Reserve reserves the event ID for the given TTL while the event is
+	// handled.  If the ID is already reserved, it returns the internal ID of the
+	// original event, or nil and false if the original event is still being
+	// handled.
+	Reserve(ctx context.Context, envID uuid.UUID, eventID string, ttl time.Duration) (original *ulid.ULID, reserved bool, err error)
+	// Commit stores the internal ID of the event which reserved the ID for the
+	// window.
+	Commit(ctx context.Context, envID uuid.UUID, eventID string, internalID ulid.ULID, window time.Duration) error
+	// Release removes a reservation which wasn't committed, allowing the event to
+	// be retried.
+	Release(ctx context.Context, envID uuid.UUID, eventID string) error
+}
+
+// WindowFunc returns the dedupe window for an environment.  Windows of zero or
+// less disable deduplication.
+type WindowFunc func(ctx context.Context, envID uuid.UUID) time.Duration
+
+// StaticWindow returns a WindowFunc using the same window for every environment.
+func StaticWindow(d time.Duration) WindowFunc {
+	return func(ctx context.Context, envID uuid.UUID) time.Duration {
+		return d
+	}
+}
+
+// Handler handles an event which isn't a duplicate, returning its internal ID.
+type Handler func(ctx context.Context, evt event.Event) (string, error)
+
+// Deduper deduplicates events by their ID.
+type Deduper struct {
+	store  Store
+	window WindowFunc
+}
+
+func New(s Store, window WindowFunc) *Deduper {
+	if window == nil {
+		window = StaticWindow(DefaultWindow)
+	}
+	return &Deduper{store: s, window: window}
+}
+
+// Handle calls the handler unless an event with the same ID was handled in the
+// environment within its window, in which case the original event's internal ID
+// is returned and duplicate is true.  Events without IDs are always handled.
+func (d *Deduper) Handle(ctx context.Context, envID uuid.UUID, evt event.Event, h Handler) (id string, duplicate bool, err error) {
+	window := d.window(ctx, envID)
+	if evt.ID == "" || window <= 0 {
+		id, err := h(ctx, evt)
+		return id, false, err
+	}
+
+	original, reserved, err := d.reserve(ctx, envID, evt.ID, min(window, pendingTTL))
+	if err != nil {
+		return "", false, err
+	}
+	if !reserved {
+		metrics.IncrEventDedupeHitCounter(ctx, metrics.CounterOpt{
+			PkgName: pkgName,
+			Tags:    map[string]any{"workspaceID": envID.String()},
+		})
+		return original.String(), true, nil
+	}
+
+	id, err = h(ctx, evt)
+	if err != nil {
+		// Allow the event to be retried.
+		if rerr := d.store.Release(context.WithoutCancel(ctx), envID, evt.ID); rerr != nil {
+			err = errors.Join(err, rerr)
+		}
+		return id, false, err
+	}
+
+	internalID, err := ulid.Parse(id)
+	if err != nil {
+		return id, false, fmt.Errorf("invalid internal event ID %q: %w", id, err)
+	}
+	if err := d.store.Commit(ctx, envID, evt.ID, internalID, window); err != nil {
+		// The event was handled, so return its ID.  The reservation expires
+		// shortly after, and the ID is no longer deduplicated.
+		logger.StdlibLogger(ctx).Error("error committing event ID", "error", err, "event_id", evt.ID)
+	}
+	return id, false, nil
+}
+
+// reserve reserves the event ID, waiting for the original event to be handled
+// if it's in progress.
+func (d *Deduper) reserve(ctx context.Context, envID uuid.UUID, eventID string, ttl time.Duration) (*ulid.ULID, bool, error) {
+	deadline := time.Now().Add(pendingWait)
+	for {
+		original, reserved, err := d.store.Reserve(ctx, envID, eventID, ttl)
+		if err != nil || reserved || original != nil {
+			return original, reserved, err
+		}
+		if time.Now().After(deadline) {
+			return nil, false, ErrPending
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(pendingPoll):
+		}
+	}
+}
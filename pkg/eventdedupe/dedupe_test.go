@@ -0,0 +1,209 @@
+package eventdedupe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDeduper(t *testing.T, window time.Duration) (*Deduper, *miniredis.Miniredis) {
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(rc.Close)
+	return New(NewRedisStore(rc, "{dedupe}:"), StaticWindow(window)), r
+}
+
+// countingHandler returns a handler which generates a new internal ID for each
+// event handled.
+func countingHandler(calls *int) Handler {
+	return func(ctx context.Context, evt event.Event) (string, error) {
+		*calls++
+		return ulid.Make().String(), nil
+	}
+}
+
+func TestHandleDuplicate(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestDeduper(t, time.Hour)
+	envID := uuid.New()
+	evt := event.Event{ID: "order-123", Name: "order/created"}
+
+	calls := 0
+	id, dupe, err := d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+	require.False(t, dupe)
+
+	again, dupe, err := d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+	require.True(t, dupe)
+	require.Equal(t, id, again)
+	require.Equal(t, 1, calls)
+
+	t.Run("other environments are not deduplicated", func(t *testing.T) {
+		other, dupe, err := d.Handle(ctx, uuid.New(), evt, countingHandler(&calls))
+		require.NoError(t, err)
+		require.False(t, dupe)
+		require.NotEqual(t, id, other)
+		require.Equal(t, 2, calls)
+	})
+}
+
+func TestHandleWindowExpires(t *testing.T) {
+	ctx := context.Background()
+	d, r := newTestDeduper(t, time.Minute)
+	envID := uuid.New()
+	evt := event.Event{ID: "order-123", Name: "order/created"}
+
+	calls := 0
+	_, _, err := d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+
+	r.FastForward(2 * time.Minute)
+
+	_, dupe, err := d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+	require.False(t, dupe)
+	require.Equal(t, 2, calls)
+}
+
+func TestHandleErrorReleases(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestDeduper(t, time.Hour)
+	envID := uuid.New()
+	evt := event.Event{ID: "order-123", Name: "order/created"}
+
+	_, _, err := d.Handle(ctx, envID, evt, func(ctx context.Context, evt event.Event) (string, error) {
+		return "", errors.New("publish failed")
+	})
+	require.Error(t, err)
+
+	calls := 0
+	_, dupe, err := d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+	require.False(t, dupe)
+	require.Equal(t, 1, calls)
+}
+
+func TestHandleSkipped(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("events without IDs", func(t *testing.T) {
+		d, _ := newTestDeduper(t, time.Hour)
+		calls := 0
+		for i := 0; i < 2; i++ {
+			_, dupe, err := d.Handle(ctx, uuid.New(), event.Event{Name: "order/created"}, countingHandler(&calls))
+			require.NoError(t, err)
+			require.False(t, dupe)
+		}
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("zero window", func(t *testing.T) {
+		d, _ := newTestDeduper(t, 0)
+		envID := uuid.New()
+		calls := 0
+		for i := 0; i < 2; i++ {
+			_, dupe, err := d.Handle(ctx, envID, event.Event{ID: "order-123", Name: "order/created"}, countingHandler(&calls))
+			require.NoError(t, err)
+			require.False(t, dupe)
+		}
+		require.Equal(t, 2, calls)
+	})
+}
+
+func TestHandleWaitsForPending(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestDeduper(t, time.Hour)
+	envID := uuid.New()
+	evt := event.Event{ID: "order-123", Name: "order/created"}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	original := ulid.Make().String()
+	go func() {
+		_, _, _ = d.Handle(ctx, envID, evt, func(ctx context.Context, evt event.Event) (string, error) {
+			close(started)
+			<-release
+			return original, nil
+		})
+	}()
+	<-started
+
+	go func() {
+		<-time.After(100 * time.Millisecond)
+		close(release)
+	}()
+
+	id, dupe, err := d.Handle(ctx, envID, evt, func(ctx context.Context, evt event.Event) (string, error) {
+		t.Fatal("duplicate event handled")
+		return "", nil
+	})
+	require.NoError(t, err)
+	require.True(t, dupe)
+	require.Equal(t, original, id)
+}
+
+func TestHandleAbandonedReservationExpires(t *testing.T) {
+	ctx := context.Background()
+	d, r := newTestDeduper(t, time.Hour)
+	envID := uuid.New()
+	evt := event.Event{ID: "order-123", Name: "order/created"}
+
+	// Reserve the ID as if a process crashed while handling the event.
+	_, reserved, err := d.store.Reserve(ctx, envID, evt.ID, pendingTTL)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	// Retries are only blocked until the reservation expires, not for the window.
+	r.FastForward(pendingTTL)
+
+	calls := 0
+	_, dupe, err := d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+	require.False(t, dupe)
+	require.Equal(t, 1, calls)
+
+	// Handled events are deduplicated for the full window.
+	r.FastForward(30 * time.Minute)
+	_, dupe, err = d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+	require.True(t, dupe)
+	require.Equal(t, 1, calls)
+}
+
+// failingCommitStore fails to commit reservations.
+type failingCommitStore struct {
+	Store
+}
+
+func (failingCommitStore) Commit(ctx context.Context, envID uuid.UUID, eventID string, internalID ulid.ULID, window time.Duration) error {
+	return errors.New("commit failed")
+}
+
+func TestHandleCommitError(t *testing.T) {
+	ctx := context.Background()
+	d, _ := newTestDeduper(t, time.Hour)
+	d.store = failingCommitStore{Store: d.store}
+	envID := uuid.New()
+	evt := event.Event{ID: "order-123", Name: "order/created"}
+
+	// The event was handled, so its ID is returned without an error.
+	calls := 0
+	id, dupe, err := d.Handle(ctx, envID, evt, countingHandler(&calls))
+	require.NoError(t, err)
+	require.False(t, dupe)
+	require.NotEmpty(t, id)
+	require.Equal(t, 1, calls)
+}
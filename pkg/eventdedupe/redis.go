@@ -0,0 +1,94 @@
+package eventdedupe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+)
+
+const (
+	// redisPending is stored while the event which reserved an ID is handled.
+	redisPending = "pending"
+
+	redisReserveScript = `
+local existing = redis.call('get', KEYS[1])
+if existing then
+  return existing
+end
+redis.call('set', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return ''
+`
+
+	redisReleaseScript = `
+if redis.call('get', KEYS[1]) == ARGV[1] then
+  return redis.call('del', KEYS[1])
+end
+return 0
+`
+)
+
+// NewRedisStore returns a Store which reserves event IDs in Redis.
+func NewRedisStore(r rueidis.Client, prefix string) Store {
+	return &redisStore{
+		r:             r,
+		prefix:        prefix,
+		reserveScript: rueidis.NewLuaScript(redisReserveScript),
+		releaseScript: rueidis.NewLuaScript(redisReleaseScript),
+	}
+}
+
+type redisStore struct {
+	r      rueidis.Client
+	prefix string
+
+	reserveScript *rueidis.Lua
+	releaseScript *rueidis.Lua
+}
+
+func (r *redisStore) key(envID uuid.UUID, eventID string) string {
+	return fmt.Sprintf("%s%s:%s", r.prefix, envID, eventID)
+}
+
+func (r *redisStore) Reserve(ctx context.Context, envID uuid.UUID, eventID string, ttl time.Duration) (*ulid.ULID, bool, error) {
+	val, err := r.reserveScript.Exec(
+		ctx,
+		r.r,
+		[]string{r.key(envID, eventID)},
+		[]string{redisPending, fmt.Sprintf("%d", ttl.Milliseconds())},
+	).ToString()
+	if err != nil {
+		return nil, false, fmt.Errorf("error reserving event ID: %w", err)
+	}
+
+	switch val {
+	case "":
+		return nil, true, nil
+	case redisPending:
+		return nil, false, nil
+	}
+	id, err := ulid.Parse(val)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid internal ID for event ID: %w", err)
+	}
+	return &id, false, nil
+}
+
+func (r *redisStore) Commit(ctx context.Context, envID uuid.UUID, eventID string, internalID ulid.ULID, window time.Duration) error {
+	cmd := r.r.B().Set().Key(r.key(envID, eventID)).Value(internalID.String()).Px(window).Build()
+	if err := r.r.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("error committing event ID: %w", err)
+	}
+	return nil
+}
+
+func (r *redisStore) Release(ctx context.Context, envID uuid.UUID, eventID string) error {
+	err := r.releaseScript.Exec(ctx, r.r, []string{r.key(envID, eventID)}, []string{redisPending}).Error()
+	if err != nil {
+		return fmt.Errorf("error releasing event ID: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package eventschema
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/oklog/ulid/v2"
+)
+
+// DefaultMode is the mode used for schemas registered without a mode.  Events
+// which don't match are accepted so that registering a schema never breaks an
+// existing producer.
+const DefaultMode = cqrs.EventSchemaModeWarn
+
+// Failure describes an event which didn't match its schema.
+type Failure struct {
+	Mode   cqrs.EventSchemaMode
+	Errors []ValidationError
+}
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("event does not match its schema: %s", strings.Join(f.Messages(), "; "))
+}
+
+// Messages returns each validation error as a string.
+func (f Failure) Messages() []string {
+	msgs := make([]string, len(f.Errors))
+	for i, e := range f.Errors {
+		msgs[i] = e.Error()
+	}
+	return msgs
+}
+
+// Check validates a schema before it's registered, setting its default mode.
+func Check(s *cqrs.EventSchema) error {
+	if s.Name == "" {
+		return errors.New("event name is required")
+	}
+	if strings.HasPrefix(s.Name, event.InternalNamePrefix) {
+		return fmt.Errorf("event name is reserved for internal use: %s", s.Name)
+	}
+	if s.Mode == "" {
+		s.Mode = DefaultMode
+	}
+	if !s.Mode.Valid() {
+		return fmt.Errorf("invalid mode for %s: %q; must be one of reject, warn or tag", s.Name, s.Mode)
+	}
+	if _, err := Compile(s.Schema); err != nil {
+		return fmt.Errorf("invalid schema for %s: %w", s.Name, err)
+	}
+	return nil
+}
+
+// Registry validates events against the schemas registered for their names.
+type Registry struct {
+	rw cqrs.EventSchemaReadWriter
+
+	mu sync.Mutex
+	// compiled caches each event name's compiled schema, keyed by the schema's
+	// last update.
+	compiled map[string]compiledSchema
+}
+
+type compiledSchema struct {
+	updatedAt time.Time
+	schema    *Schema
+}
+
+func NewRegistry(rw cqrs.EventSchemaReadWriter) *Registry {
+	return &Registry{
+		rw:       rw,
+		compiled: map[string]compiledSchema{},
+	}
+}
+
+// Validate validates the event's data against the schema registered for the
+// event's name.  It returns nil if the event matches or no schema is registered.
+func (r *Registry) Validate(ctx context.Context, evt event.Event) (*Failure, error) {
+	s, err := r.rw.GetEventSchema(ctx, evt.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading event schema: %w", err)
+	}
+
+	r.mu.Lock()
+	c, ok := r.compiled[s.Name]
+	if !ok || !c.updatedAt.Equal(s.UpdatedAt) {
+		schema, err := Compile(s.Schema)
+		if err != nil {
+			r.mu.Unlock()
+			return nil, fmt.Errorf("invalid schema for %s: %w", s.Name, err)
+		}
+		c = compiledSchema{updatedAt: s.UpdatedAt, schema: schema}
+		r.compiled[s.Name] = c
+	}
+	r.mu.Unlock()
+
+	var data any = evt.Data
+	if evt.Data == nil {
+		data = map[string]any{}
+	}
+	errs := c.schema.Validate("data", data)
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return &Failure{Mode: s.Mode, Errors: errs}, nil
+}
+
+// Record stores the event's validation failure.  eventID is the internal ID of
+// the event, or nil if the event was rejected.
+func (r *Registry) Record(ctx context.Context, evt event.Event, f Failure, eventID *ulid.ULID) error {
+	byt, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("error marshalling event: %w", err)
+	}
+	now := time.Now()
+	return r.rw.InsertEventSchemaFailure(ctx, cqrs.EventSchemaFailure{
+		ID:         ulid.MustNew(ulid.Timestamp(now), rand.Reader),
+		EventName:  evt.Name,
+		Mode:       f.Mode,
+		EventID:    eventID,
+		Event:      byt,
+		Errors:     f.Messages(),
+		ReceivedAt: now,
+	})
+}
+
+// Tag adds the validation errors to the event's data, allowing functions to
+// check for them in expressions such as
+// "size(event.data._inngest.schema_errors) == 0".
+func Tag(evt *event.Event, f Failure) {
+	if evt.Data == nil {
+		evt.Data = map[string]any{}
+	}
+	msgs := f.Messages()
+	errs := make([]any, len(msgs))
+	for i, m := range msgs {
+		errs[i] = m
+	}
+	evt.Data[consts.InngestEventDataPrefix] = map[string]any{
+		consts.SchemaErrorsKey: errs,
+	}
+}
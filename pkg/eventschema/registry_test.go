@@ -0,0 +1,91 @@
+package eventschema
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/cqrs/base_cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	cm := base_cqrs.NewCQRS(db, "sqlite")
+	r := NewRegistry(cm)
+
+	schema := cqrs.EventSchema{
+		Name:   "registry/signup",
+		Schema: json.RawMessage(`{"type":"object","required":["user_id"]}`),
+	}
+	require.NoError(t, Check(&schema))
+	require.Equal(t, DefaultMode, schema.Mode)
+	_, err = cm.UpsertEventSchema(ctx, schema)
+	require.NoError(t, err)
+
+	t.Run("events without a schema are valid", func(t *testing.T) {
+		f, err := r.Validate(ctx, event.Event{Name: "registry/other"})
+		require.NoError(t, err)
+		require.Nil(t, f)
+	})
+
+	t.Run("matching events are valid", func(t *testing.T) {
+		f, err := r.Validate(ctx, event.Event{Name: "registry/signup", Data: map[string]any{"user_id": "u"}})
+		require.NoError(t, err)
+		require.Nil(t, f)
+	})
+
+	t.Run("renamed fields fail", func(t *testing.T) {
+		evt := event.Event{Name: "registry/signup", Data: map[string]any{"userId": "u"}}
+		f, err := r.Validate(ctx, evt)
+		require.NoError(t, err)
+		require.NotNil(t, f)
+		require.Equal(t, cqrs.EventSchemaModeWarn, f.Mode)
+		require.Equal(t, []string{"data.user_id: is required"}, f.Messages())
+
+		id := ulid.Make()
+		require.NoError(t, r.Record(ctx, evt, *f, &id))
+		failures, err := cm.GetEventSchemaFailures(ctx, cqrs.GetEventSchemaFailuresOpts{EventName: &evt.Name})
+		require.NoError(t, err)
+		require.Len(t, failures, 1)
+		require.Equal(t, &id, failures[0].EventID)
+
+		Tag(&evt, *f)
+		meta := evt.Data[consts.InngestEventDataPrefix].(map[string]any)
+		require.Equal(t, []any{"data.user_id: is required"}, meta[consts.SchemaErrorsKey])
+	})
+
+	t.Run("updated schemas are recompiled", func(t *testing.T) {
+		schema.Schema = json.RawMessage(`{"type":"object","required":["userId"]}`)
+		schema.Mode = cqrs.EventSchemaModeReject
+		_, err = cm.UpsertEventSchema(ctx, schema)
+		require.NoError(t, err)
+
+		f, err := r.Validate(ctx, event.Event{Name: "registry/signup", Data: map[string]any{"user_id": "u"}})
+		require.NoError(t, err)
+		require.NotNil(t, f)
+		require.Equal(t, cqrs.EventSchemaModeReject, f.Mode)
+		require.Equal(t, []string{"data.userId: is required"}, f.Messages())
+	})
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		schema cqrs.EventSchema
+		err    string
+	}{
+		{cqrs.EventSchema{Schema: json.RawMessage(`{}`)}, "event name is required"},
+		{cqrs.EventSchema{Name: "inngest/function.failed", Schema: json.RawMessage(`{}`)}, "reserved for internal use"},
+		{cqrs.EventSchema{Name: "a", Schema: json.RawMessage(`{}`), Mode: "drop"}, `invalid mode for a: "drop"`},
+		{cqrs.EventSchema{Name: "a", Schema: json.RawMessage(`{"type":1}`)}, "invalid schema for a"},
+	}
+	for _, test := range tests {
+		require.ErrorContains(t, Check(&test.schema), test.err)
+	}
+}
@@ -0,0 +1,203 @@
+// Package eventschema validates event data against JSON Schemas registered per
+// event name.
+package eventschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// schemaURL is the location each schema is compiled at.  References within the
+// schema, such as "#/$defs/user", resolve against it.
+const schemaURL = "inngest:event-schema.json"
+
+var printer = message.NewPrinter(language.English)
+
+// Schema is a compiled JSON Schema.  Schemas are validated against their
+// metaschema, using the draft named by "$schema" or draft 2020-12 by default, and
+// every keyword of that draft is supported with two exceptions:
+//
+//   - format is asserted rather than treated as an annotation, so a value such as
+//     "not-an-email" fails "format": "email".
+//   - $ref may only point within the schema; remote and file references are
+//     rejected when the schema is compiled.
+type Schema struct {
+	schema *jsonschema.Schema
+}
+
+// ValidationError describes a single value which doesn't match its schema.
+type ValidationError struct {
+	// Path is the path to the value within the event, eg. "data.user.id".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// Compile parses a JSON Schema document.
+func Compile(raw json.RawMessage) (*Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft2020)
+	c.AssertFormat()
+	c.UseLoader(localLoader{})
+	if err := c.AddResource(schemaURL, doc); err != nil {
+		return nil, err
+	}
+	s, err := c.Compile(schemaURL)
+	if err != nil {
+		return nil, compileError(err)
+	}
+	// A reference which resolves to itself without descending into the value is
+	// only found during validation, and fails for every value.
+	var verr *jsonschema.ValidationError
+	if errors.As(s.Validate(nil), &verr) {
+		for _, e := range leaves(verr) {
+			if _, ok := e.ErrorKind.(*kind.RefCycle); ok {
+				return nil, fmt.Errorf("schema is invalid: %s", e.ErrorKind.LocalizedString(printer))
+			}
+		}
+	}
+	return &Schema{schema: s}, nil
+}
+
+// localLoader rejects every reference outside of the schema, so compiling a
+// schema never reads files or makes requests.
+type localLoader struct{}
+
+func (localLoader) Load(url string) (any, error) {
+	return nil, errors.New("only references within the schema are supported")
+}
+
+// compileError describes why a schema failed to compile, listing each keyword
+// which doesn't match the metaschema rather than the library's nested output.
+func compileError(err error) error {
+	var serr *jsonschema.SchemaValidationError
+	if !errors.As(err, &serr) {
+		return err
+	}
+	verr, ok := serr.Err.(*jsonschema.ValidationError)
+	if !ok {
+		return err
+	}
+	msgs := []string{}
+	for _, e := range leaves(verr) {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", pointer(e.InstanceLocation), e.ErrorKind.LocalizedString(printer)))
+	}
+	sort.Strings(msgs)
+	return fmt.Errorf("schema is invalid: %s", strings.Join(msgs, "; "))
+}
+
+// Validate validates the value against the schema, returning every value which
+// doesn't match.  Path is the path of the value, used as the prefix for each
+// error's path.
+func (s *Schema) Validate(path string, v any) []ValidationError {
+	errs := []ValidationError{}
+
+	err := s.schema.Validate(normalize(v))
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		if err != nil {
+			errs = append(errs, ValidationError{Path: path, Message: err.Error()})
+		}
+		return errs
+	}
+
+	for _, e := range leaves(verr) {
+		at := join(path, e.InstanceLocation)
+		// Missing and unexpected properties are reported against the property
+		// rather than the object holding it, which is where users look for them.
+		switch k := e.ErrorKind.(type) {
+		case *kind.Required:
+			for _, name := range k.Missing {
+				errs = append(errs, ValidationError{Path: join(at, []string{name}), Message: "is required"})
+			}
+		case *kind.AdditionalProperties:
+			for _, name := range k.Properties {
+				errs = append(errs, ValidationError{Path: join(at, []string{name}), Message: "is not an allowed property"})
+			}
+		default:
+			errs = append(errs, ValidationError{Path: at, Message: k.LocalizedString(printer)})
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		if errs[i].Path != errs[j].Path {
+			return errs[i].Path < errs[j].Path
+		}
+		return errs[i].Message < errs[j].Message
+	})
+	return errs
+}
+
+// leaves returns the errors which caused err, without the errors for
+// keywords such as properties and allOf which only group them.
+func leaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	all := []*jsonschema.ValidationError{}
+	for _, c := range err.Causes {
+		all = append(all, leaves(c)...)
+	}
+	return all
+}
+
+// normalize converts v into the types produced by decoding JSON, which are the
+// only types the validator understands.  Event data is usually decoded already,
+// so this only round-trips values built in code.
+func normalize(v any) any {
+	switch v.(type) {
+	case nil, bool, string, float64, json.Number, map[string]any, []any:
+		return v
+	}
+	byt, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(byt))
+	if err != nil {
+		return v
+	}
+	return doc
+}
+
+// join appends the instance location to path, eg. "data" and ["tags", "1"]
+// become "data.tags[1]".
+func join(path string, loc []string) string {
+	var sb strings.Builder
+	sb.WriteString(path)
+	for _, tok := range loc {
+		if _, err := strconv.Atoi(tok); err == nil {
+			sb.WriteString("[" + tok + "]")
+			continue
+		}
+		sb.WriteString("." + tok)
+	}
+	return sb.String()
+}
+
+// pointer formats an instance location within a schema as a JSON pointer, eg.
+// "#/properties/a/pattern".
+func pointer(loc []string) string {
+	if len(loc) == 0 {
+		return "#"
+	}
+	return "#/" + strings.Join(loc, "/")
+}
@@ -0,0 +1,145 @@
+package eventschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["user", "amount"],
+		"additionalProperties": false,
+		"properties": {
+			"user": {
+				"type": "object",
+				"required": ["id"],
+				"properties": {
+					"id": {"type": "string", "minLength": 3},
+					"email": {"type": "string", "pattern": "@"}
+				}
+			},
+			"amount": {"type": "number", "minimum": 0, "exclusiveMaximum": 100},
+			"count": {"type": "integer"},
+			"currency": {"enum": ["usd", "eur"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+			"plan": {"oneOf": [{"const": "free"}, {"type": "object"}]},
+			"note": {"type": ["string", "null"]},
+			"contact": {"type": "string", "format": "email"}
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		data     string
+		expected []ValidationError
+	}{
+		{
+			name:     "valid",
+			data:     `{"user": {"id": "usr_1", "email": "a@b.c"}, "amount": 12.5, "count": 2, "currency": "usd", "tags": ["a", "b"], "plan": "free", "note": null, "contact": "a@b.c"}`,
+			expected: []ValidationError{},
+		},
+		{
+			name: "missing required fields",
+			data: `{"user": {}}`,
+			expected: []ValidationError{
+				{Path: "data.amount", Message: "is required"},
+				{Path: "data.user.id", Message: "is required"},
+			},
+		},
+		{
+			name: "renamed field",
+			data: `{"user": {"id": "usr_1"}, "total": 1}`,
+			expected: []ValidationError{
+				{Path: "data.amount", Message: "is required"},
+				{Path: "data.total", Message: "is not an allowed property"},
+			},
+		},
+		{
+			name: "wrong types",
+			data: `{"user": {"id": 1}, "amount": "1", "count": 1.5, "note": 1}`,
+			expected: []ValidationError{
+				{Path: "data.amount", Message: "got string, want number"},
+				{Path: "data.count", Message: "got number, want integer"},
+				{Path: "data.note", Message: "got number, want null or string"},
+				{Path: "data.user.id", Message: "got number, want string"},
+			},
+		},
+		{
+			name: "constraints",
+			data: `{"user": {"id": "u", "email": "nope"}, "amount": 100, "currency": "gbp", "tags": ["a", 1, "a"], "plan": 1, "contact": "nope"}`,
+			expected: []ValidationError{
+				{Path: "data.amount", Message: "exclusiveMaximum: got 100, want 100"},
+				{Path: "data.contact", Message: "'nope' is not valid email: missing @"},
+				{Path: "data.currency", Message: "value must be one of 'usd', 'eur'"},
+				{Path: "data.plan", Message: "got number, want object"},
+				{Path: "data.plan", Message: "value must be 'free'"},
+				{Path: "data.tags", Message: "items at 0 and 2 are equal"},
+				{Path: "data.tags", Message: "maxItems: got 3, want 2"},
+				{Path: "data.tags[1]", Message: "got number, want string"},
+				{Path: "data.user.email", Message: "'nope' does not match pattern '@'"},
+				{Path: "data.user.id", Message: "minLength: got 1, want 3"},
+			},
+		},
+	}
+
+	s, err := Compile(json.RawMessage(schema))
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var data map[string]any
+			require.NoError(t, json.Unmarshal([]byte(test.data), &data))
+			require.Equal(t, test.expected, s.Validate("data", data))
+		})
+	}
+}
+
+func TestValidateRefs(t *testing.T) {
+	s, err := Compile(json.RawMessage(`{
+		"$defs": {
+			"node": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}
+				}
+			}
+		},
+		"$ref": "#/$defs/node"
+	}`))
+	require.NoError(t, err)
+
+	var data map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "a", "children": [{"name": "b", "children": [{}]}]}`), &data))
+	require.Equal(t, []ValidationError{
+		{Path: "data.children[0].children[0].name", Message: "is required"},
+	}, s.Validate("data", data))
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`nope`, "schema is not valid JSON"},
+		{`[]`, "#: got array, want boolean or object"},
+		{`{"type": "str"}`, "#/type: value must be one of"},
+		{`{"properties": {"a": {"pattern": "("}}}`, "#/properties/a/pattern: '(' is not valid regex"},
+		{`{"minLength": -1}`, "#/minLength: minimum: got -1, want 0"},
+		{`{"$ref": "https://example.com/schema.json"}`, "only references within the schema are supported"},
+		{`{"$ref": "file:///etc/passwd"}`, "only references within the schema are supported"},
+		{`{"$ref": "#/definitions/missing"}`, `"inngest:event-schema.json#/definitions/missing" not found`},
+		{`{"$ref": "#"}`, "reference cycle"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.schema, func(t *testing.T) {
+			_, err := Compile(json.RawMessage(test.schema))
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// Package bulk processes bulk operations, cancelling or replaying every function
+// run matching a filter in the background.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/logger"
+	"github.com/khulnasoft/inngest/pkg/service"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	DefaultPollInterval = 5 * time.Second
+	DefaultPageSize     = 100
+)
+
+// Data is the set of readers required to find and replay runs.
+type Data interface {
+	cqrs.TraceReader
+	cqrs.FunctionReader
+	cqrs.EventReader
+}
+
+type Opts struct {
+	// Store reads and writes bulk operations.
+	Store cqrs.BulkOperationReadWriter
+	// Data finds the runs matching each operation.
+	Data Data
+	// Executor cancels and schedules runs.
+	Executor execution.Executor
+	// PollInterval is how often pending operations are checked.  Defaults
+	// to DefaultPollInterval.
+	PollInterval time.Duration
+	// PageSize is the number of runs processed between progress updates.
+	// Defaults to DefaultPageSize.
+	PageSize int
+}
+
+// NewService returns a service which processes pending bulk operations.
+func NewService(o Opts) service.Service {
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultPollInterval
+	}
+	if o.PageSize <= 0 {
+		o.PageSize = DefaultPageSize
+	}
+	return &svc{opts: o}
+}
+
+type svc struct {
+	opts Opts
+}
+
+func (s *svc) Name() string {
+	return "bulk-operations"
+}
+
+func (s *svc) Pre(ctx context.Context) error {
+	if s.opts.Store == nil || s.opts.Data == nil || s.opts.Executor == nil {
+		return fmt.Errorf("bulk operations require a store, data readers, and executor")
+	}
+	return nil
+}
+
+func (s *svc) Run(ctx context.Context) error {
+	t := time.NewTicker(s.opts.PollInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := s.poll(ctx); err != nil {
+				logger.StdlibLogger(ctx).Error("error processing bulk operations", "error", err)
+			}
+		}
+	}
+}
+
+func (s *svc) Stop(ctx context.Context) error {
+	return nil
+}
+
+func (s *svc) poll(ctx context.Context) error {
+	ops, err := s.opts.Store.PendingBulkOperations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := s.process(ctx, op); err != nil {
+			logger.StdlibLogger(ctx).Error("error processing bulk operation",
+				"error", err,
+				"op_id", op.ID,
+				"wsID", op.WorkspaceID,
+			)
+		}
+	}
+	return nil
+}
+
+// process processes every remaining run for the given operation, updating the
+// operation's progress after each page of runs.  Processing ends once an update
+// is rejected because the operation was stopped.
+func (s *svc) process(ctx context.Context, op cqrs.BulkOperation) error {
+	err := s.processRuns(ctx, op)
+	if errors.Is(err, cqrs.ErrBulkOperationDone) {
+		return nil
+	}
+	return err
+}
+
+func (s *svc) processRuns(ctx context.Context, op cqrs.BulkOperation) error {
+	if op.Status.IsDone() {
+		return nil
+	}
+
+	op.Status = cqrs.BulkOperationStatusRunning
+	if err := s.update(ctx, &op); err != nil {
+		return err
+	}
+
+	cel := ""
+	if op.If != nil {
+		cel = *op.If
+	}
+
+	for {
+		runs, err := s.opts.Data.GetTraceRuns(ctx, cqrs.GetTraceRunOpt{
+			Filter: cqrs.GetTraceRunFilter{
+				AccountID:   op.AccountID,
+				WorkspaceID: op.WorkspaceID,
+				FunctionID:  []uuid.UUID{op.FunctionID},
+				TimeField:   enums.TraceRunTimeQueuedAt,
+				From:        op.From,
+				Until:       op.Until,
+				Status:      op.RunStatus,
+				CEL:         cel,
+			},
+			Order: []cqrs.GetTraceRunOrder{
+				{Field: enums.TraceRunTimeQueuedAt, Direction: enums.TraceRunOrderAsc},
+			},
+			Cursor: op.Cursor,
+			Items:  uint(s.opts.PageSize),
+		})
+		if err != nil {
+			msg := fmt.Sprintf("error loading runs: %s", err)
+			op.Status = cqrs.BulkOperationStatusFailed
+			op.Error = &msg
+			return s.update(ctx, &op)
+		}
+
+		for _, run := range runs {
+			if err := s.apply(ctx, op, run); err != nil {
+				logger.StdlibLogger(ctx).Warn("error applying bulk operation to run",
+					"error", err,
+					"op_id", op.ID,
+					"run_id", run.RunID,
+				)
+				op.Failed++
+			} else {
+				op.Succeeded++
+			}
+			op.Processed++
+			op.Cursor = run.Cursor
+		}
+
+		if len(runs) < s.opts.PageSize {
+			op.Status = cqrs.BulkOperationStatusCompleted
+			return s.update(ctx, &op)
+		}
+
+		if err := s.update(ctx, &op); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			// Progress is saved, so processing continues from the cursor on
+			// the next start.
+			return nil
+		}
+	}
+}
+
+func (s *svc) update(ctx context.Context, op *cqrs.BulkOperation) error {
+	op.UpdatedAt = time.Now()
+	return s.opts.Store.UpdateBulkOperation(ctx, *op)
+}
+
+func (s *svc) apply(ctx context.Context, op cqrs.BulkOperation, run *cqrs.TraceRun) error {
+	runID, err := ulid.Parse(run.RunID)
+	if err != nil {
+		return fmt.Errorf("invalid run ID: %w", err)
+	}
+
+	switch op.Kind {
+	case cqrs.BulkOperationKindCancel:
+		return s.opts.Executor.Cancel(ctx, sv2.ID{
+			RunID:      runID,
+			FunctionID: run.FunctionID,
+			Tenant: sv2.Tenant{
+				AppID:     run.AppID,
+				EnvID:     op.WorkspaceID,
+				AccountID: op.AccountID,
+			},
+		}, execution.CancelRequest{})
+	case cqrs.BulkOperationKindReplay:
+		return s.replay(ctx, op, runID, run)
+	default:
+		return fmt.Errorf("unknown bulk operation kind: %s", op.Kind)
+	}
+}
+
+func (s *svc) replay(ctx context.Context, op cqrs.BulkOperation, runID ulid.ULID, run *cqrs.TraceRun) error {
+	fnCQRS, err := s.opts.Data.GetFunctionByInternalUUID(ctx, op.WorkspaceID, run.FunctionID)
+	if err != nil {
+		return fmt.Errorf("error loading function: %w", err)
+	}
+	fn, err := fnCQRS.InngestFunction()
+	if err != nil {
+		return fmt.Errorf("error loading function config: %w", err)
+	}
+
+	ids := make([]ulid.ULID, 0, len(run.TriggerIDs))
+	for _, id := range run.TriggerIDs {
+		parsed, err := ulid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("invalid trigger ID: %w", err)
+		}
+		ids = append(ids, parsed)
+	}
+	evts, err := s.opts.Data.GetEventsByInternalIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("error loading run events: %w", err)
+	}
+	if len(evts) == 0 {
+		return fmt.Errorf("no events found for run")
+	}
+
+	tracked := make([]event.TrackedEvent, len(evts))
+	for n, evt := range evts {
+		// Keep the original event IDs so that replays are linked to the
+		// same events as the original run.
+		tracked[n] = event.NewOSSTrackedEventWithID(evt.Event(), evt.InternalID())
+	}
+
+	_, err = s.opts.Executor.Schedule(ctx, execution.ScheduleRequest{
+		Function:      *fn,
+		AppID:         fnCQRS.AppID,
+		AccountID:     op.AccountID,
+		WorkspaceID:   op.WorkspaceID,
+		Events:        tracked,
+		OriginalRunID: &runID,
+	})
+	return err
+}
@@ -0,0 +1,111 @@
+package bulk
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessStopped(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	store := NewRedisReadWriter(rc, "")
+
+	for _, tc := range []struct {
+		name string
+		runs int
+	}{
+		{name: "stopped whilst processing a page", runs: 10},
+		{name: "stopped whilst processing the last page", runs: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			op := cqrs.BulkOperation{
+				ID:          ulid.MustNew(ulid.Now(), rand.Reader),
+				Kind:        cqrs.BulkOperationKindCancel,
+				WorkspaceID: uuid.New(),
+				FunctionID:  uuid.New(),
+				From:        time.Now().Add(-time.Hour),
+				Until:       time.Now(),
+				Status:      cqrs.BulkOperationStatusPending,
+			}
+			require.NoError(t, store.CreateBulkOperation(ctx, op))
+
+			data := &fakeData{runs: tc.runs}
+			exec := &fakeExecutor{
+				// Stop the operation as soon as the first run is cancelled.
+				onCancel: func() {
+					current, err := store.BulkOperation(ctx, op.WorkspaceID, op.ID)
+					require.NoError(t, err)
+					if current.Status == cqrs.BulkOperationStatusStopped {
+						return
+					}
+					current.Status = cqrs.BulkOperationStatusStopped
+					require.NoError(t, store.UpdateBulkOperation(ctx, *current))
+				},
+			}
+			s := NewService(Opts{Store: store, Data: data, Executor: exec, PageSize: 5}).(*svc)
+
+			require.NoError(t, s.process(ctx, op))
+
+			found, err := store.BulkOperation(ctx, op.WorkspaceID, op.ID)
+			require.NoError(t, err)
+			require.Equal(t, cqrs.BulkOperationStatusStopped, found.Status)
+			require.Equal(t, 1, data.calls)
+
+			pending, err := store.PendingBulkOperations(ctx)
+			require.NoError(t, err)
+			require.Empty(t, pending)
+		})
+	}
+}
+
+// fakeData returns the given number of runs, in pages.
+type fakeData struct {
+	Data
+
+	runs  int
+	calls int
+}
+
+func (f *fakeData) GetTraceRuns(ctx context.Context, opt cqrs.GetTraceRunOpt) ([]*cqrs.TraceRun, error) {
+	f.calls++
+	n := min(int(opt.Items), f.runs)
+	f.runs -= n
+
+	runs := make([]*cqrs.TraceRun, n)
+	for i := range runs {
+		runs[i] = &cqrs.TraceRun{
+			RunID:      ulid.MustNew(ulid.Now(), rand.Reader).String(),
+			FunctionID: opt.Filter.FunctionID[0],
+		}
+	}
+	return runs, nil
+}
+
+type fakeExecutor struct {
+	execution.Executor
+
+	onCancel func()
+}
+
+func (e *fakeExecutor) Cancel(ctx context.Context, id sv2.ID, r execution.CancelRequest) error {
+	e.onCancel()
+	return nil
+}
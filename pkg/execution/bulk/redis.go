@@ -0,0 +1,212 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+)
+
+const (
+	DefaultPrefix = "{bulk}"
+
+	// redisUpdateScript writes an operation unless it's missing or its stored
+	// status is final, so that progress updates never overwrite a stopped
+	// operation.
+	redisUpdateScript = `
+local existing = redis.call('HGET', KEYS[1], ARGV[1])
+if not existing then
+  return -1
+end
+local status = cjson.decode(existing)["o"]["status"]
+if status == "completed" or status == "failed" or status == "stopped" then
+  return 1
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+if ARGV[4] == "1" then
+  redis.call('SREM', KEYS[2], ARGV[3])
+else
+  redis.call('SADD', KEYS[2], ARGV[3])
+end
+return 0
+`
+)
+
+var updateScript = rueidis.NewLuaScript(redisUpdateScript)
+
+var (
+	nilID = ulid.ULID{}
+
+	ErrNotFound = fmt.Errorf("bulk operation not found")
+)
+
+// NewRedisReadWriter stores bulk operations in Redis.
+func NewRedisReadWriter(r rueidis.Client, prefix string) cqrs.BulkOperationReadWriter {
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	return redisReadWriter{r, prefix}
+}
+
+type redisReadWriter struct {
+	r      rueidis.Client
+	prefix string
+}
+
+type redisWrapper struct {
+	Version   int                `json:"v"`
+	Operation cqrs.BulkOperation `json:"o"`
+}
+
+func (r redisReadWriter) CreateBulkOperation(ctx context.Context, op cqrs.BulkOperation) error {
+	if op.ID == nilID {
+		return fmt.Errorf("A bulk operation ID must be created before writing")
+	}
+	return r.write(ctx, op)
+}
+
+func (r redisReadWriter) UpdateBulkOperation(ctx context.Context, op cqrs.BulkOperation) error {
+	if op.ID == nilID {
+		return fmt.Errorf("A bulk operation ID is required")
+	}
+	byt, err := json.Marshal(redisWrapper{Version: 1, Operation: op})
+	if err != nil {
+		return err
+	}
+
+	done := "0"
+	if op.Status.IsDone() {
+		done = "1"
+	}
+	res, err := updateScript.Exec(
+		ctx,
+		r.r,
+		[]string{r.key(op.WorkspaceID), r.pendingKey()},
+		[]string{op.ID.String(), string(byt), r.pendingMember(op), done},
+	).AsInt64()
+	if err != nil {
+		return fmt.Errorf("error updating bulk operation: %w", err)
+	}
+	switch res {
+	case -1:
+		return ErrNotFound
+	case 1:
+		return cqrs.ErrBulkOperationDone
+	}
+	return nil
+}
+
+func (r redisReadWriter) write(ctx context.Context, op cqrs.BulkOperation) error {
+	byt, err := json.Marshal(redisWrapper{Version: 1, Operation: op})
+	if err != nil {
+		return err
+	}
+
+	// Operations are stored in a hash per workspace, with an additional set of
+	// unfinished operations across all workspaces so that workers can find
+	// operations to process without scanning every workspace.
+	cmds := rueidis.Commands{
+		r.r.B().Hset().Key(r.key(op.WorkspaceID)).FieldValue().FieldValue(op.ID.String(), string(byt)).Build(),
+	}
+	if op.Status.IsDone() {
+		cmds = append(cmds, r.r.B().Srem().Key(r.pendingKey()).Member(r.pendingMember(op)).Build())
+	} else {
+		cmds = append(cmds, r.r.B().Sadd().Key(r.pendingKey()).Member(r.pendingMember(op)).Build())
+	}
+
+	for _, res := range r.r.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return fmt.Errorf("error writing bulk operation: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r redisReadWriter) BulkOperations(ctx context.Context, wsID uuid.UUID) ([]cqrs.BulkOperation, error) {
+	cmd := r.r.B().Hgetall().Key(r.key(wsID)).Build()
+	all, err := r.r.Do(ctx, cmd).AsMap()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []cqrs.BulkOperation{}
+	for _, item := range all {
+		found := &redisWrapper{}
+		if err := item.DecodeJSON(found); err != nil {
+			return nil, err
+		}
+		result = append(result, found.Operation)
+	}
+
+	// Return the newest operations first.
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID.Compare(result[j].ID) > 0
+	})
+	return result, nil
+}
+
+func (r redisReadWriter) BulkOperation(ctx context.Context, wsID uuid.UUID, id ulid.ULID) (*cqrs.BulkOperation, error) {
+	cmd := r.r.B().Hget().Key(r.key(wsID)).Field(id.String()).Build()
+	found := &redisWrapper{}
+	err := r.r.Do(ctx, cmd).DecodeJSON(found)
+	if rueidis.IsRedisNil(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &found.Operation, nil
+}
+
+func (r redisReadWriter) PendingBulkOperations(ctx context.Context) ([]cqrs.BulkOperation, error) {
+	cmd := r.r.B().Smembers().Key(r.pendingKey()).Build()
+	members, err := r.r.Do(ctx, cmd).AsStrSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []cqrs.BulkOperation{}
+	for _, m := range members {
+		wsStr, idStr, _ := strings.Cut(m, ":")
+		wsID, err := uuid.Parse(wsStr)
+		if err != nil {
+			continue
+		}
+		id, err := ulid.Parse(idStr)
+		if err != nil {
+			continue
+		}
+		op, err := r.BulkOperation(ctx, wsID, id)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *op)
+	}
+
+	// Process the oldest operations first.
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID.Compare(result[j].ID) < 0
+	})
+	return result, nil
+}
+
+func (r redisReadWriter) key(wsID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", r.prefix, wsID)
+}
+
+func (r redisReadWriter) pendingKey() string {
+	return fmt.Sprintf("%s:pending", r.prefix)
+}
+
+func (r redisReadWriter) pendingMember(op cqrs.BulkOperation) string {
+	return fmt.Sprintf("%s:%s", op.WorkspaceID, op.ID)
+}
@@ -0,0 +1,99 @@
+package bulk
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisReadWriter(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	rw := NewRedisReadWriter(rc, "")
+	wsID := uuid.New()
+
+	op := cqrs.BulkOperation{
+		ID:          ulid.MustNew(ulid.Now(), rand.Reader),
+		Kind:        cqrs.BulkOperationKindReplay,
+		WorkspaceID: wsID,
+		FunctionID:  uuid.New(),
+		From:        time.Now().Add(-time.Hour).Truncate(time.Second),
+		Until:       time.Now().Truncate(time.Second),
+		Status:      cqrs.BulkOperationStatusPending,
+	}
+
+	t.Run("an ID is required", func(t *testing.T) {
+		err := rw.CreateBulkOperation(ctx, cqrs.BulkOperation{})
+		require.Error(t, err)
+	})
+
+	t.Run("created operations are pending", func(t *testing.T) {
+		require.NoError(t, rw.CreateBulkOperation(ctx, op))
+
+		found, err := rw.BulkOperation(ctx, wsID, op.ID)
+		require.NoError(t, err)
+		require.Equal(t, op.ID, found.ID)
+		require.True(t, op.From.Equal(found.From))
+
+		all, err := rw.BulkOperations(ctx, wsID)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+
+		pending, err := rw.PendingBulkOperations(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+	})
+
+	t.Run("finished operations are no longer pending", func(t *testing.T) {
+		op.Status = cqrs.BulkOperationStatusCompleted
+		op.Processed = 10
+		require.NoError(t, rw.UpdateBulkOperation(ctx, op))
+
+		found, err := rw.BulkOperation(ctx, wsID, op.ID)
+		require.NoError(t, err)
+		require.Equal(t, 10, found.Processed)
+
+		pending, err := rw.PendingBulkOperations(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 0)
+	})
+
+	t.Run("finished operations are not overwritten", func(t *testing.T) {
+		updated := op
+		updated.Status = cqrs.BulkOperationStatusRunning
+		updated.Processed = 20
+		require.ErrorIs(t, rw.UpdateBulkOperation(ctx, updated), cqrs.ErrBulkOperationDone)
+
+		found, err := rw.BulkOperation(ctx, wsID, op.ID)
+		require.NoError(t, err)
+		require.Equal(t, cqrs.BulkOperationStatusCompleted, found.Status)
+		require.Equal(t, 10, found.Processed)
+
+		pending, err := rw.PendingBulkOperations(ctx)
+		require.NoError(t, err)
+		require.Len(t, pending, 0)
+	})
+
+	t.Run("missing operations return ErrNotFound", func(t *testing.T) {
+		_, err := rw.BulkOperation(ctx, wsID, ulid.MustNew(ulid.Now(), rand.Reader))
+		require.ErrorIs(t, err, ErrNotFound)
+
+		missing := op
+		missing.ID = ulid.MustNew(ulid.Now(), rand.Reader)
+		require.ErrorIs(t, rw.UpdateBulkOperation(ctx, missing), ErrNotFound)
+	})
+}
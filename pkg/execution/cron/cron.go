@@ -0,0 +1,90 @@
+// Package cron schedules function cron triggers as durable queue items.
+//
+// Each tick is enqueued with a job ID derived from the function, schedule and
+// scheduled time, so any number of processes may schedule the same tick and
+// only one queue item is ever created.  Whichever worker leases the item fires
+// the tick, then enqueues the schedule's next tick.
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/execution/state/redis_state"
+)
+
+// Handler fires the cron tick for the given queue item.
+type Handler func(ctx context.Context, item queue.Item) error
+
+// Payload is the queue item payload for a single cron tick.
+type Payload struct {
+	AccountID       uuid.UUID `json:"acctID"`
+	WorkspaceID     uuid.UUID `json:"wsID"`
+	AppID           uuid.UUID `json:"appID"`
+	FunctionID      uuid.UUID `json:"fnID"`
+	FunctionVersion int       `json:"fnV"`
+	// Spec is the cron trigger's schedule including its timezone, as returned
+	// by inngest.CronTrigger.Spec.
+	Spec string `json:"spec"`
+	// At is the time the tick was scheduled for, in unix milliseconds.
+	At int64 `json:"at"`
+	// CatchUp is true for ticks missed during downtime.  These don't enqueue
+	// the schedule's next tick.
+	CatchUp bool `json:"catchUp,omitempty"`
+}
+
+// ScheduledAt returns the time the tick was scheduled for.
+func (p Payload) ScheduledAt() time.Time {
+	return time.UnixMilli(p.At)
+}
+
+// JobID returns the idempotent queue job ID for the tick.
+func (p Payload) JobID() string {
+	return JobID(p.FunctionID, p.Spec, p.ScheduledAt())
+}
+
+// JobID returns the idempotent queue job ID for the given function's schedule
+// ticking at the given time.
+func JobID(fnID uuid.UUID, spec string, at time.Time) string {
+	return fmt.Sprintf("cron:%s:%s:%d", fnID, spec, at.UnixMilli())
+}
+
+// Enqueue enqueues the tick described by the payload at its scheduled time, or
+// immediately for catch-up ticks.  Enqueueing a tick which already exists is a
+// no-op.
+func Enqueue(ctx context.Context, q queue.Producer, p Payload) error {
+	jobID := p.JobID()
+	queueName := queue.KindCron
+	maxAttempts := consts.MaxRetries + 1
+
+	at := p.ScheduledAt()
+	if p.CatchUp {
+		at = time.Now()
+	}
+
+	err := q.Enqueue(ctx, queue.Item{
+		JobID:       &jobID,
+		WorkspaceID: p.WorkspaceID,
+		Kind:        queue.KindCron,
+		Identifier: state.Identifier{
+			WorkflowID:      p.FunctionID,
+			WorkflowVersion: p.FunctionVersion,
+			AccountID:       p.AccountID,
+			WorkspaceID:     p.WorkspaceID,
+			AppID:           p.AppID,
+		},
+		MaxAttempts: &maxAttempts,
+		Payload:     p,
+		QueueName:   &queueName,
+	}, at, queue.EnqueueOpts{})
+	if errors.Is(err, redis_state.ErrQueueItemExists) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	"github.com/khulnasoft/inngest/pkg/execution/state/redis_state"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueue(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	client := redis_state.NewQueueClient(rc, redis_state.QueueDefaultKey)
+	q := redis_state.NewQueue(redis_state.QueueShard{
+		Kind:        string(enums.QueueShardKindRedis),
+		RedisClient: client,
+		Name:        consts.DefaultQueueShardName,
+	})
+
+	at := time.Now().Add(time.Hour).Truncate(time.Minute)
+	p := Payload{
+		AccountID:   uuid.New(),
+		WorkspaceID: uuid.New(),
+		AppID:       uuid.New(),
+		FunctionID:  uuid.New(),
+		Spec:        "CRON_TZ=UTC 0 * * * *",
+		At:          at.UnixMilli(),
+	}
+
+	items := func() []queue.QueueItem {
+		all := []queue.QueueItem{}
+		key := client.KeyGenerator().QueueItem()
+		fields, err := r.HKeys(key)
+		require.NoError(t, err)
+		for _, field := range fields {
+			qi := queue.QueueItem{}
+			require.NoError(t, json.Unmarshal([]byte(r.HGet(key, field)), &qi))
+			all = append(all, qi)
+		}
+		return all
+	}
+
+	// Many processes enqueueing the same tick create a single queue item.
+	require.NoError(t, Enqueue(ctx, q, p))
+	require.NoError(t, Enqueue(ctx, q, p))
+	found := items()
+	require.Len(t, found, 1)
+	require.Equal(t, queue.KindCron, found[0].Data.Kind)
+	require.Equal(t, at.UnixMilli(), found[0].AtMS)
+
+	enqueued := Payload{}
+	require.NoError(t, json.Unmarshal(found[0].Data.Payload.(json.RawMessage), &enqueued))
+	require.Equal(t, p, enqueued)
+
+	// The next tick is a separate item.
+	next := p
+	next.At = at.Add(time.Hour).UnixMilli()
+	require.NoError(t, Enqueue(ctx, q, next))
+	require.Len(t, items(), 2)
+
+	// Catch-up ticks are enqueued immediately.
+	missed := p
+	missed.At = at.Add(-24 * time.Hour).UnixMilli()
+	missed.CatchUp = true
+	require.NoError(t, Enqueue(ctx, q, missed))
+	require.Len(t, items(), 3)
+}
+
+func TestJobID(t *testing.T) {
+	fnID := uuid.New()
+	at := time.Now()
+	spec := "CRON_TZ=UTC 0 * * * *"
+
+	require.Equal(t, JobID(fnID, spec, at), Payload{FunctionID: fnID, Spec: spec, At: at.UnixMilli()}.JobID())
+	require.NotEqual(t, JobID(fnID, spec, at), JobID(fnID, spec, at.Add(time.Minute)))
+	require.NotEqual(t, JobID(fnID, spec, at), JobID(fnID, "CRON_TZ=Europe/Berlin 0 * * * *", at))
+	require.NotEqual(t, JobID(fnID, spec, at), JobID(uuid.New(), spec, at))
+}
@@ -110,6 +110,12 @@ func do(ctx context.Context, forwarder pubsub.RequestForwarder, appId uuid.UUID,
 	resp, err := forwarder.Proxy(ctx, appId, data)
 	dur := time.Since(pre)
 
+	// The request never reached a worker, so requeue it without counting it as an attempt.
+	var nack *pubsub.NackError
+	if errors.As(err, &nack) {
+		return nil, queue.RetryAtError(queue.AlwaysRetryError(err), &nack.RetryAt)
+	}
+
 	// TODO Check if we need some of the request error handling logic from httpdriver.do()
 	if err != nil && resp == nil {
 		return nil, err
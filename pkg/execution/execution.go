@@ -97,6 +97,10 @@ type Executor interface {
 	Cancel(ctx context.Context, id sv2.ID, r CancelRequest) error
 	// Resume resumes an in-progress function run from the given waitForEvent pause.
 	Resume(ctx context.Context, p state.Pause, r ResumeRequest) error
+	// ResumeSignal resumes the function run waiting for the given signal within
+	// a workspace, using data as the step's output.  This returns
+	// state.ErrSignalPauseNotFound if no run is waiting for the signal.
+	ResumeSignal(ctx context.Context, workspaceID uuid.UUID, signalID string, data json.RawMessage) (*ResumeSignalResult, error)
 
 	// AddLifecycleListener adds a lifecycle listener to run on hooks.  This must
 	// always add to a list of listeners vs replace listeners.
@@ -189,6 +193,11 @@ type ScheduleRequestFromStep struct {
 	// Input is the input data for the step. Can be partial JSON, in which case
 	// an SDK will merge this with the existing input data.
 	Input json.RawMessage
+
+	// StepOverrides replaces the memoized output of the given step IDs when
+	// copying state from the original run.  Each value is stored as the step's
+	// data.  Only steps that ran prior to StepID can be overridden.
+	StepOverrides map[string]json.RawMessage
 }
 
 // CancelRequest stores information about the incoming cancellation request within
@@ -272,6 +281,12 @@ func (r *ResumeRequest) withKey(key string) string {
 	return ""
 }
 
+// ResumeSignalResult is returned when successfully resuming a run via a signal.
+type ResumeSignalResult struct {
+	// RunID is the ID of the run that was resumed.
+	RunID ulid.ULID `json:"run_id"`
+}
+
 // HandlePauseResult returns status information about pause handling.
 type HandlePauseResult [2]int32
 
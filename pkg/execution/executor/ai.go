@@ -0,0 +1,146 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/logger"
+	"github.com/khulnasoft/inngest/pkg/telemetry/metrics"
+	"github.com/khulnasoft/inngest/pkg/util/aigateway"
+)
+
+// WithAIGatewayCache sets the cache used for AI gateway responses, for functions
+// which configure a cache TTL.
+func WithAIGatewayCache(c aigateway.Cache) ExecutorOpt {
+	return func(e execution.Executor) error {
+		e.(*executor).aiCache = c
+		return nil
+	}
+}
+
+// WithAITokenBudgets sets the store tracking AI gateway token usage.  Functions
+// which configure a token budget are limited by it, and envBudget optionally
+// returns a budget shared by every function in an environment.
+func WithAITokenBudgets(b aigateway.Budgets, envBudget func(ctx context.Context, envID uuid.UUID) *aigateway.Budget) ExecutorOpt {
+	return func(e execution.Executor) error {
+		e.(*executor).aiBudgets = b
+		e.(*executor).aiEnvBudget = envBudget
+		return nil
+	}
+}
+
+// aiBudget is a token budget for a single function or environment.
+type aiBudget struct {
+	key    string
+	budget aigateway.Budget
+}
+
+// aiTokenBudgets returns the token budgets that the run's AI requests count
+// towards.
+func (e *executor) aiTokenBudgets(ctx context.Context, i *runInstance) []aiBudget {
+	if e.aiBudgets == nil {
+		return nil
+	}
+
+	budgets := []aiBudget{}
+	if i.f.AI != nil && i.f.AI.TokenBudget != nil {
+		tb := i.f.AI.TokenBudget
+		budgets = append(budgets, aiBudget{
+			key: "fn:" + i.md.ID.FunctionID.String(),
+			budget: aigateway.Budget{
+				Scope:  "function",
+				Limit:  tb.Limit,
+				Period: tb.PeriodDuration(),
+			},
+		})
+	}
+	if e.aiEnvBudget != nil {
+		if b := e.aiEnvBudget(ctx, i.md.ID.Tenant.EnvID); b != nil {
+			budgets = append(budgets, aiBudget{
+				key:    "env:" + i.md.ID.Tenant.EnvID.String(),
+				budget: *b,
+			})
+		}
+	}
+	return budgets
+}
+
+// checkAITokenBudgets returns an aigateway.BudgetExceededError if any budget is
+// used.  Errors loading usage are logged and ignored, so that AI requests aren't
+// blocked when usage is unavailable.
+func (e *executor) checkAITokenBudgets(ctx context.Context, budgets []aiBudget) error {
+	now := time.Now()
+	for _, b := range budgets {
+		err := e.aiBudgets.Check(ctx, b.key, b.budget, now)
+		if errors.Is(err, aigateway.ErrBudgetExceeded) {
+			return err
+		}
+		if err != nil {
+			logger.StdlibLogger(ctx).Error("error checking ai token budget", "error", err, "budget", b.key)
+		}
+	}
+	return nil
+}
+
+// recordAIUsage records the tokens used by a successful AI request against each
+// budget and in the usage metrics.
+func (e *executor) recordAIUsage(ctx context.Context, budgets []aiBudget, format string, usage aigateway.Usage) {
+	for _, dir := range []struct {
+		name   string
+		tokens int64
+	}{{"input", usage.InputTokens}, {"output", usage.OutputTokens}} {
+		metrics.IncrAIGatewayTokensCounter(ctx, dir.tokens, metrics.CounterOpt{
+			PkgName: pkgName,
+			Tags: map[string]any{
+				"format":    format,
+				"model":     usage.Model,
+				"direction": dir.name,
+			},
+		})
+	}
+
+	now := time.Now()
+	for _, b := range budgets {
+		if err := e.aiBudgets.Record(ctx, b.key, b.budget, usage.Total(), now); err != nil {
+			logger.StdlibLogger(ctx).Error("error recording ai token usage", "error", err, "budget", b.key)
+		}
+	}
+}
+
+// aiCacheKey returns the cache key and TTL for the request, or an empty key if
+// the function doesn't cache AI responses.  Keys are scoped to the function.
+func (e *executor) aiCacheKey(ctx context.Context, i *runInstance, req aigateway.Request) (string, time.Duration) {
+	if e.aiCache == nil || i.f.AI == nil {
+		return "", 0
+	}
+	ttl := i.f.AI.CacheDuration()
+	if ttl <= 0 {
+		return "", 0
+	}
+	key, err := aigateway.CacheKey(req)
+	if err != nil {
+		logger.StdlibLogger(ctx).Warn("error creating ai cache key", "error", err)
+		return "", 0
+	}
+	return i.md.ID.FunctionID.String() + ":" + key, ttl
+}
+
+// cachedAIResponse returns the cached response for the key, if any.
+func (e *executor) cachedAIResponse(ctx context.Context, key string) ([]byte, bool) {
+	byt, ok, err := e.aiCache.Get(ctx, key)
+	if err != nil {
+		logger.StdlibLogger(ctx).Error("error loading cached ai response", "error", err)
+	}
+	status := "miss"
+	if ok {
+		status = "hit"
+	}
+	metrics.IncrAIGatewayCacheCounter(ctx, metrics.CounterOpt{
+		PkgName: pkgName,
+		Tags:    map[string]any{"status": status},
+	})
+	return byt, ok
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/fatih/structs"
 	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/backoff"
 	"github.com/khulnasoft/inngest/pkg/consts"
 	"github.com/khulnasoft/inngest/pkg/cqrs"
 	"github.com/khulnasoft/inngest/pkg/enums"
@@ -28,6 +30,7 @@ import (
 	"github.com/khulnasoft/inngest/pkg/execution/driver/httpdriver"
 	"github.com/khulnasoft/inngest/pkg/execution/queue"
 	"github.com/khulnasoft/inngest/pkg/execution/realtime"
+	"github.com/khulnasoft/inngest/pkg/execution/singleton"
 	"github.com/khulnasoft/inngest/pkg/execution/state"
 	"github.com/khulnasoft/inngest/pkg/execution/state/redis_state"
 	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
@@ -39,6 +42,7 @@ import (
 	"github.com/khulnasoft/inngest/pkg/telemetry/metrics"
 	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
 	"github.com/khulnasoft/inngest/pkg/util"
+	"github.com/khulnasoft/inngest/pkg/util/aigateway"
 	"github.com/oklog/ulid/v2"
 	"github.com/rs/zerolog"
 	"github.com/xhit/go-str2duration/v2"
@@ -62,9 +66,15 @@ var (
 	ErrNoRuntimeDriver   = fmt.Errorf("runtime driver for action not found")
 	ErrFunctionDebounced = fmt.Errorf("function debounced")
 	ErrFunctionSkipped   = fmt.Errorf("function skipped")
+	// ErrFunctionSingletonSkipped is returned when a run is skipped because
+	// another run holds its singleton key.
+	ErrFunctionSingletonSkipped = fmt.Errorf("%w: singleton run in progress", ErrFunctionSkipped)
 
 	ErrFunctionEnded = fmt.Errorf("function already ended")
 
+	// ErrStepTimedOut is returned when an attempt of a step exceeds the step's timeout.
+	ErrStepTimedOut = fmt.Errorf("step timed out")
+
 	// ErrHandledStepError is returned when an OpcodeStepError is caught and the
 	// step should be safely retried.
 	ErrHandledStepError = fmt.Errorf("handled step error")
@@ -215,6 +225,14 @@ func WithDebouncer(d debounce.Debouncer) ExecutorOpt {
 	}
 }
 
+// WithSingletonManager sets the singleton manager used to enforce function singleton config.
+func WithSingletonManager(s singleton.Singleton) ExecutorOpt {
+	return func(e execution.Executor) error {
+		e.(*executor).singleton = s
+		return nil
+	}
+}
+
 func WithBatcher(b batch.BatchManager) ExecutorOpt {
 	return func(e execution.Executor) error {
 		e.(*executor).batcher = b
@@ -297,6 +315,7 @@ type executor struct {
 
 	queue               queue.Queue
 	debouncer           debounce.Debouncer
+	singleton           singleton.Singleton
 	batcher             batch.BatchManager
 	fl                  state.FunctionLoader
 	evalFactory         func(ctx context.Context, expr string) (expressions.Evaluator, error)
@@ -324,6 +343,12 @@ type executor struct {
 	shardFinder        redis_state.ShardSelector
 
 	traceReader cqrs.TraceReader
+
+	// aiCache caches AI gateway responses for functions which configure a cache TTL.
+	aiCache aigateway.Cache
+	// aiBudgets tracks AI gateway token usage against budgets.
+	aiBudgets   aigateway.Budgets
+	aiEnvBudget func(ctx context.Context, envID uuid.UUID) *aigateway.Budget
 }
 
 func (e *executor) SetFinalizer(f execution.FinalizePublisher) {
@@ -504,6 +529,20 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 		return nil, ErrFunctionSkipped
 	}
 
+	// Store the run's singleton key in its state, so that the key is released
+	// when the run finishes.  The key is claimed once the run's state exists, so
+	// that duplicate schedules never affect the run holding the key.
+	if req.Function.Singleton != nil && e.singleton != nil {
+		key, err := singleton.SingletonKey(ctx, req.Function.ID, *req.Function.Singleton, req.Events[0].GetEvent().Map())
+		switch err {
+		case nil:
+			metadata.Config.SetSingletonKey(key)
+		case singleton.ErrNotSingleton:
+		default:
+			return nil, err
+		}
+	}
+
 	mapped := make([]map[string]any, len(req.Events))
 	for n, item := range req.Events {
 		mapped[n] = item.GetEvent().Map()
@@ -573,9 +612,21 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 	}
 
 	if req.OriginalRunID != nil && req.FromStep != nil && req.FromStep.StepID != "" {
-		if err := reconstruct(ctx, e.traceReader, req, &newState); err != nil {
-			return nil, fmt.Errorf("error reconstructing input state: %w", err)
+		// Prefer copying state directly from the original run, falling back to
+		// reconstructing state from traces once the original run's state is gone.
+		copied, err := reconstructFromState(ctx, e.smv2, req, &newState)
+		if err != nil {
+			return nil, fmt.Errorf("error copying original run state: %w", err)
+		}
+		if !copied {
+			if err := reconstruct(ctx, e.traceReader, req, &newState); err != nil {
+				return nil, fmt.Errorf("error reconstructing input state: %w", err)
+			}
+		}
+		if err := applyStepOverrides(req, &newState); err != nil {
+			return nil, fmt.Errorf("error overriding step state: %w", err)
 		}
+		setFromStepInput(req, &newState)
 	}
 
 	err := e.smv2.Create(ctx, newState)
@@ -587,6 +638,12 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 		return nil, fmt.Errorf("error creating run state: %w", err)
 	}
 
+	// Ensure that only a single run exists for the function's singleton key, skipping
+	// this run or cancelling the existing run depending on the singleton mode.
+	if e.handleSingleton(ctx, req, metadata, evts) {
+		return nil, ErrFunctionSingletonSkipped
+	}
+
 	//
 	// Create cancellation pauses immediately, only if this is a non-batch event.
 	//
@@ -710,6 +767,93 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 	return &metadata, nil
 }
 
+// handleSingleton claims the run's singleton key once its state has been
+// created.  In skip mode, the new run is deleted and true is returned if another
+// run holds the key.  In cancel mode, the run holding the key is cancelled.
+//
+// Errors claiming the key are logged and the run continues, as the run's state
+// and idempotency key already exist and the schedule can't be retried.
+func (e *executor) handleSingleton(ctx context.Context, req execution.ScheduleRequest, md sv2.Metadata, evts []json.RawMessage) bool {
+	key := md.Config.SingletonKey()
+	if key == "" || e.singleton == nil {
+		return false
+	}
+
+	runID := md.ID.RunID
+	previous := func(id ulid.ULID) sv2.ID {
+		prev := md.ID
+		prev.RunID = id
+		return prev
+	}
+	l := logger.StdlibLogger(ctx).With("run_id", runID.String())
+
+	switch req.Function.Singleton.Mode {
+	case enums.SingletonModeCancel:
+		existing, err := e.singleton.Replace(ctx, key, runID)
+		if err != nil {
+			l.Error("error replacing singleton key", "error", err)
+			return false
+		}
+		if existing == nil {
+			return false
+		}
+		// Cancel the run which previously held the key.  If the run has already
+		// finished, cancelling is a no-op.
+		err = e.Cancel(ctx, previous(*existing), execution.CancelRequest{})
+		if err != nil && !errors.Is(err, ErrFunctionEnded) {
+			l.Error("error cancelling singleton run", "error", err, "singleton_run_id", existing.String())
+		}
+		return false
+	default:
+		existing, err := e.singleton.Claim(ctx, key, runID)
+		if err != nil {
+			l.Error("error claiming singleton key", "error", err)
+			return false
+		}
+		if existing == nil {
+			return false
+		}
+		exists, err := e.smv2.Exists(ctx, previous(*existing))
+		if err != nil {
+			l.Error("error checking singleton run", "error", err, "singleton_run_id", existing.String())
+			return false
+		}
+		if !exists {
+			// The run holding the key no longer exists, eg. because its state
+			// expired before the key was released.  Take over the key.
+			if _, err := e.singleton.Replace(ctx, key, runID); err != nil {
+				l.Error("error replacing singleton key", "error", err)
+			}
+			return false
+		}
+
+		// Another run holds the key, so remove this run.  The run's idempotency
+		// key is kept, so retrying this schedule is still a no-op.
+		if _, err := e.smv2.Delete(ctx, md.ID); err != nil {
+			l.Error("error deleting skipped singleton run", "error", err)
+		}
+		for _, lc := range e.lifecycles {
+			go lc.OnFunctionSkipped(context.WithoutCancel(ctx), md, execution.SkipState{
+				CronSchedule: req.Events[0].GetEvent().CronSchedule(),
+				Reason:       enums.SkipReasonSingleton,
+				Events:       evts,
+			})
+		}
+		return true
+	}
+}
+
+// releaseSingleton releases the singleton key held by the given run, if any.
+func (e *executor) releaseSingleton(ctx context.Context, md sv2.Metadata) {
+	key := md.Config.SingletonKey()
+	if key == "" || e.singleton == nil {
+		return
+	}
+	if err := e.singleton.Release(ctx, key, md.ID.RunID); err != nil {
+		logger.StdlibLogger(ctx).Error("error releasing singleton key", "error", err, "run_id", md.ID.RunID.String())
+	}
+}
+
 type runInstance struct {
 	md         sv2.Metadata
 	f          inngest.Function
@@ -718,6 +862,36 @@ type runInstance struct {
 	edge       inngest.Edge
 	resp       *state.DriverResponse
 	stackIndex int
+
+	priorityOnce   sync.Once
+	priorityFactor *int64
+}
+
+// stepPriorityFactor returns the priority factor for step jobs enqueued by this instance.
+// The function's priority.run expression is re-evaluated so that in-progress runs keep
+// their priority against newly enqueued work, including runs started before the expression
+// was added or changed.  If the function has no priority, or the expression cannot be
+// evaluated, the factor of the current job is inherited.
+func (i *runInstance) stepPriorityFactor(ctx context.Context) *int64 {
+	i.priorityOnce.Do(func() {
+		i.priorityFactor = i.item.PriorityFactor
+		if i.f.Priority == nil || i.f.Priority.Run == nil || len(i.events) == 0 {
+			return
+		}
+
+		evt := map[string]any{}
+		if err := json.Unmarshal(i.events[0], &evt); err != nil {
+			return
+		}
+
+		factor, err := i.f.RunPriorityFactor(ctx, evt)
+		if err != nil {
+			logger.StdlibLogger(ctx).Warn("error evaluating step priority", "error", err, "run_id", i.md.ID.RunID.String())
+			return
+		}
+		i.priorityFactor = &factor
+	})
+	return i.priorityFactor
 }
 
 // Execute loads a workflow and the current run state, then executes the
@@ -1056,6 +1230,9 @@ func (e *executor) finalize(ctx context.Context, md sv2.Metadata, evts []json.Ra
 		logger.StdlibLogger(ctx).Error("error deleting state in finalize", "error", err)
 	}
 
+	// Release the singleton key so that new runs can be scheduled.
+	e.releaseSingleton(ctx, md)
+
 	// We may be cancelling an in-progress run.  If that's the case, we want to delete any
 	// outstanding jobs from the queue, if possible.
 	//
@@ -1189,7 +1366,7 @@ func (e *executor) run(ctx context.Context, i *runInstance) (*state.DriverRespon
 
 	// Execute the actual step.
 	response, err := e.executeDriverForStep(ctx, i)
-	if response.Err != nil && err == nil {
+	if response != nil && response.Err != nil && err == nil {
 		// This step errored, so always return an error.
 		return response, fmt.Errorf("%s", *response.Err)
 	}
@@ -1210,7 +1387,33 @@ func (e *executor) executeDriverForStep(ctx context.Context, i *runInstance) (*s
 
 	step := &i.f.Steps[0]
 
-	response, err := d.Execute(ctx, e.smv2, i.md, i.item, i.edge, *step, i.stackIndex, i.item.Attempt)
+	// Enforce the step's timeout for each attempt, if declared.
+	var timeout time.Duration
+	if i.item.StepPolicy != nil {
+		timeout = i.item.StepPolicy.TimeoutDuration()
+	}
+	driverCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		driverCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	response, err := d.Execute(driverCtx, e.smv2, i.md, i.item, i.edge, *step, i.stackIndex, i.item.Attempt)
+	if timeout > 0 && errors.Is(driverCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		err = fmt.Errorf("%w after %s", ErrStepTimedOut, timeout)
+		if response != nil {
+			errstr := err.Error()
+			response.Err = &errstr
+		}
+	}
+
+	// Drivers may reject requests before they reach the SDK, eg. if all connect workers are
+	// at capacity.  These are retried as-is and are never handled as step errors.
+	var alwaysRetry queue.AlwaysRetryableError
+	if response == nil && errors.As(err, &alwaysRetry) {
+		return nil, err
+	}
 
 	// TODO: Steps.
 	if response == nil {
@@ -1685,6 +1888,7 @@ func (e *executor) Resume(ctx context.Context, pause state.Pause, r execution.Re
 		return fmt.Errorf("error loading metadata to resume from pause: %w", err)
 	}
 
+	leased := true
 	err = util.Crit(ctx, "consume pause", func(ctx context.Context) error {
 		// Lease this pause so that only this thread can schedule the execution.
 		//
@@ -1693,6 +1897,7 @@ func (e *executor) Resume(ctx context.Context, pause state.Pause, r execution.Re
 		err = e.pm.LeasePause(ctx, pause.ID)
 		if err == state.ErrPauseLeased || err == state.ErrPauseNotFound {
 			// Ignore;  this is being handled by another runner.
+			leased = false
 			return nil
 		}
 
@@ -1777,10 +1982,20 @@ func (e *executor) Resume(ctx context.Context, pause state.Pause, r execution.Re
 		return err
 	}
 
+	if !leased && pause.IsSignal() && !r.IsTimeout {
+		// Signals are resumed exactly once via the API, so the caller
+		// must know that this request did not resume the run.
+		return state.ErrSignalPauseNotFound
+	}
+
 	if pause.IsInvoke() {
 		for _, e := range e.lifecycles {
 			go e.OnInvokeFunctionResumed(context.WithoutCancel(ctx), md, pause, r)
 		}
+	} else if pause.IsSignal() {
+		for _, e := range e.lifecycles {
+			go e.OnWaitForSignalResumed(context.WithoutCancel(ctx), md, pause, r)
+		}
 	} else {
 		for _, e := range e.lifecycles {
 			go e.OnWaitForEventResumed(context.WithoutCancel(ctx), md, pause, r)
@@ -1914,6 +2129,8 @@ func (e *executor) handleGenerator(ctx context.Context, i *runInstance, gen stat
 		return e.handleGeneratorSleep(ctx, i, gen, edge)
 	case enums.OpcodeWaitForEvent:
 		return e.handleGeneratorWaitForEvent(ctx, i, gen, edge)
+	case enums.OpcodeWaitForSignal:
+		return e.handleGeneratorWaitForSignal(ctx, i, gen, edge)
 	case enums.OpcodeInvokeFunction:
 		return e.handleGeneratorInvokeFunction(ctx, i, gen, edge)
 	case enums.OpcodeAIGateway:
@@ -1959,7 +2176,7 @@ func (e *executor) handleGeneratorStep(ctx context.Context, i *runInstance, gen
 		GroupID:               groupID,
 		Kind:                  queue.KindEdge,
 		Identifier:            i.item.Identifier, // TODO: Refactor
-		PriorityFactor:        i.item.PriorityFactor,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
 		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
 		Attempt:               0,
 		MaxAttempts:           i.item.MaxAttempts,
@@ -1985,6 +2202,7 @@ func (e *executor) handleGeneratorStep(ctx context.Context, i *runInstance, gen
 	// step output to any realtime subscribers.
 	if e.rtpub != nil {
 		e.rtpub.Publish(ctx, realtime.Message{
+			ID:         ulid.Make(),
 			Kind:       realtime.MessageKindStep,
 			Data:       gen.Data,
 			TopicNames: []string{gen.UserDefinedName()},
@@ -2030,18 +2248,45 @@ func (e *executor) handleStepError(ctx context.Context, i *runInstance, gen stat
 		// This is a NonRetryableError thrown in a step.
 		retryable = false
 	}
-	if !queue.ShouldRetry(nil, i.item.Attempt, i.item.GetMaxAttempts()) {
+
+	// Steps planned with a policy carry it in the queue item.  Steps executed
+	// immediately may declare a policy in the opcode itself.
+	policy := i.item.StepPolicy
+	planned := policy != nil
+	if policy == nil {
+		policy = stepPolicy(ctx, gen)
+	}
+	maxAttempts := i.item.GetMaxAttempts()
+	if policy != nil && policy.MaxAttempts != nil {
+		maxAttempts = *policy.MaxAttempts
+	}
+
+	if !queue.ShouldRetry(nil, i.item.Attempt, maxAttempts) {
 		// This is the last attempt as per the attempt in the queue, which
 		// means we've failed N times, and so it is not retryable.
 		retryable = false
 	}
 
+	if retryable && policy != nil && !planned {
+		// The current queue item retries using the function's policy, so schedule
+		// the step's retry as its own item carrying the step policy.
+		return e.handleStepPolicyRetry(ctx, i, gen, edge, *policy)
+	}
+
 	if retryable {
+		var at *time.Time
+		if policy != nil {
+			at = policy.NextRetryAt(i.item.Attempt)
+		}
+
 		// Return an error to trigger standard queue retries.
 		for _, l := range e.lifecycles {
 			i.item.Attempt += 1
 			go l.OnStepScheduled(ctx, i.md, i.item, &gen.Name)
 		}
+		if at != nil {
+			return queue.RetryAtError(ErrHandledStepError, at)
+		}
 		return ErrHandledStepError
 	}
 
@@ -2074,7 +2319,7 @@ func (e *executor) handleStepError(ctx context.Context, i *runInstance, gen stat
 		GroupID:               groupID,
 		Kind:                  queue.KindEdgeError,
 		Identifier:            i.item.Identifier,
-		PriorityFactor:        i.item.PriorityFactor,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
 		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
 		Attempt:               0,
 		MaxAttempts:           i.item.MaxAttempts,
@@ -2092,6 +2337,73 @@ func (e *executor) handleStepError(ctx context.Context, i *runInstance, gen stat
 	return nil
 }
 
+// handleStepPolicyRetry schedules the next attempt of a step which declared its own retry
+// policy and failed during immediate execution.  The retry runs as a planned step so that
+// the step's policy is enforced on all further attempts.
+func (e *executor) handleStepPolicyRetry(ctx context.Context, i *runInstance, gen state.GeneratorOpcode, edge queue.PayloadEdge, policy state.StepPolicy) error {
+	nextEdge := inngest.Edge{
+		IncomingGeneratorStep:     gen.ID,
+		IncomingGeneratorStepName: gen.UserDefinedName(),
+		Outgoing:                  edge.Edge.Outgoing,
+		Incoming:                  edge.Edge.Incoming,
+	}
+
+	at := time.Now()
+	if next := policy.NextRetryAt(i.item.Attempt); next != nil {
+		at = *next
+	} else {
+		at = backoff.DefaultBackoff(i.item.Attempt)
+	}
+
+	groupID := uuid.New().String()
+	ctx = state.WithGroupID(ctx, groupID)
+
+	jobID := fmt.Sprintf("%s-%s", i.item.Identifier.IdempotencyKey(), gen.ID+"-retry")
+	nextItem := queue.Item{
+		JobID:                 &jobID,
+		GroupID:               groupID,
+		WorkspaceID:           i.md.ID.Tenant.EnvID,
+		Kind:                  queue.KindEdge,
+		Identifier:            i.item.Identifier,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
+		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
+		Attempt:               i.item.Attempt + 1,
+		MaxAttempts:           i.item.MaxAttempts,
+		StepPolicy:            &policy,
+		Payload: queue.PayloadEdge{
+			Edge: nextEdge,
+		},
+	}
+	if policy.MaxAttempts != nil {
+		nextItem.MaxAttempts = policy.MaxAttempts
+	}
+	err := e.queue.Enqueue(ctx, nextItem, at, queue.EnqueueOpts{})
+	if err == redis_state.ErrQueueItemExists {
+		return nil
+	}
+
+	for _, l := range e.lifecycles {
+		go l.OnStepScheduled(ctx, i.md, nextItem, &gen.Name)
+	}
+	return err
+}
+
+// stepPolicy returns the policy declared by the SDK for the given step, ignoring
+// invalid policies so that the step runs using the function's defaults.
+func stepPolicy(ctx context.Context, gen state.GeneratorOpcode) *state.StepPolicy {
+	switch gen.Op {
+	case enums.OpcodeStepPlanned, enums.OpcodeStepRun, enums.OpcodeStep, enums.OpcodeStepError:
+	default:
+		return nil
+	}
+	policy, err := gen.StepPolicy()
+	if err != nil {
+		logger.StdlibLogger(ctx).Warn("ignoring invalid step policy", "error", err, "step_id", gen.ID)
+		return nil
+	}
+	return policy
+}
+
 func (e *executor) handleGeneratorStepPlanned(ctx context.Context, i *runInstance, gen state.GeneratorOpcode, edge queue.PayloadEdge) error {
 	nextEdge := inngest.Edge{
 		// Planned generator IDs are the same as the actual OpcodeStep IDs.
@@ -2124,7 +2436,7 @@ func (e *executor) handleGeneratorStepPlanned(ctx context.Context, i *runInstanc
 		WorkspaceID:           i.md.ID.Tenant.EnvID,
 		Kind:                  queue.KindEdge,
 		Identifier:            i.item.Identifier,
-		PriorityFactor:        i.item.PriorityFactor,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
 		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
 		Attempt:               0,
 		MaxAttempts:           i.item.MaxAttempts,
@@ -2132,6 +2444,12 @@ func (e *executor) handleGeneratorStepPlanned(ctx context.Context, i *runInstanc
 			Edge: nextEdge,
 		},
 	}
+	if policy := stepPolicy(ctx, gen); policy != nil {
+		nextItem.StepPolicy = policy
+		if policy.MaxAttempts != nil {
+			nextItem.MaxAttempts = policy.MaxAttempts
+		}
+	}
 	err := e.queue.Enqueue(ctx, nextItem, now, queue.EnqueueOpts{})
 	if err == redis_state.ErrQueueItemExists {
 		return nil
@@ -2175,7 +2493,7 @@ func (e *executor) handleGeneratorSleep(ctx context.Context, i *runInstance, gen
 		GroupID:               groupID,
 		Kind:                  queue.KindSleep,
 		Identifier:            i.item.Identifier,
-		PriorityFactor:        i.item.PriorityFactor,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
 		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
 		Attempt:               0,
 		MaxAttempts:           i.item.MaxAttempts,
@@ -2202,18 +2520,57 @@ func (e *executor) handleGeneratorAIGateway(ctx context.Context, i *runInstance,
 	// then generate an aigateway.ParsedInferenceRequest to store in the history store.
 	// This happens automatically within trace_lifecycle.go.
 
-	req, err := input.HTTPRequest()
-	if err != nil {
-		return fmt.Errorf("error creating ai gateway request: %w", err)
+	var (
+		hr     *http.Response
+		output []byte
+		cached bool
+		// noRetry is set when the request can never succeed by retrying, eg. when a
+		// token budget is exceeded.
+		noRetry bool
+	)
+
+	budgets := e.aiTokenBudgets(ctx, i)
+	cacheKey, cacheTTL := e.aiCacheKey(ctx, i, input)
+	if cacheKey != "" {
+		output, cached = e.cachedAIResponse(ctx, cacheKey)
 	}
 
-	hr, output, _, err := httpdriver.ExecuteRequest(ctx, httpdriver.DefaultClient, req)
-	failure := err != nil || (hr != nil && hr.StatusCode > 299)
+	if cached {
+		// Cached responses don't call the provider, so use no tokens.
+		hr = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{aigateway.HeaderCache: []string{aigateway.CacheHit}},
+		}
+	} else if err = e.checkAITokenBudgets(ctx, budgets); err != nil {
+		noRetry = true
+		hr = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	} else {
+		req, rerr := input.HTTPRequest()
+		if rerr != nil {
+			return fmt.Errorf("error creating ai gateway request: %w", rerr)
+		}
+		hr, output, _, err = httpdriver.ExecuteRequest(ctx, httpdriver.DefaultClient, req)
+		if hr == nil {
+			hr = &http.Response{Header: http.Header{}}
+		}
+	}
+	failure := err != nil || hr.StatusCode > 299
 
 	// Update the driver response appropriately for the trace lifecycles.
 	i.resp.StatusCode = hr.StatusCode
 	hr.ContentLength = int64(len(output))
 
+	if !failure && !cached {
+		if usage, err := aigateway.ParseUsage(input.Format, output); err == nil {
+			e.recordAIUsage(ctx, budgets, input.Format, usage)
+		}
+		if cacheKey != "" {
+			if err := e.aiCache.Set(ctx, cacheKey, output, cacheTTL); err != nil {
+				logger.StdlibLogger(ctx).Error("error caching ai response", "error", err)
+			}
+		}
+	}
+
 	// Handle errors individually, here.
 	if failure {
 		if len(output) == 0 {
@@ -2232,12 +2589,20 @@ func (e *executor) handleGeneratorAIGateway(ctx context.Context, i *runInstance,
 			Data:    output, // For golang's multiple returns.
 			Stack:   string(output),
 		}
+		if errors.Is(err, aigateway.ErrBudgetExceeded) {
+			userLandErr = state.UserError{
+				Name:    "AIGatewayBudgetExceededError",
+				Message: err.Error(),
+				Data:    output,
+				NoRetry: true,
+			}
+		}
 		i.resp.UpdateOpcodeError(&gen, userLandErr)
 
 		// And, finally, if this is retryable return an error which will be retried.
 		// Otherwise, we enqueue the next step directly so that the SDK can throw
 		// an error on output.
-		if queue.ShouldRetry(nil, i.item.Attempt, i.item.GetMaxAttempts()) {
+		if !noRetry && queue.ShouldRetry(nil, i.item.Attempt, i.item.GetMaxAttempts()) {
 			// Set the response error, ensuring the response is retryable in the queue.
 			i.resp.SetError(err)
 
@@ -2317,7 +2682,7 @@ func (e *executor) handleGeneratorAIGateway(ctx context.Context, i *runInstance,
 		GroupID:               groupID,
 		Kind:                  queue.KindEdge,
 		Identifier:            i.item.Identifier, // TODO: Refactor
-		PriorityFactor:        i.item.PriorityFactor,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
 		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
 		Attempt:               0,
 		MaxAttempts:           i.item.MaxAttempts,
@@ -2426,7 +2791,7 @@ func (e *executor) handleGeneratorInvokeFunction(ctx context.Context, i *runInst
 		GroupID:               i.item.GroupID,
 		Kind:                  queue.KindPause,
 		Identifier:            i.item.Identifier,
-		PriorityFactor:        i.item.PriorityFactor,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
 		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
 		MaxAttempts:           i.item.MaxAttempts,
 		Payload: queue.PayloadPauseTimeout{
@@ -2566,7 +2931,7 @@ func (e *executor) handleGeneratorWaitForEvent(ctx context.Context, i *runInstan
 		GroupID:               i.item.GroupID,
 		Kind:                  queue.KindPause,
 		Identifier:            i.item.Identifier,
-		PriorityFactor:        i.item.PriorityFactor,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
 		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
 		Payload: queue.PayloadPauseTimeout{
 			PauseID:   pauseID,
@@ -2584,6 +2949,183 @@ func (e *executor) handleGeneratorWaitForEvent(ctx context.Context, i *runInstan
 	return err
 }
 
+func (e *executor) handleGeneratorWaitForSignal(ctx context.Context, i *runInstance, gen state.GeneratorOpcode, edge queue.PayloadEdge) error {
+	opts, err := gen.WaitForSignalOpts()
+	if err != nil {
+		// Retrying won't fix invalid options, so fail the step immediately.
+		return e.handleWaitForSignalError(ctx, i, gen, edge, state.UserError{
+			Name:    "InvalidWaitForSignal",
+			Message: err.Error(),
+			NoRetry: true,
+		})
+	}
+
+	expires, err := opts.Expires()
+	if err != nil {
+		return e.handleWaitForSignalError(ctx, i, gen, edge, state.UserError{
+			Name:    "InvalidWaitForSignal",
+			Message: fmt.Sprintf("Invalid wait for signal timeout: %s", err),
+			NoRetry: true,
+		})
+	}
+
+	pauseID := inngest.DeterministicSha1UUID(i.md.ID.RunID.String() + gen.ID)
+	opcode := gen.Op.String()
+	now := time.Now()
+
+	sid := run.NewSpanID(ctx)
+	carrier := itrace.NewTraceCarrier(
+		itrace.WithTraceCarrierTimestamp(now),
+		itrace.WithTraceCarrierSpanID(&sid),
+	)
+	itrace.UserTracer().Propagator().Inject(ctx, propagation.MapCarrier(carrier.Context))
+
+	pause := state.Pause{
+		ID:          pauseID,
+		WorkspaceID: i.md.ID.Tenant.EnvID,
+		Identifier:  i.item.Identifier,
+		GroupID:     i.item.GroupID,
+		Outgoing:    gen.ID,
+		Incoming:    edge.Edge.Incoming,
+		StepName:    gen.UserDefinedName(),
+		Opcode:      &opcode,
+		Expires:     state.Time(expires),
+		SignalID:    &opts.Signal,
+		DataKey:     gen.ID,
+		MaxAttempts: i.item.MaxAttempts,
+		Metadata: map[string]any{
+			consts.OtelPropagationKey: carrier,
+		},
+	}
+	err = e.pm.SavePause(ctx, pause)
+	if err != nil {
+		if err == state.ErrPauseAlreadyExists {
+			return nil
+		}
+		if err == state.ErrSignalConflict {
+			// Signals are unique per environment.  Fail the step so that
+			// the SDK can surface the conflict.
+			return e.handleWaitForSignalError(ctx, i, gen, edge, state.UserError{
+				Name:    "SignalConflict",
+				Message: fmt.Sprintf("The signal %q is already being waited on by another run", opts.Signal),
+				NoRetry: true,
+			})
+		}
+
+		return err
+	}
+
+	// As with waitForEvent, enqueue a timeout job.  Both the API and the
+	// timeout consume the pause, so only one of them will resume the run.
+	jobID := fmt.Sprintf("%s-%s", i.md.IdempotencyKey(), gen.ID)
+	err = e.queue.Enqueue(ctx, queue.Item{
+		JobID:                 &jobID,
+		WorkspaceID:           i.md.ID.Tenant.EnvID,
+		GroupID:               i.item.GroupID,
+		Kind:                  queue.KindPause,
+		Identifier:            i.item.Identifier,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
+		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
+		Payload: queue.PayloadPauseTimeout{
+			PauseID:   pauseID,
+			OnTimeout: true,
+		},
+	}, expires, queue.EnqueueOpts{})
+	if err == redis_state.ErrQueueItemExists {
+		return nil
+	}
+
+	for _, e := range e.lifecycles {
+		go e.OnWaitForSignal(context.WithoutCancel(ctx), i.md, i.item, gen, pause)
+	}
+
+	return err
+}
+
+// ResumeSignal resumes the run waiting on the given signal within a workspace,
+// storing data as the output of the signal step.
+// handleWaitForSignalError records a wait for signal which can never succeed
+// as the step's error, then enqueues the next discovery step so that the SDK
+// throws the error, in the same way as non-retryable AI gateway errors.
+func (e *executor) handleWaitForSignalError(ctx context.Context, i *runInstance, gen state.GeneratorOpcode, edge queue.PayloadEdge, userErr state.UserError) error {
+	if i.resp != nil {
+		i.resp.UpdateOpcodeError(&gen, userErr)
+	}
+
+	// Step errors are wrapped with "error" so that SDKs throw them.
+	byt, err := json.Marshal(userErr)
+	if err != nil {
+		return err
+	}
+	output, err := json.Marshal(map[string]json.RawMessage{"error": byt})
+	if err != nil {
+		return err
+	}
+	if err := e.smv2.SaveStep(ctx, i.md.ID, gen.ID, output); err != nil {
+		return err
+	}
+
+	groupID := uuid.New().String()
+	ctx = state.WithGroupID(ctx, groupID)
+
+	jobID := fmt.Sprintf("%s-%s", i.md.IdempotencyKey(), gen.ID)
+	nextItem := queue.Item{
+		JobID:                 &jobID,
+		WorkspaceID:           i.md.ID.Tenant.EnvID,
+		GroupID:               groupID,
+		Kind:                  queue.KindEdge,
+		Identifier:            i.item.Identifier,
+		PriorityFactor:        i.stepPriorityFactor(ctx),
+		CustomConcurrencyKeys: i.item.CustomConcurrencyKeys,
+		Attempt:               0,
+		MaxAttempts:           i.item.MaxAttempts,
+		Payload: queue.PayloadEdge{Edge: inngest.Edge{
+			Outgoing: gen.ID,
+			Incoming: edge.Edge.Incoming,
+		}},
+	}
+	err = e.queue.Enqueue(ctx, nextItem, time.Now(), queue.EnqueueOpts{})
+	if err == redis_state.ErrQueueItemExists {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, l := range e.lifecycles {
+		go l.OnStepScheduled(ctx, i.md, nextItem, nil)
+	}
+	return nil
+}
+
+func (e *executor) ResumeSignal(ctx context.Context, workspaceID uuid.UUID, signalID string, data json.RawMessage) (*execution.ResumeSignalResult, error) {
+	pause, err := e.pm.PauseBySignalID(ctx, workspaceID, signalID)
+	if err == state.ErrSignalPauseNotFound || err == state.ErrPauseNotFound {
+		return nil, state.ErrSignalPauseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pause.Expires.Time().Before(time.Now()) {
+		// The timeout job will consume this pause.
+		return nil, state.ErrSignalPauseNotFound
+	}
+
+	r := execution.ResumeRequest{
+		StepName: pause.StepName,
+	}
+	r.SetData(data)
+
+	if err := e.Resume(ctx, *pause, r); err != nil {
+		return nil, err
+	}
+
+	return &execution.ResumeSignalResult{
+		RunID: pause.Identifier.RunID,
+	}, nil
+}
+
 func (e *executor) newExpressionEvaluator(ctx context.Context, expr string) (expressions.Evaluator, error) {
 	if e.evalFactory != nil {
 		return e.evalFactory(ctx, expr)
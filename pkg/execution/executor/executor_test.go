@@ -0,0 +1,86 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/backoff"
+	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepPriorityFactor(t *testing.T) {
+	ctx := context.Background()
+	inherited := int64(10)
+	expr := "event.data.priority"
+	events := []json.RawMessage{json.RawMessage(`{"name":"test/event","data":{"priority":120}}`)}
+
+	t.Run("re-evaluates the run priority expression", func(t *testing.T) {
+		i := &runInstance{
+			f:      inngest.Function{Priority: &inngest.Priority{Run: &expr}},
+			events: events,
+			item:   queue.Item{PriorityFactor: &inherited},
+		}
+		factor := i.stepPriorityFactor(ctx)
+		require.NotNil(t, factor)
+		require.EqualValues(t, 120, *factor)
+	})
+
+	t.Run("inherits the job factor without a priority expression", func(t *testing.T) {
+		i := &runInstance{
+			events: events,
+			item:   queue.Item{PriorityFactor: &inherited},
+		}
+		require.Equal(t, &inherited, i.stepPriorityFactor(ctx))
+	})
+
+	t.Run("inherits the job factor if the expression fails", func(t *testing.T) {
+		invalid := "event.data.priority +"
+		i := &runInstance{
+			f:      inngest.Function{Priority: &inngest.Priority{Run: &invalid}},
+			events: events,
+			item:   queue.Item{PriorityFactor: &inherited},
+		}
+		require.Equal(t, &inherited, i.stepPriorityFactor(ctx))
+	})
+}
+
+func TestWithStepRetryAt(t *testing.T) {
+	err := fmt.Errorf("step failed")
+
+	t.Run("items without a policy use the default backoff", func(t *testing.T) {
+		require.Equal(t, err, withStepRetryAt(queue.Item{}, err))
+	})
+
+	t.Run("items with a backoff policy retry using the policy", func(t *testing.T) {
+		item := queue.Item{
+			Attempt: 2,
+			StepPolicy: &state.StepPolicy{
+				Backoff: &state.StepBackoff{Kind: backoff.KindFixed, Interval: "1h"},
+			},
+		}
+		wrapped := withStepRetryAt(item, err)
+		require.ErrorIs(t, wrapped, err)
+
+		var specifier queue.RetryAtSpecifier
+		require.True(t, errors.As(wrapped, &specifier))
+		require.WithinDuration(t, time.Now().Add(time.Hour), *specifier.NextRetryAt(), time.Second)
+	})
+
+	t.Run("errors specifying a retry time are unchanged", func(t *testing.T) {
+		at := time.Now().Add(time.Minute)
+		item := queue.Item{
+			StepPolicy: &state.StepPolicy{
+				Backoff: &state.StepBackoff{Kind: backoff.KindFixed, Interval: "1h"},
+			},
+		}
+		retryAt := queue.RetryAtError(err, &at)
+		require.Equal(t, retryAt, withStepRetryAt(item, retryAt))
+	})
+}
@@ -12,6 +12,82 @@ import (
 	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
 )
 
+// reconstructFromState copies memoized step state from the original run's state
+// store entry into the new run.  This is only possible whilst the original run's
+// state exists, eg. for runs that are still in progress.  If the original run's
+// state no longer exists, or its stack doesn't include the step to run from, this
+// returns false and state must be reconstructed from traces instead.
+func reconstructFromState(ctx context.Context, sl sv2.StateLoader, req execution.ScheduleRequest, newState *sv2.CreateState) (bool, error) {
+	orig, err := sl.LoadState(ctx, sv2.ID{
+		RunID:      *req.OriginalRunID,
+		FunctionID: req.Function.ID,
+		Tenant: sv2.Tenant{
+			AppID:     req.AppID,
+			EnvID:     req.WorkspaceID,
+			AccountID: req.AccountID,
+		},
+	})
+	if err == state.ErrRunNotFound || err == sv2.ErrMetadataNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error loading original run state: %w", err)
+	}
+
+	steps := []state.MemoizedStep{}
+	found := false
+	for _, stepID := range orig.Metadata.Stack {
+		if stepID == req.FromStep.StepID {
+			found = true
+			break
+		}
+
+		output, ok := orig.Steps[stepID]
+		if !ok {
+			return false, fmt.Errorf("step found in stack but output not found in original run")
+		}
+		steps = append(steps, state.MemoizedStep{
+			ID:   stepID,
+			Data: output,
+		})
+	}
+
+	if !found {
+		// The step may not have been reached yet, or its state may have been
+		// discarded;  traces include every step that ran.
+		return false, nil
+	}
+
+	newState.Steps = steps
+	return true, nil
+}
+
+// applyStepOverrides replaces the memoized output of any copied steps with the
+// overrides given in the schedule request.
+func applyStepOverrides(req execution.ScheduleRequest, newState *sv2.CreateState) error {
+	if req.FromStep == nil || len(req.FromStep.StepOverrides) == 0 {
+		return nil
+	}
+
+	applied := 0
+	for n, step := range newState.Steps {
+		override, ok := req.FromStep.StepOverrides[step.ID]
+		if !ok {
+			continue
+		}
+		if !json.Valid(override) {
+			return fmt.Errorf("override for step %s is not valid JSON", step.ID)
+		}
+		newState.Steps[n].Data = map[string]any{"data": override}
+		applied++
+	}
+
+	if applied != len(req.FromStep.StepOverrides) {
+		return fmt.Errorf("step overrides must only reference steps that ran before the step to run from")
+	}
+	return nil
+}
+
 func reconstruct(ctx context.Context, tr cqrs.TraceReader, req execution.ScheduleRequest, newState *sv2.CreateState) error {
 
 	// Load the original run state and copy the state from the original
@@ -123,7 +199,11 @@ func reconstruct(ctx context.Context, tr cqrs.TraceReader, req execution.Schedul
 	}
 
 	newState.Steps = steps
+	return nil
+}
 
+// setFromStepInput stores the input for the step to run from, if provided.
+func setFromStepInput(req execution.ScheduleRequest, newState *sv2.CreateState) {
 	if req.FromStep != nil && req.FromStep.Input != nil {
 		newState.StepInputs = []state.MemoizedStep{
 			{
@@ -132,6 +212,4 @@ func reconstruct(ctx context.Context, tr cqrs.TraceReader, req execution.Schedul
 			},
 		}
 	}
-
-	return nil
 }
@@ -0,0 +1,120 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyStepOverrides(t *testing.T) {
+	newState := func() *sv2.CreateState {
+		return &sv2.CreateState{
+			Steps: []state.MemoizedStep{
+				{ID: "a", Data: map[string]any{"data": 1}},
+				{ID: "b", Data: map[string]any{"data": 2}},
+			},
+		}
+	}
+
+	t.Run("overrides copied steps", func(t *testing.T) {
+		s := newState()
+		err := applyStepOverrides(execution.ScheduleRequest{
+			FromStep: &execution.ScheduleRequestFromStep{
+				StepID:        "c",
+				StepOverrides: map[string]json.RawMessage{"b": json.RawMessage(`{"ok":true}`)},
+			},
+		}, s)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"data": 1}, s.Steps[0].Data)
+		require.Equal(t, map[string]any{"data": json.RawMessage(`{"ok":true}`)}, s.Steps[1].Data)
+	})
+
+	t.Run("errors on steps that were not copied", func(t *testing.T) {
+		err := applyStepOverrides(execution.ScheduleRequest{
+			FromStep: &execution.ScheduleRequestFromStep{
+				StepID:        "c",
+				StepOverrides: map[string]json.RawMessage{"d": json.RawMessage(`1`)},
+			},
+		}, newState())
+		require.Error(t, err)
+	})
+
+	t.Run("errors on invalid JSON", func(t *testing.T) {
+		err := applyStepOverrides(execution.ScheduleRequest{
+			FromStep: &execution.ScheduleRequestFromStep{
+				StepID:        "c",
+				StepOverrides: map[string]json.RawMessage{"a": json.RawMessage(`{`)},
+			},
+		}, newState())
+		require.Error(t, err)
+	})
+}
+
+// stateLoader returns the given state for any run.
+type stateLoader struct {
+	sv2.StateLoader
+	state sv2.State
+	err   error
+}
+
+func (s stateLoader) LoadState(ctx context.Context, id sv2.ID) (sv2.State, error) {
+	return s.state, s.err
+}
+
+func TestReconstructFromState(t *testing.T) {
+	ctx := context.Background()
+	runID := ulid.Make()
+	req := func(stepID string) execution.ScheduleRequest {
+		return execution.ScheduleRequest{
+			OriginalRunID: &runID,
+			FromStep:      &execution.ScheduleRequestFromStep{StepID: stepID},
+		}
+	}
+	sl := stateLoader{
+		state: sv2.State{
+			Metadata: sv2.Metadata{Stack: []string{"a", "b", "c"}},
+			Steps: map[string]json.RawMessage{
+				"a": json.RawMessage(`{"data":1}`),
+				"b": json.RawMessage(`{"data":2}`),
+				"c": json.RawMessage(`{"data":3}`),
+			},
+		},
+	}
+
+	t.Run("copies steps prior to the step to run from", func(t *testing.T) {
+		s := &sv2.CreateState{}
+		copied, err := reconstructFromState(ctx, sl, req("c"), s)
+		require.NoError(t, err)
+		require.True(t, copied)
+		require.Equal(t, []state.MemoizedStep{
+			{ID: "a", Data: json.RawMessage(`{"data":1}`)},
+			{ID: "b", Data: json.RawMessage(`{"data":2}`)},
+		}, s.Steps)
+	})
+
+	t.Run("falls back to traces when the step isn't in the stack", func(t *testing.T) {
+		s := &sv2.CreateState{}
+		copied, err := reconstructFromState(ctx, sl, req("d"), s)
+		require.NoError(t, err)
+		require.False(t, copied)
+		require.Empty(t, s.Steps)
+	})
+
+	t.Run("falls back to traces when the run's state is gone", func(t *testing.T) {
+		copied, err := reconstructFromState(ctx, stateLoader{err: state.ErrRunNotFound}, req("c"), &sv2.CreateState{})
+		require.NoError(t, err)
+		require.False(t, copied)
+	})
+
+	t.Run("errors loading state", func(t *testing.T) {
+		_, err := reconstructFromState(ctx, stateLoader{err: errors.New("unavailable")}, req("c"), &sv2.CreateState{})
+		require.Error(t, err)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/khulnasoft/inngest/pkg/event"
 	"github.com/khulnasoft/inngest/pkg/execution"
 	"github.com/khulnasoft/inngest/pkg/execution/batch"
+	"github.com/khulnasoft/inngest/pkg/execution/cron"
 	"github.com/khulnasoft/inngest/pkg/execution/debounce"
 	"github.com/khulnasoft/inngest/pkg/execution/queue"
 	"github.com/khulnasoft/inngest/pkg/execution/state"
@@ -72,6 +73,14 @@ func WithServiceBatcher(b batch.BatchManager) func(s *svc) {
 	}
 }
 
+// WithServiceCronHandler sets the handler which fires cron ticks leased from
+// the queue.
+func WithServiceCronHandler(h cron.Handler) func(s *svc) {
+	return func(s *svc) {
+		s.cron = h
+	}
+}
+
 func NewService(c config.Config, opts ...Opt) service.Service {
 	svc := &svc{config: c}
 	for _, o := range opts {
@@ -92,6 +101,8 @@ type svc struct {
 	exec      execution.Executor
 	debouncer debounce.Debouncer
 	batcher   batch.BatchManager
+	// cron fires cron ticks enqueued by the runner.
+	cron cron.Handler
 
 	wg sync.WaitGroup
 
@@ -185,6 +196,8 @@ func (s *svc) Run(ctx context.Context) error {
 			err = s.handleDebounce(ctx, item)
 		case queue.KindScheduleBatch:
 			err = s.handleScheduledBatch(ctx, item)
+		case queue.KindCron:
+			err = s.handleCron(ctx, item)
 		case queue.KindQueueMigrate:
 			// NOOP:
 			// this kind don't work in the Dev server
@@ -241,16 +254,32 @@ func (s *svc) handleQueueItem(ctx context.Context, item queue.Item) error {
 		// If the error is not of type response error, we assume the step is
 		// always retryable.
 		if resp == nil || err != nil {
-			return err
+			return withStepRetryAt(item, err)
 		}
 
 		// Always retry; non-retryable is covered above.
-		return fmt.Errorf("%s", resp.Error())
+		return withStepRetryAt(item, fmt.Errorf("%s", resp.Error()))
 	}
 
 	return nil
 }
 
+// withStepRetryAt schedules the retry of a failed step using the step's backoff policy,
+// if declared.  Errors which already specify when to retry are returned as-is.
+func withStepRetryAt(item queue.Item, err error) error {
+	if item.StepPolicy == nil {
+		return err
+	}
+	var specifier queue.RetryAtSpecifier
+	if errors.As(err, &specifier) {
+		return err
+	}
+	if at := item.StepPolicy.NextRetryAt(item.Attempt); at != nil {
+		return queue.RetryAtError(err, at)
+	}
+	return err
+}
+
 func (s *svc) handlePauseTimeout(ctx context.Context, item queue.Item) error {
 	l := logger.From(ctx).With().Str("run_id", item.Identifier.RunID.String()).Logger()
 
@@ -328,6 +357,13 @@ func (s *svc) handleScheduledBatch(ctx context.Context, item queue.Item) error {
 	return nil
 }
 
+func (s *svc) handleCron(ctx context.Context, item queue.Item) error {
+	if s.cron == nil {
+		return fmt.Errorf("no cron handler configured")
+	}
+	return s.cron(ctx, item)
+}
+
 func (s *svc) handleDebounce(ctx context.Context, item queue.Item) error {
 	d := debounce.DebouncePayload{}
 	if err := json.Unmarshal(item.Payload.(json.RawMessage), &d); err != nil {
@@ -0,0 +1,283 @@
+package executor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs/base_cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/execution/state/sql_state"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeSignal(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	smv2 := sql_state.MustStateServiceV2(sql_state.New(db, "sqlite"))
+
+	md := sv2.Metadata{
+		ID: sv2.ID{
+			RunID:      ulid.MustNew(ulid.Now(), rand.Reader),
+			FunctionID: uuid.New(),
+			Tenant:     sv2.Tenant{AppID: uuid.New(), EnvID: uuid.New(), AccountID: uuid.New()},
+		},
+		Config: *sv2.InitConfig(&sv2.Config{}),
+	}
+	require.NoError(t, smv2.Create(ctx, sv2.CreateState{
+		Metadata: md,
+		Events:   []json.RawMessage{json.RawMessage(`{"name":"test/event"}`)},
+	}))
+
+	pm := &fakePauseManager{pauses: map[string]*state.Pause{}}
+	q := &fakeQueue{}
+	e := &executor{smv2: smv2, pm: pm, queue: q}
+
+	addPause := func(signal string, expires time.Time) *state.Pause {
+		p := &state.Pause{
+			ID:          uuid.New(),
+			WorkspaceID: md.ID.Tenant.EnvID,
+			Identifier: state.Identifier{
+				RunID:       md.ID.RunID,
+				WorkflowID:  md.ID.FunctionID,
+				AccountID:   md.ID.Tenant.AccountID,
+				WorkspaceID: md.ID.Tenant.EnvID,
+				AppID:       md.ID.Tenant.AppID,
+			},
+			Outgoing: "wait",
+			Incoming: "step",
+			StepName: "wait",
+			Expires:  state.Time(expires),
+			SignalID: &signal,
+			DataKey:  "wait",
+		}
+		pm.pauses[signal] = p
+		return p
+	}
+
+	t.Run("resumes the run waiting for the signal", func(t *testing.T) {
+		p := addPause("signal-1", time.Now().Add(time.Hour))
+
+		res, err := e.ResumeSignal(ctx, md.ID.Tenant.EnvID, "signal-1", json.RawMessage(`{"ok":true}`))
+		require.NoError(t, err)
+		require.Equal(t, md.ID.RunID, res.RunID)
+
+		require.Contains(t, pm.consumed, p.ID)
+		require.Len(t, q.items, 1)
+		require.Equal(t, queue.KindEdge, q.items[0].Kind)
+		require.Equal(t, p.Edge(), q.items[0].Payload.(queue.PayloadEdge).Edge)
+	})
+
+	t.Run("signals already resumed are not found", func(t *testing.T) {
+		_, err := e.ResumeSignal(ctx, md.ID.Tenant.EnvID, "signal-1", nil)
+		require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+		require.Len(t, q.items, 1)
+	})
+
+	t.Run("unknown signals are not found", func(t *testing.T) {
+		_, err := e.ResumeSignal(ctx, md.ID.Tenant.EnvID, "unknown", nil)
+		require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+	})
+
+	t.Run("signals in other workspaces are not found", func(t *testing.T) {
+		addPause("signal-2", time.Now().Add(time.Hour))
+		_, err := e.ResumeSignal(ctx, uuid.New(), "signal-2", nil)
+		require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+	})
+
+	t.Run("expired signals are not found", func(t *testing.T) {
+		p := addPause("signal-3", time.Now().Add(-time.Second))
+		_, err := e.ResumeSignal(ctx, md.ID.Tenant.EnvID, "signal-3", nil)
+		require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+		require.NotContains(t, pm.consumed, p.ID)
+	})
+
+	t.Run("signals leased by another resume are not found", func(t *testing.T) {
+		p := addPause("signal-4", time.Now().Add(time.Hour))
+		pm.leased = map[uuid.UUID]bool{p.ID: true}
+		_, err := e.ResumeSignal(ctx, md.ID.Tenant.EnvID, "signal-4", nil)
+		require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+		require.NotContains(t, pm.consumed, p.ID)
+	})
+}
+
+func TestHandleGeneratorWaitForSignal(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	smv2 := sql_state.MustStateServiceV2(sql_state.New(db, "sqlite"))
+
+	pm := &fakePauseManager{pauses: map[string]*state.Pause{}}
+	q := &fakeQueue{}
+	e := &executor{smv2: smv2, pm: pm, queue: q}
+
+	// Another run is already waiting on the "taken" signal.
+	taken := "taken"
+	pm.pauses[taken] = &state.Pause{ID: uuid.New(), SignalID: &taken}
+
+	tests := []struct {
+		name string
+		opts map[string]any
+		// error is the expected step error's name, or empty if the run waits
+		// for the signal.
+		error   string
+		message string
+	}{
+		{
+			name: "waits for the signal",
+			opts: map[string]any{"signal": "signal", "timeout": "1h"},
+		},
+		{
+			name:    "missing timeout",
+			opts:    map[string]any{"signal": "signal"},
+			error:   "InvalidWaitForSignal",
+			message: "A timeout must be provided",
+		},
+		{
+			name:    "empty timeout",
+			opts:    map[string]any{"signal": "signal", "timeout": ""},
+			error:   "InvalidWaitForSignal",
+			message: "A timeout must be provided",
+		},
+		{
+			name:    "invalid timeout",
+			opts:    map[string]any{"signal": "signal", "timeout": "soon"},
+			error:   "InvalidWaitForSignal",
+			message: "Invalid wait for signal timeout",
+		},
+		{
+			name:    "missing signal",
+			opts:    map[string]any{"timeout": "1h"},
+			error:   "InvalidWaitForSignal",
+			message: "A signal name must be provided",
+		},
+		{
+			name:    "signal already in use",
+			opts:    map[string]any{"signal": taken, "timeout": "1h"},
+			error:   "SignalConflict",
+			message: `The signal "taken" is already being waited on by another run`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			md := sv2.Metadata{
+				ID: sv2.ID{
+					RunID:      ulid.MustNew(ulid.Now(), rand.Reader),
+					FunctionID: uuid.New(),
+					Tenant:     sv2.Tenant{AppID: uuid.New(), EnvID: uuid.New(), AccountID: uuid.New()},
+				},
+				Config: *sv2.InitConfig(&sv2.Config{}),
+			}
+			require.NoError(t, smv2.Create(ctx, sv2.CreateState{
+				Metadata: md,
+				Events:   []json.RawMessage{json.RawMessage(`{"name":"test/event"}`)},
+			}))
+
+			gen := state.GeneratorOpcode{
+				ID:   "wait",
+				Op:   enums.OpcodeWaitForSignal,
+				Opts: tc.opts,
+			}
+			i := &runInstance{
+				md:   md,
+				resp: &state.DriverResponse{Generator: []*state.GeneratorOpcode{&gen}},
+			}
+			q.items = nil
+
+			err := e.handleGeneratorWaitForSignal(ctx, i, gen, queue.PayloadEdge{Edge: inngest.Edge{Incoming: "step"}})
+			require.NoError(t, err)
+
+			steps, err := smv2.LoadSteps(ctx, md.ID)
+			require.NoError(t, err)
+
+			if tc.error == "" {
+				require.Empty(t, steps)
+				require.Contains(t, pm.pauses, "signal")
+				require.Len(t, q.items, 1)
+				require.Equal(t, queue.KindPause, q.items[0].Kind)
+				delete(pm.pauses, "signal")
+				return
+			}
+
+			// The error is recorded as the step's output and the run continues
+			// so that the SDK throws the error.
+			require.Nil(t, pm.pauses["signal"])
+			require.NotNil(t, i.resp.Generator[0].Error)
+			require.Equal(t, tc.error, i.resp.Generator[0].Error.Name)
+
+			output := struct {
+				Error state.UserError `json:"error"`
+			}{}
+			require.NoError(t, json.Unmarshal(steps["wait"], &output))
+			require.Equal(t, tc.error, output.Error.Name)
+			require.Contains(t, output.Error.Message, tc.message)
+
+			require.Len(t, q.items, 1)
+			require.Equal(t, queue.KindEdge, q.items[0].Kind)
+			require.Equal(t, inngest.Edge{Outgoing: "wait", Incoming: "step"}, q.items[0].Payload.(queue.PayloadEdge).Edge)
+		})
+	}
+}
+
+type fakePauseManager struct {
+	state.PauseManager
+
+	pauses   map[string]*state.Pause
+	leased   map[uuid.UUID]bool
+	consumed []uuid.UUID
+}
+
+func (f *fakePauseManager) SavePause(ctx context.Context, p state.Pause) error {
+	if existing, ok := f.pauses[*p.SignalID]; ok && existing.ID != p.ID {
+		return state.ErrSignalConflict
+	}
+	f.pauses[*p.SignalID] = &p
+	return nil
+}
+
+func (f *fakePauseManager) PauseBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) (*state.Pause, error) {
+	p, ok := f.pauses[signalID]
+	if !ok || p.WorkspaceID != wsID {
+		return nil, state.ErrSignalPauseNotFound
+	}
+	return p, nil
+}
+
+func (f *fakePauseManager) LeasePause(ctx context.Context, id uuid.UUID) error {
+	if f.leased[id] {
+		return state.ErrPauseLeased
+	}
+	return nil
+}
+
+func (f *fakePauseManager) ConsumePause(ctx context.Context, id uuid.UUID, data any) error {
+	f.consumed = append(f.consumed, id)
+	for signal, p := range f.pauses {
+		if p.ID == id {
+			delete(f.pauses, signal)
+		}
+	}
+	return nil
+}
+
+type fakeQueue struct {
+	queue.Queue
+
+	items []queue.Item
+}
+
+func (f *fakeQueue) Enqueue(ctx context.Context, item queue.Item, at time.Time, opts queue.EnqueueOpts) error {
+	f.items = append(f.items, item)
+	return nil
+}
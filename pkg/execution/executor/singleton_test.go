@@ -0,0 +1,105 @@
+package executor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs/base_cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/execution/singleton"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/execution/state/sql_state"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleSingletonSkip(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	smv2 := sql_state.MustStateServiceV2(sql_state.New(db, "sqlite"))
+
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	e := &executor{smv2: smv2, singleton: singleton.New(rc, "{singleton}:")}
+
+	fn := inngest.Function{ID: uuid.New(), Singleton: &inngest.Singleton{}}
+	req := execution.ScheduleRequest{
+		Function: fn,
+		Events:   []event.TrackedEvent{event.NewOSSTrackedEvent(event.Event{Name: "test/event"})},
+	}
+	key, err := singleton.SingletonKey(ctx, fn.ID, *fn.Singleton, map[string]any{})
+	require.NoError(t, err)
+
+	create := func(idempotency string) sv2.Metadata {
+		md := sv2.Metadata{
+			ID: sv2.ID{
+				RunID:      ulid.MustNew(ulid.Now(), rand.Reader),
+				FunctionID: fn.ID,
+				Tenant:     sv2.Tenant{AppID: uuid.New(), EnvID: uuid.New(), AccountID: uuid.New()},
+			},
+			Config: *sv2.InitConfig(&sv2.Config{Idempotency: idempotency}),
+		}
+		md.Config.SetSingletonKey(key)
+		err := smv2.Create(ctx, sv2.CreateState{
+			Metadata: md,
+			Events:   []json.RawMessage{json.RawMessage(`{"name":"test/event"}`)},
+		})
+		require.NoError(t, err)
+		return md
+	}
+	exists := func(md sv2.Metadata) bool {
+		ok, err := smv2.Exists(ctx, md.ID)
+		require.NoError(t, err)
+		return ok
+	}
+
+	first := create("first")
+	require.False(t, e.handleSingleton(ctx, req, first, nil))
+
+	// Another run is skipped and deleted, leaving the first run holding the key.
+	second := create("second")
+	require.True(t, e.handleSingleton(ctx, req, second, nil))
+	require.False(t, exists(second))
+	require.True(t, exists(first))
+
+	// The skipped run's idempotency key is kept, so retrying its schedule is a
+	// no-op rather than creating a run.
+	err = smv2.Create(ctx, sv2.CreateState{
+		Metadata: sv2.Metadata{ID: second.ID, Config: second.Config},
+		Events:   []json.RawMessage{json.RawMessage(`{"name":"test/event"}`)},
+	})
+	require.ErrorIs(t, err, state.ErrIdentifierExists)
+
+	// Once the first run's state is gone, new runs take over the key.
+	_, err = smv2.Delete(ctx, first.ID)
+	require.NoError(t, err)
+	third := create("third")
+	require.False(t, e.handleSingleton(ctx, req, third, nil))
+	require.True(t, exists(third))
+
+	fourth := create("fourth")
+	require.True(t, e.handleSingleton(ctx, req, fourth, nil))
+
+	t.Run("runs without a singleton key are never skipped", func(t *testing.T) {
+		md := create("no-key")
+		md.Config.SetSingletonKey("")
+		require.False(t, e.handleSingleton(ctx, req, md, nil))
+		require.True(t, exists(md))
+	})
+}
@@ -566,6 +566,100 @@ func (l lifecycle) OnWaitForEventResumed(
 	}
 }
 
+func (l lifecycle) OnWaitForSignal(
+	ctx context.Context,
+	md sv2.Metadata,
+	item queue.Item,
+	op state.GeneratorOpcode,
+	_ state.Pause,
+) {
+	groupID, err := toUUID(item.GroupID)
+	if err != nil {
+		l.log.Error(
+			"error parsing group ID",
+			"error", err,
+			"group_id", item.GroupID,
+			"run_id", md.ID.RunID.String(),
+		)
+	}
+
+	stepName := op.UserDefinedName()
+	h := History{
+		ID:              ulid.MustNew(ulid.Now(), rand.Reader),
+		AccountID:       md.ID.Tenant.AccountID,
+		WorkspaceID:     md.ID.Tenant.EnvID,
+		CreatedAt:       time.Now(),
+		FunctionID:      md.ID.FunctionID,
+		FunctionVersion: int64(md.Config.FunctionVersion),
+		GroupID:         groupID,
+		RunID:           md.ID.RunID,
+		Type:            enums.HistoryTypeStepWaiting.String(),
+		Attempt:         int64(item.Attempt),
+		IdempotencyKey:  md.IdempotencyKey(),
+		EventID:         md.Config.EventID(),
+		StepName:        &stepName,
+		StepID:          &op.ID,
+		BatchID:         md.Config.BatchID,
+	}
+	for _, d := range l.drivers {
+		if err := d.Write(context.WithoutCancel(ctx), h); err != nil {
+			l.log.Error("execution lifecycle error", "lifecycle", "onWaitForSignal", "error", err)
+		}
+	}
+}
+
+// OnWaitForSignalResumed is called when a function is resumed from waiting
+// for a signal.
+func (l lifecycle) OnWaitForSignalResumed(
+	ctx context.Context,
+	md sv2.Metadata,
+	pause state.Pause,
+	req execution.ResumeRequest,
+) {
+	var groupIDUUID *uuid.UUID
+	if pause.GroupID != "" {
+		val, err := toUUID(pause.GroupID)
+		if err != nil {
+			l.log.Error(
+				"error parsing group ID",
+				"error", err,
+				"group_id", pause.GroupID,
+				"run_id", md.ID.RunID.String(),
+			)
+		}
+		groupIDUUID = val
+	}
+
+	var stepName *string
+	if req.StepName != "" {
+		stepName = &req.StepName
+	}
+
+	h := History{
+		AccountID:       md.ID.Tenant.AccountID,
+		WorkspaceID:     md.ID.Tenant.EnvID,
+		CreatedAt:       time.Now(),
+		FunctionID:      md.ID.FunctionID,
+		FunctionVersion: int64(md.Config.FunctionVersion),
+		GroupID:         groupIDUUID,
+		ID:              ulid.MustNew(ulid.Now(), rand.Reader),
+		RunID:           md.ID.RunID,
+		Type:            enums.HistoryTypeStepCompleted.String(),
+		IdempotencyKey:  md.IdempotencyKey(),
+		EventID:         md.Config.EventID(),
+		WaitResult: &WaitResult{
+			Timeout: req.IsTimeout,
+		},
+		BatchID:  md.Config.BatchID,
+		StepName: stepName,
+	}
+	for _, d := range l.drivers {
+		if err := d.Write(context.WithoutCancel(ctx), h); err != nil {
+			l.log.Error("execution lifecycle error", "lifecycle", "onWaitForSignalResumed", "error", err)
+		}
+	}
+}
+
 // OnInvokeFunction is called when a function is invoked from a step.
 func (l lifecycle) OnInvokeFunction(
 	ctx context.Context,
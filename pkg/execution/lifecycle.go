@@ -145,6 +145,25 @@ type LifecycleListener interface {
 		ResumeRequest,
 	)
 
+	// OnWaitForSignal is called when a wait for signal step is scheduled.  The
+	// statev1.GeneratorOpcode contains the wait for signal details.
+	OnWaitForSignal(
+		context.Context,
+		statev2.Metadata,
+		queue.Item,
+		statev1.GeneratorOpcode,
+		state.Pause,
+	)
+
+	// OnWaitForSignalResumed is called when a function is resumed from waiting
+	// for a signal, either via the API or by timing out.
+	OnWaitForSignalResumed(
+		context.Context,
+		statev2.Metadata,
+		state.Pause,
+		ResumeRequest,
+	)
+
 	// OnInvokeFunction is called when a function is invoked from a step.
 	OnInvokeFunction(
 		context.Context,
@@ -306,6 +325,25 @@ func (NoopLifecyceListener) OnWaitForEventResumed(
 ) {
 }
 
+func (NoopLifecyceListener) OnWaitForSignal(
+	context.Context,
+	statev2.Metadata,
+	queue.Item,
+	statev1.GeneratorOpcode,
+	state.Pause,
+) {
+}
+
+// OnWaitForSignalResumed is called when a function is resumed from waiting
+// for a signal.
+func (NoopLifecyceListener) OnWaitForSignalResumed(
+	context.Context,
+	statev2.Metadata,
+	state.Pause,
+	ResumeRequest,
+) {
+}
+
 // OnInvokeFunction is called when a function is invoked from a step.
 func (NoopLifecyceListener) OnInvokeFunction(
 	context.Context,
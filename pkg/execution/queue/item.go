@@ -189,9 +189,13 @@ type Item struct {
 	// PriorityFactor is the overall priority factor for this particular function
 	// run.  This allows individual runs to take precedence within the same queue.
 	// The higher the number (up to consts.PriorityFactorMax), the higher priority
-	// this run has.  All next steps will use this as the factor when scheduling
-	// future edge jobs (on their first attempt).
+	// this run has.  Next steps re-evaluate the function's priority when scheduling
+	// future edge jobs (on their first attempt), falling back to this factor.
 	PriorityFactor *int64 `json:"pf,omitempty"`
+	// StepPolicy is the SDK-declared timeout and retry policy for the step run by this
+	// item.  When set, MaxAttempts is taken from the policy and retries use the policy's
+	// backoff.
+	StepPolicy *state.StepPolicy `json:"sp,omitempty"`
 }
 
 type Throttle struct {
@@ -289,6 +293,7 @@ func (i *Item) UnmarshalJSON(b []byte) error {
 		Throttle              *Throttle                 `json:"throttle"`
 		CustomConcurrencyKeys []state.CustomConcurrency `json:"cck,omitempty"`
 		PriorityFactor        *int64                    `json:"pf,omitempty"`
+		StepPolicy            *state.StepPolicy         `json:"sp,omitempty"`
 	}
 	temp := &kind{}
 	err := json.Unmarshal(b, temp)
@@ -308,6 +313,7 @@ func (i *Item) UnmarshalJSON(b []byte) error {
 	i.CustomConcurrencyKeys = temp.CustomConcurrencyKeys
 	i.PriorityFactor = temp.PriorityFactor
 	i.QueueName = temp.QueueName
+	i.StepPolicy = temp.StepPolicy
 
 	// Save this for custom unmarshalling of other jobs.  This is overwritten
 	// for known queue kinds.
@@ -11,4 +11,5 @@ const (
 	KindScheduleBatch = "schedule-batch"
 	KindEdgeError     = "edge-error" // KindEdgeError is used to indicate a final step error attempting a graceful save.
 	KindQueueMigrate  = "queue-migrate"
+	KindCron          = "cron" // KindCron fires a single tick of a function's cron schedule.
 )
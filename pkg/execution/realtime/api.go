@@ -3,16 +3,42 @@ package realtime
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/coder/websocket"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/khulnasoft/inngest/pkg/api/apiv1/apiv1auth"
 	"github.com/khulnasoft/inngest/pkg/logger"
 	"github.com/khulnasoft/inngest/pkg/publicerr"
+	"github.com/oklog/ulid/v2"
 )
 
+// MaxPublishSize is the maximum size of a publish request.
+const MaxPublishSize = 512 * 1024
+
+// TokenRequest creates a JWT with the given topics and scopes.  Requests may also
+// be a list of topics, creating a JWT which subscribes to the topics.
+type TokenRequest struct {
+	// Topics are the topics subscribed to when connecting.
+	Topics []Topic `json:"topics"`
+	// Scopes grant capabilities on channels matching patterns.
+	Scopes []Scope `json:"scopes"`
+	// ExpiresIn is the JWT's lifetime in seconds, defaulting to one minute.
+	ExpiresIn int `json:"expires_in"`
+}
+
+// PublishRequest publishes data to a channel.
+type PublishRequest struct {
+	// Channel is the channel to publish to, eg. "run:<run_id>:progress".
+	Channel string `json:"channel"`
+	// Data is the message's data.
+	Data json.RawMessage `json:"data"`
+}
+
 type APIOpts struct {
 	JWTSecret []byte
 	// Broadcaster allows connections to subscribe to topics, picking up events from
@@ -56,13 +82,22 @@ func (a *api) setup() {
 		r.Use(realtimeAuthMW(a.opts.JWTSecret, a.opts.AuthMiddleware))
 
 		r.Get("/realtime/connect", a.GetWebsocketUpgrade)
+		r.Get("/realtime/sse", a.GetSSE)
 		r.Post("/realtime/token", a.PostCreateJWT)
+		r.Post("/realtime/publish", a.PostPublish)
 	})
 }
 
 func (a *api) PostCreateJWT(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("content-type", "application/json")
 
+	// Realtime JWTs skip the standard auth middleware, so tokens must never be
+	// able to create new tokens with broader scopes.
+	if _, err := realtimeAuth(r.Context()); err == nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(403, "Realtime tokens cannot create tokens"))
+		return
+	}
+
 	// This only uses the given auth finder, which does not accept JWT claims.
 	auth, err := a.opts.AuthFinder(r.Context())
 	if err != nil {
@@ -70,24 +105,48 @@ func (a *api) PostCreateJWT(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// We expect the user to post a list of topics that they're interested in.
-	topics := []Topic{}
-	if err := json.NewDecoder(r.Body).Decode(&topics); err != nil {
-		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid request: must provide a list of topics"))
+	// We expect the user to post a list of topics that they're interested in, or
+	// a token request with scopes.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid request"))
 		return
 	}
+	req := TokenRequest{}
+	if err := json.Unmarshal(body, &req.Topics); err != nil {
+		if err := json.Unmarshal(body, &req); err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid request: must provide a list of topics or a token request"))
+			return
+		}
+	}
 
 	// Set the env ID from the authentication context.
-	for n := range topics {
-		topics[n].EnvID = auth.WorkspaceID()
+	for n := range req.Topics {
+		req.Topics[n].EnvID = auth.WorkspaceID()
+	}
+
+	opts := JWTOpts{
+		Topics: req.Topics,
+		Scopes: req.Scopes,
+		Expiry: time.Duration(req.ExpiresIn) * time.Second,
+	}
+	if opts.Expiry > MaxExpiry {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(400, "expires_in must be at most %d seconds", int(MaxExpiry.Seconds())))
+		return
+	}
+	for _, s := range opts.Scopes {
+		if err := s.Validate(); err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid scope: %s", err))
+			return
+		}
 	}
 
-	jwt, err := NewJWT(
+	jwt, err := NewJWTWithOpts(
 		r.Context(),
 		a.opts.JWTSecret,
 		auth.AccountID(),
 		auth.WorkspaceID(),
-		topics,
+		opts,
 	)
 	if err != nil {
 		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 500, "Error creating JWT.  Please try again"))
@@ -113,6 +172,20 @@ func (a *api) GetWebsocketUpgrade(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	topics, until, err := subscribeTopics(auth, r)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	resume, err := resumeOpts(r)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
 	ws, err := websocket.Accept(w, r, nil)
 	if err != nil {
 		w.WriteHeader(400)
@@ -125,7 +198,7 @@ func (a *api) GetWebsocketUpgrade(w http.ResponseWriter, r *http.Request) {
 		"new realtime connection",
 		"acct_id", auth.AccountID(),
 		"env_id", auth.Env,
-		"topics", auth.Topics,
+		"topics", topics,
 	)
 
 	sub, err := NewWebsocketSubscription(
@@ -135,13 +208,15 @@ func (a *api) GetWebsocketUpgrade(w http.ResponseWriter, r *http.Request) {
 		auth.WorkspaceID(),
 		a.opts.JWTSecret,
 		ws,
-		auth.Topics,
+		topics,
+		resume,
 	)
 	if err != nil {
 		logger.StdlibLogger(ctx).Error("error creating websocket subscription", "error", err)
 		ws.Close(websocket.StatusAbnormalClosure, "error subscribing to topics")
 		return
 	}
+	defer a.closeAt(sub, until)()
 
 	// Handle reading of additional messages such as subscription requests from the WS
 	pollCtx := context.Background()
@@ -154,3 +229,177 @@ func (a *api) GetWebsocketUpgrade(w http.ResponseWriter, r *http.Request) {
 
 	_ = ws.CloseNow()
 }
+
+// GetSSE streams messages for the JWT's topics as server-sent events, for
+// subscribers which cannot use websockets, eg. behind proxies which don't
+// support upgrading connections.
+//
+// See resumeOpts for resuming subscriptions from the topics' history.
+func (a *api) GetSSE(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	auth, err := realtimeAuth(ctx)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 401, "Not authenticated"))
+		return
+	}
+
+	topics, until, err := subscribeTopics(auth, r)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	resume, err := resumeOpts(r)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	logger.StdlibLogger(ctx).Info(
+		"new realtime sse connection",
+		"acct_id", auth.AccountID(),
+		"env_id", auth.Env,
+		"topics", topics,
+	)
+
+	sub, err := NewSSESubscription(
+		ctx,
+		a.opts.Broadcaster,
+		auth.AccountID(),
+		auth.WorkspaceID(),
+		w,
+		topics,
+		resume,
+	)
+	if err != nil {
+		logger.StdlibLogger(ctx).Error("error creating sse subscription", "error", err)
+		if sub == nil {
+			w.Header().Add("content-type", "application/json")
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 500, "Error subscribing to topics"))
+		}
+		return
+	}
+
+	defer a.closeAt(sub, until)()
+
+	// Block until the subscriber disconnects.
+	_ = sub.Poll(ctx)
+
+	if err := a.opts.Broadcaster.CloseSubscription(context.Background(), sub.ID()); err != nil {
+		logger.StdlibLogger(ctx).Warn("error closing sse subscription", "error", err)
+	}
+}
+
+// PostPublish publishes a message to a channel, allowing services to send data to
+// realtime subscribers.  Requests authenticated with a JWT must have a scope
+// allowing publishing to the channel;  other requests may publish to any channel
+// within the authenticated environment.
+func (a *api) PostPublish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Add("content-type", "application/json")
+
+	if a.opts.Broadcaster == nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(501, "Realtime is not enabled"))
+		return
+	}
+
+	req := PublishRequest{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, MaxPublishSize+1)).Decode(&req); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid publish request"))
+		return
+	}
+
+	// Only fall back to the standard auth if no JWT was given, so that JWTs
+	// can never publish beyond their scopes.
+	var envID uuid.UUID
+	claims, err := realtimeAuth(ctx)
+	if err == nil {
+		envID = claims.WorkspaceID()
+	} else {
+		auth, err := a.opts.AuthFinder(ctx)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 401, "Not authenticated"))
+			return
+		}
+		envID = auth.WorkspaceID()
+	}
+
+	t, err := ParseChannel(envID, req.Channel)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid channel: %s", err))
+		return
+	}
+	if claims != nil {
+		if ok, _ := claims.Authorize(t, CapabilityPublish, time.Now()); !ok {
+			_ = publicerr.WriteHTTP(w, publicerr.Errorf(403, "Not allowed to publish to %s", req.Channel))
+			return
+		}
+	}
+
+	data := req.Data
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+	msg := NewMessage(MessageKindData, data)
+	msg.EnvID = t.EnvID
+	msg.RunID = t.RunID
+	msg.TopicNames = []string{t.Name}
+	a.opts.Broadcaster.Publish(ctx, msg)
+
+	w.WriteHeader(201)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"id": msg.ID.String(),
+	})
+}
+
+// subscribeTopics returns the topics to subscribe the connection to:  the JWT's
+// topics along with any channels given via the channel query param, which must be
+// allowed by the JWT's scopes.  If the scopes expire, the expiry is returned.
+func subscribeTopics(auth *JWTClaims, r *http.Request) ([]Topic, *time.Time, error) {
+	topics, until, err := auth.SubscribeTopics(r.URL.Query()["channel"], time.Now())
+	if err != nil {
+		return nil, nil, publicerr.Wrapf(err, 403, "Unable to subscribe: %s", err)
+	}
+	return topics, until, nil
+}
+
+// closeAt closes the subscription once the scopes authorizing it expire.  The
+// returned func stops the timer, and must be called once the subscription ends.
+func (a *api) closeAt(sub Subscription, until *time.Time) func() {
+	if until == nil {
+		return func() {}
+	}
+	timer := time.AfterFunc(time.Until(*until), func() {
+		_ = a.opts.Broadcaster.CloseSubscription(context.Background(), sub.ID())
+	})
+	return func() { timer.Stop() }
+}
+
+// resumeOpts returns the options for resuming a subscription from the request,
+// or nil if only new messages should be sent:
+//
+//   - The Last-Event-ID header, or last_event_id query param, sends messages
+//     published after the given message ID, eg. when reconnecting.
+//   - The history=true query param sends the topics' entire history, eg. all step
+//     output for a run published so far.
+func resumeOpts(r *http.Request) (*ResumeOpts, error) {
+	last := r.Header.Get("Last-Event-ID")
+	if last == "" {
+		last = r.URL.Query().Get("last_event_id")
+	}
+	if last != "" {
+		id, err := ulid.Parse(last)
+		if err != nil {
+			return nil, publicerr.Wrapf(err, 400, "Invalid last event ID")
+		}
+		return &ResumeOpts{After: &id}, nil
+	}
+	if r.URL.Query().Get("history") == "true" {
+		return &ResumeOpts{}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,147 @@
+package realtime
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/api/apiv1/apiv1auth"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishAPI(t *testing.T) {
+	secret := []byte("secret")
+	b := NewInProcessBroadcaster()
+	srv := httptest.NewServer(NewAPI(APIOpts{
+		JWTSecret:   secret,
+		Broadcaster: b,
+		AuthFinder:  apiv1auth.NilAuthFinder,
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	auth, _ := apiv1auth.NilAuthFinder(ctx)
+	runID := ulid.Make()
+	channel := "run:" + runID.String() + ":progress"
+
+	token := func(t *testing.T, scopes ...Scope) string {
+		jwt, err := NewJWTWithOpts(ctx, secret, auth.AccountID(), auth.WorkspaceID(), JWTOpts{Scopes: scopes})
+		require.NoError(t, err)
+		return jwt
+	}
+	publish := func(t *testing.T, jwt string, channel string) *http.Response {
+		byt, _ := json.Marshal(PublishRequest{Channel: channel, Data: json.RawMessage(`{"percent":50}`)})
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/realtime/publish", bytes.NewReader(byt))
+		require.NoError(t, err)
+		if jwt != "" {
+			req.Header.Set("Authorization", "Bearer "+jwt)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	// Subscribe to the channel using a wildcard subscribe scope.
+	sub := token(t, Scope{Channel: "run:*:progress", Capabilities: []Capability{CapabilitySubscribe}})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/realtime/sse?channel="+channel, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+sub)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	r := bufio.NewReader(resp.Body)
+
+	require.Eventually(t, func() bool {
+		bc := b.(*broadcaster)
+		bc.l.RLock()
+		defer bc.l.RUnlock()
+		return len(bc.subs) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	t.Run("publishing with a publish scope", func(t *testing.T) {
+		pub := token(t, Scope{Channel: "run:*:progress", Capabilities: []Capability{CapabilityPublish}})
+		resp := publish(t, pub, channel)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		evt := readSSE(t, r)
+		require.Equal(t, string(MessageKindData), evt.Event)
+		msg := Message{}
+		require.NoError(t, json.Unmarshal([]byte(evt.Data), &msg))
+		require.Equal(t, json.RawMessage(`{"percent":50}`), msg.Data)
+		require.Equal(t, runID, msg.RunID)
+		require.Equal(t, []string{"progress"}, msg.TopicNames)
+	})
+
+	t.Run("publishing without a publish scope", func(t *testing.T) {
+		resp := publish(t, sub, channel)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		pub := token(t, Scope{Channel: "run:*:other", Capabilities: []Capability{CapabilityPublish}})
+		resp = publish(t, pub, channel)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("publishing with API auth", func(t *testing.T) {
+		resp := publish(t, "", channel)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		require.Equal(t, string(MessageKindData), readSSE(t, r).Event)
+	})
+
+	t.Run("publishing to event channels", func(t *testing.T) {
+		resp := publish(t, "", "event:api/user.created")
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("subscribing without a subscribe scope", func(t *testing.T) {
+		pub := token(t, Scope{Channel: "run:*:progress", Capabilities: []Capability{CapabilityPublish}})
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/realtime/sse?channel="+channel, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+pub)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("creating scoped tokens", func(t *testing.T) {
+		body := `{"scopes":[{"channel":"run:*:progress","capabilities":["publish"]}],"expires_in":3600}`
+		resp, err := http.Post(srv.URL+"/realtime/token", "application/json", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		res := map[string]string{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		claims, err := ValidateJWT(ctx, secret, res["jwt"])
+		require.NoError(t, err)
+		require.Len(t, claims.Scopes, 1)
+		require.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, 5*time.Second)
+
+		body = `{"scopes":[{"channel":"run:*:progress","capabilities":["delete"]}]}`
+		resp, err = http.Post(srv.URL+"/realtime/token", "application/json", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("realtime tokens cannot create tokens", func(t *testing.T) {
+		body := `{"scopes":[{"channel":"*","capabilities":["publish"]}],"expires_in":86400}`
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/realtime/token", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+sub)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
@@ -41,7 +41,7 @@ func realtimeAuthMW(jwtSecret []byte, mw func(http.Handler) http.Handler) func(h
 
 			// Call the original middelware.
 			if mw != nil {
-				mw(next)
+				mw(next).ServeHTTP(w, r)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -3,6 +3,7 @@ package realtime
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/khulnasoft/inngest/pkg/logger"
 	"github.com/khulnasoft/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
 )
 
 var (
@@ -25,20 +27,36 @@ var (
 	KeepaliveInterval   = 15 * time.Second
 )
 
+// BroadcasterOpt configures a broadcaster.
+type BroadcasterOpt func(b *broadcaster)
+
+// WithHistory stores each published message in the given History, allowing
+// subscribers to resume subscriptions via Resume.
+func WithHistory(h History) BroadcasterOpt {
+	return func(b *broadcaster) {
+		b.history = h
+	}
+}
+
 // NewInProcessBroadcaster is a single broadcaster which manages active subscriptions
 // in-memory and broadcasts to connected subscribers.
-func NewInProcessBroadcaster() Broadcaster {
-	return newBroadcaster()
+func NewInProcessBroadcaster(opts ...BroadcasterOpt) Broadcaster {
+	return newBroadcaster(opts...)
 }
 
-func newBroadcaster() *broadcaster {
-	return &broadcaster{
+func newBroadcaster(opts ...BroadcasterOpt) *broadcaster {
+	b := &broadcaster{
 		closing: 0,
 		subs:    map[uuid.UUID]*activesub{},
 		topics:  map[string]topicsub{},
 		l:       &sync.RWMutex{},
 		conds:   map[string]*sync.Cond{},
+		replays: map[uuid.UUID]*replay{},
+	}
+	for _, o := range opts {
+		o(b)
 	}
+	return b
 }
 
 // broadcaster represents a set of subscriptions for one or more topics.
@@ -67,6 +85,14 @@ type broadcaster struct {
 	// conds is a map of subscriptionID-topic hashes to a sync.Cond, allowing
 	// us to
 	conds map[string]*sync.Cond
+
+	// history stores published messages for resuming subscriptions, if set.
+	history History
+
+	// replays holds messages published to subscriptions while they're resuming,
+	// keyed by subscription ID.
+	replays map[uuid.UUID]*replay
+	rl      sync.Mutex
 }
 
 func (b *broadcaster) Subscribe(ctx context.Context, s Subscription, topics []Topic) error {
@@ -76,6 +102,135 @@ func (b *broadcaster) Subscribe(ctx context.Context, s Subscription, topics []To
 	return b.subscribe(ctx, s, topics, nil, nil)
 }
 
+func (b *broadcaster) Resume(ctx context.Context, s Subscription, topics []Topic, opts ResumeOpts) error {
+	return b.resume(ctx, s, topics, opts, b.Subscribe)
+}
+
+// resume subscribes to the topics using the given subscribe func, then sends the
+// topics' history.  Messages published to the subscription during this time are
+// held, then sent once the history has been sent.  Messages published to many
+// topics are only sent once.
+func (b *broadcaster) resume(
+	ctx context.Context,
+	s Subscription,
+	topics []Topic,
+	opts ResumeOpts,
+	subscribe func(ctx context.Context, s Subscription, topics []Topic) error,
+) error {
+	if b.history == nil || len(topics) == 0 {
+		return subscribe(ctx, s, topics)
+	}
+
+	r := b.startReplay(s.ID())
+	defer b.finishReplay(ctx, s)
+
+	if err := subscribe(ctx, s, topics); err != nil {
+		return err
+	}
+
+	msgs := []Message{}
+	for _, t := range topics {
+		history, err := b.history.Since(ctx, t, opts.After)
+		if err != nil {
+			// Still send live messages, as the subscription has been created.
+			logger.StdlibLogger(ctx).Warn(
+				"error loading realtime history",
+				"error", err,
+				"topic", t,
+				"subscription_id", s.ID(),
+			)
+			continue
+		}
+		msgs = append(msgs, history...)
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return msgs[i].ID.Compare(msgs[j].ID) < 0
+	})
+	for _, m := range b.unsent(r, msgs) {
+		b.send(ctx, s, m)
+	}
+	return nil
+}
+
+// startReplay holds messages published to the subscription until finishReplay
+// is called.
+func (b *broadcaster) startReplay(subID uuid.UUID) *replay {
+	b.rl.Lock()
+	defer b.rl.Unlock()
+
+	r, ok := b.replays[subID]
+	if !ok {
+		r = &replay{sent: map[ulid.ULID]struct{}{}}
+		b.replays[subID] = r
+	}
+	r.active++
+	return r
+}
+
+// finishReplay sends messages held while the subscription's history was sent,
+// skipping messages which were sent as part of the history.
+func (b *broadcaster) finishReplay(ctx context.Context, s Subscription) {
+	b.rl.Lock()
+	r, ok := b.replays[s.ID()]
+	if !ok {
+		b.rl.Unlock()
+		return
+	}
+	if r.active--; r.active > 0 || r.draining {
+		// Another resume for the same subscription is sending history, or
+		// held messages are already being sent.
+		b.rl.Unlock()
+		return
+	}
+	r.draining = true
+	b.rl.Unlock()
+
+	b.drain(ctx, s, r)
+}
+
+// drain sends held messages without holding the replay lock, so that sending
+// doesn't block other subscriptions.  The replay stays in place until every held
+// message is sent, so that messages published while draining are held and sent
+// in order after older held messages.
+func (b *broadcaster) drain(ctx context.Context, s Subscription, r *replay) {
+	for {
+		b.rl.Lock()
+		if r.active > 0 || len(r.held) == 0 {
+			// Either the backlog is flushed, or another resume started and
+			// will drain once its history has been sent.
+			if r.active == 0 {
+				delete(b.replays, s.ID())
+			}
+			r.draining = false
+			b.rl.Unlock()
+			return
+		}
+		held := r.held
+		r.held = nil
+		b.rl.Unlock()
+
+		for _, m := range b.unsent(r, held) {
+			b.send(ctx, s, m)
+		}
+	}
+}
+
+// unsent returns the messages which haven't yet been sent during the replay,
+// marking them as sent.
+func (b *broadcaster) unsent(r *replay, msgs []Message) []Message {
+	b.rl.Lock()
+	defer b.rl.Unlock()
+
+	unsent := make([]Message, 0, len(msgs))
+	for _, m := range msgs {
+		if r.markSent(m.ID) {
+			unsent = append(unsent, m)
+		}
+	}
+	return unsent
+}
+
 // subscribe ensures that a given Subscription is subscribed to the provided topics.
 // The onSubscribe callback is called when the subscription starts for eahc topic, and the
 // onUnsubscribe callback is called when the subscription ends, eg. when Close or Unsubscribe
@@ -295,6 +450,15 @@ func (b *broadcaster) Close(ctx context.Context) error {
 }
 
 func (b *broadcaster) Publish(ctx context.Context, m Message) {
+	if b.history != nil {
+		if err := b.history.Append(ctx, m); err != nil {
+			logger.StdlibLogger(ctx).Error(
+				"error appending realtime history",
+				"error", err,
+			)
+		}
+	}
+
 	b.l.RLock()
 	defer b.l.RUnlock()
 
@@ -319,8 +483,21 @@ func (b *broadcaster) Publish(ctx context.Context, m Message) {
 }
 
 // publishTo publishes a message to a subscription, keeping track of retries if the
-// write fails.
+// write fails.  Messages are held if the subscription's history is being sent.
 func (b *broadcaster) publishTo(ctx context.Context, s Subscription, m Message) {
+	b.rl.Lock()
+	if r, ok := b.replays[s.ID()]; ok {
+		r.held = append(r.held, m)
+		b.rl.Unlock()
+		return
+	}
+	b.rl.Unlock()
+
+	b.send(ctx, s, m)
+}
+
+// send writes a message to a subscription, retrying if the write fails.
+func (b *broadcaster) send(ctx context.Context, s Subscription, m Message) {
 	if err := s.WriteMessage(m); err == nil {
 		return
 	}
@@ -355,10 +532,10 @@ func (b *broadcaster) keepalive(ctx context.Context, subID uuid.UUID) {
 		// ensure the subscription ID exists, else it has been closed.
 		b.l.RLock()
 		sub, ok := b.subs[subID]
+		b.l.RUnlock()
 		if !ok {
 			return
 		}
-		b.l.RUnlock()
 
 		err := sub.SendKeepalive(Message{
 			Kind:      MessageKindPing,
@@ -387,6 +564,31 @@ func (b *broadcaster) keepalive(ctx context.Context, subID uuid.UUID) {
 	}
 }
 
+// replay tracks a subscription which is being sent its history.
+type replay struct {
+	// active is the number of resumes sending history to the subscription.
+	active int
+	// held are the messages published while history was being sent.
+	held []Message
+	// draining is set while held messages are being sent.
+	draining bool
+	// sent records the IDs of messages sent during the replay.
+	sent map[ulid.ULID]struct{}
+}
+
+// markSent records that the message is being sent, returning false if it was
+// already sent.  Messages without IDs are always sent.
+func (r *replay) markSent(id ulid.ULID) bool {
+	if id == (ulid.ULID{}) {
+		return true
+	}
+	if _, ok := r.sent[id]; ok {
+		return false
+	}
+	r.sent[id] = struct{}{}
+	return true
+}
+
 // activesub represents an active subscription with interest in one or
 // more Topics, for lookup from subscriber -> topics.
 type activesub struct {
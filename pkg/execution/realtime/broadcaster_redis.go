@@ -23,9 +23,12 @@ const (
 //
 // The messages pass from executors (calling .Publish) to gateways (susbcribed to redis pub/sub via
 // .Subscribe calls), being sent to all interested subscribers.
-func NewRedisBroadcaster(pubc, subc rueidis.Client) Broadcaster {
+//
+// Use WithHistory with a Redis history to allow subscriptions to be resumed via any
+// gateway.
+func NewRedisBroadcaster(pubc, subc rueidis.Client, opts ...BroadcasterOpt) Broadcaster {
 	return &redisBroadcaster{
-		broadcaster: newBroadcaster(),
+		broadcaster: newBroadcaster(opts...),
 		pubc:        pubc,
 		subc:        subc,
 	}
@@ -57,6 +60,17 @@ func (b *redisBroadcaster) Publish(ctx context.Context, m Message) {
 		return
 	}
 
+	// Store the message before publishing, so that subscribers resuming
+	// concurrently receive the message either from history or via pub/sub.
+	if b.history != nil {
+		if err := b.history.Append(ctx, m); err != nil {
+			logger.StdlibLogger(ctx).Error(
+				"error appending realtime history",
+				"error", err,
+			)
+		}
+	}
+
 	for _, t := range m.Topics() {
 		go func(t Topic) {
 			cmd := b.pubc.B().Publish().Channel(t.String()).Message(string(content)).Build()
@@ -116,6 +130,12 @@ func (b *redisBroadcaster) Subscribe(ctx context.Context, s Subscription, topics
 	return err
 }
 
+// Resume subscribes to the topics via Redis pub/sub, sending any messages from
+// the topics' history first.
+func (b *redisBroadcaster) Resume(ctx context.Context, s Subscription, topics []Topic, opts ResumeOpts) error {
+	return b.broadcaster.resume(ctx, s, topics, opts, b.Subscribe)
+}
+
 func (b *redisBroadcaster) redisPubsub(ctx context.Context, s Subscription, t Topic) error {
 	cmd := b.subc.B().Subscribe().Channel(t.String()).Build()
 	err := b.subc.Receive(ctx, cmd, func(msg rueidis.PubSubMessage) {
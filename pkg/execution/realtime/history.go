@@ -0,0 +1,210 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+)
+
+const (
+	// DefaultHistorySize is the maximum number of messages kept for each topic if
+	// no size is configured.
+	DefaultHistorySize = 1_000
+	// DefaultHistoryTTL is the time that messages are kept for each topic if no
+	// TTL is configured.
+	DefaultHistoryTTL = time.Hour
+
+	// redisHistoryField is the stream entry field containing the message.
+	redisHistoryField = "m"
+)
+
+// History stores a bounded backlog of recent messages for each topic, allowing
+// subscribers to receive messages published while they were disconnected.
+type History interface {
+	// Append stores the message in the backlog of each of the message's topics.
+	// Messages without IDs are not stored.
+	Append(ctx context.Context, m Message) error
+	// Since returns messages in the topic's backlog published after the message
+	// with the given ID, oldest first.  If after is nil, the entire backlog is
+	// returned.
+	Since(ctx context.Context, t Topic, after *ulid.ULID) ([]Message, error)
+}
+
+// HistoryOpts configures the backlog kept for each topic.
+type HistoryOpts struct {
+	// Size is the maximum number of messages kept for each topic.  Defaults to
+	// DefaultHistorySize.
+	Size int
+	// TTL is the time that messages are kept for.  Defaults to DefaultHistoryTTL.
+	TTL time.Duration
+}
+
+func (o HistoryOpts) withDefaults() HistoryOpts {
+	if o.Size <= 0 {
+		o.Size = DefaultHistorySize
+	}
+	if o.TTL <= 0 {
+		o.TTL = DefaultHistoryTTL
+	}
+	return o
+}
+
+// NewInMemoryHistory returns a History which stores messages in-process, for use
+// with the in-process broadcaster.
+func NewInMemoryHistory(opts HistoryOpts) History {
+	return &memoryHistory{
+		opts:   opts.withDefaults(),
+		topics: map[string][]historyEntry{},
+	}
+}
+
+type historyEntry struct {
+	Message
+	at time.Time
+}
+
+type memoryHistory struct {
+	opts HistoryOpts
+
+	l         sync.Mutex
+	topics    map[string][]historyEntry
+	lastSweep time.Time
+}
+
+func (h *memoryHistory) Append(ctx context.Context, m Message) error {
+	if m.ID == (ulid.ULID{}) {
+		return nil
+	}
+
+	now := time.Now()
+
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	for _, t := range m.Topics() {
+		key := t.String()
+		entries := append(h.unexpired(h.topics[key], now), historyEntry{Message: m, at: now})
+		if len(entries) > h.opts.Size {
+			entries = entries[len(entries)-h.opts.Size:]
+		}
+		h.topics[key] = entries
+	}
+
+	// Periodically remove topics which haven't been published to within the TTL,
+	// as they're only trimmed when published to.
+	if now.Sub(h.lastSweep) > h.opts.TTL {
+		h.lastSweep = now
+		for key, entries := range h.topics {
+			if entries = h.unexpired(entries, now); len(entries) == 0 {
+				delete(h.topics, key)
+			} else {
+				h.topics[key] = entries
+			}
+		}
+	}
+	return nil
+}
+
+func (h *memoryHistory) Since(ctx context.Context, t Topic, after *ulid.ULID) ([]Message, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	msgs := []Message{}
+	for _, e := range h.unexpired(h.topics[t.String()], time.Now()) {
+		if after != nil && e.ID.Compare(*after) <= 0 {
+			continue
+		}
+		msgs = append(msgs, e.Message)
+	}
+	return msgs, nil
+}
+
+// unexpired returns the entries appended within the TTL.  Entries are stored in
+// the order they were appended, so only the oldest entries expire.
+func (h *memoryHistory) unexpired(entries []historyEntry, now time.Time) []historyEntry {
+	for i, e := range entries {
+		if now.Sub(e.at) < h.opts.TTL {
+			return entries[i:]
+		}
+	}
+	return nil
+}
+
+// NewRedisHistory returns a History which stores each topic's messages in a
+// Redis stream, capped to the configured size and expiring after the TTL once
+// no more messages are published.
+func NewRedisHistory(r rueidis.Client, opts HistoryOpts) History {
+	return &redisHistory{
+		r:    r,
+		opts: opts.withDefaults(),
+	}
+}
+
+type redisHistory struct {
+	r    rueidis.Client
+	opts HistoryOpts
+}
+
+func (h *redisHistory) key(t Topic) string {
+	return "realtime:history:" + t.String()
+}
+
+func (h *redisHistory) Append(ctx context.Context, m Message) error {
+	if m.ID == (ulid.ULID{}) {
+		return nil
+	}
+
+	byt, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshalling realtime history message: %w", err)
+	}
+
+	cmds := rueidis.Commands{}
+	for _, t := range m.Topics() {
+		key := h.key(t)
+		cmds = append(cmds,
+			h.r.B().Xadd().
+				Key(key).
+				Maxlen().Almost().Threshold(strconv.Itoa(h.opts.Size)).
+				Id("*").
+				FieldValue().FieldValue(redisHistoryField, string(byt)).
+				Build(),
+			h.r.B().Pexpire().Key(key).Milliseconds(h.opts.TTL.Milliseconds()).Build(),
+		)
+	}
+	for _, res := range h.r.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return fmt.Errorf("error appending realtime history: %w", err)
+		}
+	}
+	return nil
+}
+
+func (h *redisHistory) Since(ctx context.Context, t Topic, after *ulid.ULID) ([]Message, error) {
+	cmd := h.r.B().Xrange().Key(h.key(t)).Start("-").End("+").Build()
+	entries, err := h.r.Do(ctx, cmd).AsXRange()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("error loading realtime history: %w", err)
+	}
+
+	// Streams are trimmed approximately, so the stream may contain slightly more
+	// messages than the configured size.
+	msgs := []Message{}
+	for _, e := range entries {
+		m := Message{}
+		if err := json.Unmarshal([]byte(e.FieldValues[redisHistoryField]), &m); err != nil {
+			return nil, fmt.Errorf("error unmarshalling realtime history message: %w", err)
+		}
+		if after != nil && m.ID.Compare(*after) <= 0 {
+			continue
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
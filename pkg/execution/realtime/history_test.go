@@ -0,0 +1,277 @@
+package realtime
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+
+	newRedisHistory := func(t *testing.T, opts HistoryOpts) (History, *miniredis.Miniredis) {
+		r := miniredis.RunT(t)
+		rc, err := rueidis.NewClient(rueidis.ClientOption{
+			InitAddress:  []string{r.Addr()},
+			DisableCache: true,
+		})
+		require.NoError(t, err)
+		t.Cleanup(rc.Close)
+		return NewRedisHistory(rc, opts), r
+	}
+
+	impls := map[string]func(t *testing.T, opts HistoryOpts) History{
+		"memory": func(t *testing.T, opts HistoryOpts) History {
+			return NewInMemoryHistory(opts)
+		},
+		"redis": func(t *testing.T, opts HistoryOpts) History {
+			h, _ := newRedisHistory(t, opts)
+			return h
+		},
+	}
+
+	for name, newHistory := range impls {
+		t.Run(name, func(t *testing.T) {
+			envID, runID := uuid.New(), ulid.Make()
+			publish := func(h History, data string) Message {
+				m := NewMessage(MessageKindStep, data)
+				m.EnvID, m.RunID = envID, runID
+				m.TopicNames = []string{"step-a"}
+				require.NoError(t, h.Append(ctx, m))
+				return m
+			}
+			stepTopic := Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: TopicNameStep}
+
+			t.Run("returns messages after the cursor", func(t *testing.T) {
+				h := newHistory(t, HistoryOpts{})
+				a, b, c := publish(h, "a"), publish(h, "b"), publish(h, "c")
+
+				msgs, err := h.Since(ctx, stepTopic, nil)
+				require.NoError(t, err)
+				require.Equal(t, []ulid.ULID{a.ID, b.ID, c.ID}, ids(msgs))
+
+				msgs, err = h.Since(ctx, Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: "step-a"}, &a.ID)
+				require.NoError(t, err)
+				require.Equal(t, []ulid.ULID{b.ID, c.ID}, ids(msgs))
+
+				msgs, err = h.Since(ctx, Topic{Kind: TopicKindRun, EnvID: envID, RunID: ulid.Make(), Name: TopicNameStep}, nil)
+				require.NoError(t, err)
+				require.Empty(t, msgs)
+			})
+
+			t.Run("messages without IDs are not stored", func(t *testing.T) {
+				h := newHistory(t, HistoryOpts{})
+				require.NoError(t, h.Append(ctx, Message{Kind: MessageKindStep, EnvID: envID, RunID: runID}))
+
+				msgs, err := h.Since(ctx, stepTopic, nil)
+				require.NoError(t, err)
+				require.Empty(t, msgs)
+			})
+
+			t.Run("the backlog is bounded", func(t *testing.T) {
+				h := newHistory(t, HistoryOpts{Size: 2})
+				_, b, c := publish(h, "a"), publish(h, "b"), publish(h, "c")
+
+				msgs, err := h.Since(ctx, stepTopic, nil)
+				require.NoError(t, err)
+				require.Equal(t, []ulid.ULID{b.ID, c.ID}, ids(msgs))
+			})
+		})
+	}
+
+	t.Run("memory history expires", func(t *testing.T) {
+		h := NewInMemoryHistory(HistoryOpts{TTL: 50 * time.Millisecond})
+		m := NewMessage(MessageKindRun, "output")
+		m.EnvID, m.RunID = uuid.New(), ulid.Make()
+		require.NoError(t, h.Append(ctx, m))
+
+		<-time.After(100 * time.Millisecond)
+
+		msgs, err := h.Since(ctx, m.Topics()[0], nil)
+		require.NoError(t, err)
+		require.Empty(t, msgs)
+	})
+
+	t.Run("redis history expires", func(t *testing.T) {
+		h, r := newRedisHistory(t, HistoryOpts{TTL: time.Minute})
+		m := NewMessage(MessageKindRun, "output")
+		m.EnvID, m.RunID = uuid.New(), ulid.Make()
+		require.NoError(t, h.Append(ctx, m))
+
+		r.FastForward(2 * time.Minute)
+
+		msgs, err := h.Since(ctx, m.Topics()[0], nil)
+		require.NoError(t, err)
+		require.Empty(t, msgs)
+	})
+}
+
+func TestBroadcasterResume(t *testing.T) {
+	ctx := context.Background()
+	b := NewInProcessBroadcaster(WithHistory(NewInMemoryHistory(HistoryOpts{})))
+
+	envID, runID := uuid.New(), ulid.Make()
+	publish := func(data string) Message {
+		m := NewMessage(MessageKindStep, data)
+		m.EnvID, m.RunID = envID, runID
+		m.TopicNames = []string{"step-a"}
+		b.Publish(ctx, m)
+		return m
+	}
+	topics := []Topic{
+		{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: TopicNameStep},
+		{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: "step-a"},
+	}
+
+	a, c := publish("a"), publish("b")
+
+	t.Run("entire history", func(t *testing.T) {
+		sub, received := newCollector()
+		require.NoError(t, b.Resume(ctx, sub, topics, ResumeOpts{}))
+		// Messages published to both topics are only sent once.
+		require.Equal(t, []ulid.ULID{a.ID, c.ID}, ids(received()))
+
+		// New messages are sent once history has been sent.
+		d := publish("d")
+		require.Equal(t, d.ID, received()[len(received())-1].ID)
+	})
+
+	t.Run("after a message", func(t *testing.T) {
+		sub, received := newCollector()
+		require.NoError(t, b.Resume(ctx, sub, topics, ResumeOpts{After: &a.ID}))
+		require.Equal(t, c.ID, received()[0].ID)
+		require.NotContains(t, ids(received()), a.ID)
+	})
+
+	t.Run("messages published while resuming are held", func(t *testing.T) {
+		bc := b.(*broadcaster)
+		sub, received := newCollector()
+
+		bc.startReplay(sub.ID())
+		require.NoError(t, bc.Subscribe(ctx, sub, topics))
+		e := publish("e")
+		require.Empty(t, received())
+
+		bc.finishReplay(ctx, sub)
+		require.Equal(t, []ulid.ULID{e.ID}, ids(received()))
+	})
+
+	t.Run("sending held messages doesn't block other subscriptions", func(t *testing.T) {
+		bc := b.(*broadcaster)
+		writing, release := make(chan struct{}), make(chan struct{})
+		slow := NewInmemorySubscription(uuid.New(), func(m Message) error {
+			close(writing)
+			<-release
+			return nil
+		})
+		defer close(release)
+		defer func() { _ = bc.CloseSubscription(ctx, slow.ID()) }()
+
+		bc.startReplay(slow.ID())
+		require.NoError(t, bc.Subscribe(ctx, slow, topics))
+		publish("f")
+		go bc.finishReplay(ctx, slow)
+		<-writing
+
+		otherEnvID, otherRunID := uuid.New(), ulid.Make()
+		other, received := newCollector()
+		require.NoError(t, bc.Subscribe(ctx, other, []Topic{
+			{Kind: TopicKindRun, EnvID: otherEnvID, RunID: otherRunID, Name: TopicNameStep},
+		}))
+
+		published := make(chan struct{})
+		go func() {
+			m := NewMessage(MessageKindStep, "g")
+			m.EnvID, m.RunID = otherEnvID, otherRunID
+			b.Publish(ctx, m)
+			close(published)
+		}()
+		select {
+		case <-published:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "publishing was blocked by a slow subscription")
+		}
+		require.Len(t, received(), 1)
+	})
+
+	t.Run("messages published while sending held messages keep their order", func(t *testing.T) {
+		bc := b.(*broadcaster)
+		var (
+			l        sync.Mutex
+			received []Message
+			writes   int32
+		)
+		writing, release := make(chan struct{}), make(chan struct{})
+		slow := NewInmemorySubscription(uuid.New(), func(m Message) error {
+			// Only the first write is slow.
+			if atomic.AddInt32(&writes, 1) == 1 {
+				close(writing)
+				<-release
+			}
+			l.Lock()
+			defer l.Unlock()
+			received = append(received, m)
+			return nil
+		})
+		defer func() { _ = bc.CloseSubscription(ctx, slow.ID()) }()
+
+		bc.startReplay(slow.ID())
+		require.NoError(t, bc.Subscribe(ctx, slow, topics))
+		h := publish("h")
+		finished := make(chan struct{})
+		go func() {
+			bc.finishReplay(ctx, slow)
+			close(finished)
+		}()
+		<-writing
+
+		// Publishing while held messages are being sent holds the message
+		// rather than sending it ahead of older held messages.
+		i := publish("i")
+		close(release)
+		<-finished
+
+		l.Lock()
+		defer l.Unlock()
+		require.Equal(t, []ulid.ULID{h.ID, i.ID}, ids(received))
+
+		bc.rl.Lock()
+		defer bc.rl.Unlock()
+		require.NotContains(t, bc.replays, slow.ID())
+	})
+}
+
+// newCollector returns a subscription which collects messages written to it.
+func newCollector() (Subscription, func() []Message) {
+	var (
+		l    sync.Mutex
+		msgs []Message
+	)
+	sub := NewInmemorySubscription(uuid.New(), func(m Message) error {
+		l.Lock()
+		defer l.Unlock()
+		msgs = append(msgs, m)
+		return nil
+	})
+	return sub, func() []Message {
+		l.Lock()
+		defer l.Unlock()
+		return append([]Message{}, msgs...)
+	}
+}
+
+func ids(msgs []Message) []ulid.ULID {
+	res := make([]ulid.ULID, len(msgs))
+	for i, m := range msgs {
+		res[i] = m.ID
+	}
+	return res
+}
@@ -91,6 +91,15 @@ type Broadcaster interface {
 	// cancelled or Unsubscribe is called on the subscription ID and topic pair.
 	Subscribe(ctx context.Context, s Subscription, topics []Topic) error
 
+	// Resume subscribes the Subscription to the given topics as with Subscribe,
+	// first sending any messages in the topics' history which the subscriber
+	// missed, eg. while reconnecting.  Messages published while the history is
+	// sent are held until the history has been sent, so that messages are
+	// delivered in order.
+	//
+	// If the broadcaster has no history, this is the same as Subscribe.
+	Resume(ctx context.Context, s Subscription, topics []Topic, opts ResumeOpts) error
+
 	// Unsubscribe a subscription from a set of specific topics.
 	Unsubscribe(ctx context.Context, subID uuid.UUID, topics []Topic) error
 
@@ -107,6 +116,15 @@ type Broadcaster interface {
 	Close(context.Context) error
 }
 
+// ResumeOpts configures which messages from the topics' history are sent when
+// resuming a subscription.
+type ResumeOpts struct {
+	// After is the ID of the last message the subscriber received.  Only messages
+	// published after this message are sent.  If nil, the topics' entire history
+	// is sent, eg. to receive all step output for a run published so far.
+	After *ulid.ULID `json:"after,omitempty"`
+}
+
 // Subscription represents a subscription to a specific set of channels, via a given protocol.
 // This may be backed by websockets, server-sent-events, and so on.
 type Subscription interface {
@@ -181,9 +199,9 @@ func (t Topic) String() string {
 // not of type byte or json.RawMessage, the data will be marshalled to JSON before
 // being set.
 //
-// Note that other fields in the message are not set.
+// Note that other fields in the message, other than the ID, are not set.
 func NewMessage(kind MessageKind, data any) Message {
-	msg := Message{Kind: kind, CreatedAt: time.Now().Truncate(time.Millisecond).UTC()}
+	msg := Message{ID: ulid.Make(), Kind: kind, CreatedAt: time.Now().Truncate(time.Millisecond).UTC()}
 	switch v := data.(type) {
 	case json.RawMessage:
 		msg.Data = v
@@ -202,6 +220,11 @@ func NewMessage(kind MessageKind, data any) Message {
 
 // Message represents a single message sent on realtime topics.
 type Message struct {
+	// ID uniquely identifies the message, ordered by creation time.  This is
+	// used as the event ID for server-sent events, allowing subscribers to resume
+	// from the last message received after reconnecting.  Control messages such as
+	// pings have no ID.
+	ID ulid.ULID `json:"id,omitempty,omitzero"`
 	// Kind represents the message kind.
 	Kind MessageKind `json:"kind"`
 	// Data represents the data in the message.
@@ -274,11 +297,16 @@ func (m Message) Topics() []Topic {
 		return topics
 	}
 
-	// Default to topic kinds of Run
+	// Default to topic kinds of Run, or Event for messages without a run, eg.
+	// data published to event channels via the publish API.
+	kind := TopicKindRun
+	if m.RunID == (ulid.ULID{}) {
+		kind = TopicKindEvent
+	}
 	topics := make([]Topic, len(m.TopicNames))
 	for n, v := range m.TopicNames {
-		topics[n+1] = Topic{
-			Kind:  TopicKindRun,
+		topics[n] = Topic{
+			Kind:  kind,
 			RunID: m.RunID,
 			EnvID: m.EnvID,
 			Name:  v,
@@ -0,0 +1,133 @@
+package realtime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// Capability is an action that a JWT scope grants on its channels.
+type Capability string
+
+const (
+	// CapabilitySubscribe allows subscribing to messages on a channel.
+	CapabilitySubscribe = Capability("subscribe")
+	// CapabilityPublish allows publishing messages to a channel via the publish
+	// API.
+	CapabilityPublish = Capability("publish")
+
+	// channelWildcard matches any value within a channel segment.
+	channelWildcard = "*"
+)
+
+// Scope grants capabilities on every channel matching its pattern.
+//
+// Channels address topics within the JWT's environment as "run:<run_id>:<name>"
+// for run topics, or "event:<name>" for event topics.  Patterns may use "*" as a
+// segment to match any value, eg. "run:*:progress" matches the "progress" topic for
+// every run, or a trailing "*" to match a prefix, eg. "run:*:progress-*".
+type Scope struct {
+	// Channel is the channel pattern that the scope applies to.
+	Channel string `json:"channel"`
+	// Capabilities lists the actions allowed on matching channels.
+	Capabilities []Capability `json:"capabilities"`
+	// ExpiresAt optionally expires the scope before the JWT expires.  Subscriptions
+	// authorized by the scope end once the scope expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (s Scope) Validate() error {
+	kind, _, _ := strings.Cut(s.Channel, ":")
+	if kind != string(TopicKindRun) && kind != string(TopicKindEvent) && kind != channelWildcard {
+		return fmt.Errorf("invalid channel pattern %q: must start with run: or event:", s.Channel)
+	}
+	if len(s.Capabilities) == 0 {
+		return fmt.Errorf("scope for %s has no capabilities", s.Channel)
+	}
+	for _, c := range s.Capabilities {
+		if c != CapabilitySubscribe && c != CapabilityPublish {
+			return fmt.Errorf("invalid capability %q: must be subscribe or publish", c)
+		}
+	}
+	return nil
+}
+
+// Allows returns whether the scope grants the capability on the channel at the
+// given time.
+func (s Scope) Allows(channel string, c Capability, now time.Time) bool {
+	if s.ExpiresAt != nil && !now.Before(*s.ExpiresAt) {
+		return false
+	}
+	has := false
+	for _, item := range s.Capabilities {
+		has = has || item == c
+	}
+	return has && matchChannel(s.Channel, channel)
+}
+
+// Channel returns the topic's channel, eg. "run:<run_id>:<name>".
+func (t Topic) Channel() string {
+	if t.Kind == TopicKindEvent {
+		return fmt.Sprintf("%s:%s", t.Kind, t.Name)
+	}
+	return fmt.Sprintf("%s:%s:%s", TopicKindRun, t.RunID, t.Name)
+}
+
+// ParseChannel parses a channel such as "run:<run_id>:<name>" into a topic in the
+// given environment.
+func ParseChannel(envID uuid.UUID, channel string) (Topic, error) {
+	kind, rest, _ := strings.Cut(channel, ":")
+	switch TopicKind(kind) {
+	case TopicKindRun:
+		run, name, ok := strings.Cut(rest, ":")
+		if !ok || name == "" {
+			return Topic{}, fmt.Errorf("invalid channel %q: must be run:<run_id>:<name>", channel)
+		}
+		runID, err := ulid.Parse(run)
+		if err != nil {
+			return Topic{}, fmt.Errorf("invalid channel %q: invalid run ID: %w", channel, err)
+		}
+		return Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: name}, nil
+	case TopicKindEvent:
+		if rest == "" {
+			return Topic{}, fmt.Errorf("invalid channel %q: must be event:<name>", channel)
+		}
+		return Topic{Kind: TopicKindEvent, EnvID: envID, Name: rest}, nil
+	}
+	return Topic{}, fmt.Errorf("invalid channel %q: must start with run: or event:", channel)
+}
+
+// matchChannel returns whether the channel matches the pattern, comparing each
+// ":" separated segment.  The final segment of a run channel is the topic name,
+// which may itself contain ":".
+func matchChannel(pattern, channel string) bool {
+	if pattern == channelWildcard {
+		return true
+	}
+
+	n := 3
+	if strings.HasPrefix(channel, string(TopicKindEvent)+":") {
+		n = 2
+	}
+	patterns := strings.SplitN(pattern, ":", n)
+	segments := strings.SplitN(channel, ":", n)
+	if len(patterns) != len(segments) {
+		return false
+	}
+	for i := range segments {
+		if !matchSegment(patterns[i], segments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchSegment(pattern, segment string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, channelWildcard); ok {
+		return strings.HasPrefix(segment, prefix)
+	}
+	return pattern == segment
+}
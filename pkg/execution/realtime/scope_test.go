@@ -0,0 +1,103 @@
+package realtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchChannel(t *testing.T) {
+	runID := ulid.Make().String()
+
+	tests := []struct {
+		pattern, channel string
+		match            bool
+	}{
+		{"run:" + runID + ":progress", "run:" + runID + ":progress", true},
+		{"run:*:progress", "run:" + runID + ":progress", true},
+		{"run:*:progress", "run:" + runID + ":other", false},
+		{"run:*:*", "run:" + runID + ":other", true},
+		{"run:*:progress-*", "run:" + runID + ":progress-upload", true},
+		{"run:*:progress-*", "run:" + runID + ":upload", false},
+		{"run:*:a:b", "run:" + runID + ":a:b", true},
+		{"run:*:*", "event:api/user.created", false},
+		{"event:api/*", "event:api/user.created", true},
+		{"event:*", "run:" + runID + ":progress", false},
+		{"*", "event:api/user.created", true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.match, matchChannel(test.pattern, test.channel), "%s %s", test.pattern, test.channel)
+	}
+}
+
+func TestParseChannel(t *testing.T) {
+	envID, runID := uuid.New(), ulid.Make()
+
+	topic, err := ParseChannel(envID, "run:"+runID.String()+":progress")
+	require.NoError(t, err)
+	require.Equal(t, Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: "progress"}, topic)
+	require.Equal(t, "run:"+runID.String()+":progress", topic.Channel())
+
+	topic, err = ParseChannel(envID, "event:api/user.created")
+	require.NoError(t, err)
+	require.Equal(t, Topic{Kind: TopicKindEvent, EnvID: envID, Name: "api/user.created"}, topic)
+
+	for _, invalid := range []string{"", "run:", "run:nope:progress", "run:" + runID.String(), "event:", "other:x"} {
+		_, err := ParseChannel(envID, invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestJWTClaimsAuthorize(t *testing.T) {
+	now := time.Now()
+	envID, runID := uuid.New(), ulid.Make()
+	expiry := now.Add(time.Minute)
+
+	progress := Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: "progress"}
+	step := Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: TopicNameStep}
+
+	claims := JWTClaims{
+		Env:    envID,
+		Topics: []Topic{step},
+		Scopes: []Scope{
+			{Channel: "run:*:progress", Capabilities: []Capability{CapabilityPublish}},
+			{Channel: "run:*:progress", Capabilities: []Capability{CapabilitySubscribe}, ExpiresAt: &expiry},
+		},
+	}
+
+	ok, until := claims.Authorize(step, CapabilitySubscribe, now)
+	require.True(t, ok)
+	require.Nil(t, until)
+
+	ok, _ = claims.Authorize(step, CapabilityPublish, now)
+	require.False(t, ok, "topics only allow subscribing")
+
+	ok, until = claims.Authorize(progress, CapabilityPublish, now)
+	require.True(t, ok)
+	require.Nil(t, until)
+
+	ok, until = claims.Authorize(progress, CapabilitySubscribe, now)
+	require.True(t, ok)
+	require.Equal(t, &expiry, until)
+
+	ok, _ = claims.Authorize(progress, CapabilitySubscribe, now.Add(2*time.Minute))
+	require.False(t, ok, "expired scopes are ignored")
+
+	other := progress
+	other.EnvID = uuid.New()
+	ok, _ = claims.Authorize(other, CapabilityPublish, now)
+	require.False(t, ok, "other environments are never allowed")
+
+	t.Run("subscribe topics", func(t *testing.T) {
+		topics, until, err := claims.SubscribeTopics([]string{progress.Channel()}, now)
+		require.NoError(t, err)
+		require.Equal(t, []Topic{step, progress}, topics)
+		require.Equal(t, &expiry, until)
+
+		_, _, err = claims.SubscribeTopics([]string{"run:" + runID.String() + ":secret"}, now)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,181 @@
+package realtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// ErrSubscriptionClosed is returned when writing to a subscription which has
+// been closed.
+var ErrSubscriptionClosed = fmt.Errorf("subscription is closed")
+
+// NewSSESubscription creates a new server-sent-events subscription which streams
+// messages over the given HTTP response, subscribing to the given topics.
+//
+// Unlike websockets, server-sent events are one way:  subscribers cannot change
+// their topics once connected, and instead reconnect with a new JWT.  Each message
+// is sent with its ID as the event ID.  Browsers send the last event ID received
+// as the Last-Event-ID header when reconnecting, which is passed here as the
+// resume cursor so that messages published while disconnected are sent from the
+// topics' history.  If resume is nil, only new messages are sent.
+//
+// Poll must be called to keep the HTTP request open until the subscriber
+// disconnects.
+func NewSSESubscription(
+	ctx context.Context,
+	b Broadcaster,
+	acctID, envID uuid.UUID,
+	w http.ResponseWriter,
+	topics []Topic,
+	resume *ResumeOpts,
+) (ReadWriteSubscription, error) {
+	if b == nil {
+		return nil, fmt.Errorf("Cannot make sse connection without broadcaster")
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, fmt.Errorf("Cannot make sse connection: streaming is not supported")
+	}
+
+	sub := &SubscriptionSSE{
+		id:      uuid.New(),
+		acctID:  acctID,
+		envID:   envID,
+		w:       w,
+		flusher: flusher,
+		done:    make(chan struct{}),
+	}
+	if resume != nil {
+		sub.lastEventID = resume.After
+	}
+
+	// Send the headers immediately so that the subscriber knows that the stream
+	// has started, before any messages are published.
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disable buffering in proxies such as nginx, which otherwise hold events
+	// until the buffer fills.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := sub.write([]byte(": connected\n\n")); err != nil {
+		return nil, err
+	}
+
+	if resume != nil {
+		return sub, b.Resume(ctx, sub, topics, *resume)
+	}
+	return sub, b.Subscribe(ctx, sub, topics)
+}
+
+// SubscriptionSSE represents a server-sent-events subscription.
+type SubscriptionSSE struct {
+	id uuid.UUID
+
+	// acctID represents the authenticated account ID when initializing
+	// the sse connection
+	acctID uuid.UUID
+	// envID represents the authenticated environment ID when initializing
+	// the sse connection
+	envID uuid.UUID
+
+	// lastEventID is the ID of the last message received by the subscriber
+	// before reconnecting.  Messages with the same or earlier IDs are skipped.
+	lastEventID *ulid.ULID
+
+	// l guards writes to w, as messages and keepalives are written from many
+	// goroutines.
+	l       sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	closed  bool
+	done    chan struct{}
+}
+
+func (s *SubscriptionSSE) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *SubscriptionSSE) Protocol() string {
+	return "sse"
+}
+
+// WriteMessage writes the message as a server-sent event, using the message's
+// kind as the event type and the message's ID as the event ID.
+func (s *SubscriptionSSE) WriteMessage(m Message) error {
+	if s.lastEventID != nil && m.ID != (ulid.ULID{}) && m.ID.Compare(*s.lastEventID) <= 0 {
+		return nil
+	}
+
+	byt, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if m.ID != (ulid.ULID{}) {
+		fmt.Fprintf(buf, "id: %s\n", m.ID)
+	}
+	fmt.Fprintf(buf, "event: %s\n", m.Kind)
+	fmt.Fprintf(buf, "data: %s\n\n", byt)
+	return s.write(buf.Bytes())
+}
+
+// SendKeepalive writes a comment, which subscribers ignore, keeping the
+// connection open through proxies which close idle connections.
+func (s *SubscriptionSSE) SendKeepalive(m Message) error {
+	return s.write([]byte(": " + string(MessageKindPing) + "\n\n"))
+}
+
+// Close stops the subscription, ending the HTTP response once Poll returns.
+func (s *SubscriptionSSE) Close() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.close()
+	return nil
+}
+
+// Poll blocks until the subscription is closed or the context is cancelled,
+// eg. when the subscriber disconnects.  Server-sent events are one way, so no
+// messages are read.
+func (s *SubscriptionSSE) Poll(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+	case <-s.done:
+	}
+
+	// The response cannot be written to once the HTTP handler returns.
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.close()
+	return ctx.Err()
+}
+
+func (s *SubscriptionSSE) write(byt []byte) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.closed {
+		return ErrSubscriptionClosed
+	}
+	if _, err := s.w.Write(byt); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// close marks the subscription as closed.  This must be called with the lock
+// held.
+func (s *SubscriptionSSE) close() {
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
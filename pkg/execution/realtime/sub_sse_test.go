@@ -0,0 +1,143 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readSSE reads events from a server-sent-events stream, ignoring comments.
+func readSSE(t *testing.T, r *bufio.Reader) sseEvent {
+	t.Helper()
+
+	evt := sseEvent{}
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			if evt.Event != "" {
+				return evt
+			}
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "id: "):
+			evt.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			evt.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			evt.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestSSESubscription(t *testing.T) {
+	secret := []byte("secret")
+	b := NewInProcessBroadcaster()
+	srv := httptest.NewServer(NewAPI(APIOpts{
+		JWTSecret:   secret,
+		Broadcaster: b,
+	}))
+	defer srv.Close()
+
+	// Cancelling the context disconnects subscribers, which must happen before
+	// the server closes.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	envID := uuid.New()
+	runID := ulid.Make()
+	topics := []Topic{{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: TopicNameStep}}
+
+	connect := func(t *testing.T, lastEventID string) *bufio.Reader {
+		jwt, err := NewJWT(ctx, secret, uuid.New(), envID, topics)
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/realtime/sse", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+jwt)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		return bufio.NewReader(resp.Body)
+	}
+
+	// waitForSubs waits for the broadcaster to register n subscriptions, as
+	// connections are subscribed after the response headers are sent.
+	waitForSubs := func(t *testing.T, n int) {
+		require.Eventually(t, func() bool {
+			bc := b.(*broadcaster)
+			bc.l.RLock()
+			defer bc.l.RUnlock()
+			return len(bc.subs) == n
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	publish := func(data string) Message {
+		msg := NewMessage(MessageKindStep, data)
+		msg.EnvID = envID
+		msg.RunID = runID
+		msg.TopicNames = []string{"step-a"}
+		b.Publish(ctx, msg)
+		return msg
+	}
+
+	r := connect(t, "")
+	waitForSubs(t, 1)
+	first := publish("first")
+
+	evt := readSSE(t, r)
+	require.Equal(t, first.ID.String(), evt.ID)
+	require.Equal(t, string(MessageKindStep), evt.Event)
+
+	received := Message{}
+	require.NoError(t, json.Unmarshal([]byte(evt.Data), &received))
+	require.Equal(t, json.RawMessage(`"first"`), received.Data)
+	require.Equal(t, runID, received.RunID)
+
+	t.Run("messages up to the last event ID are skipped", func(t *testing.T) {
+		second := publish("second")
+		require.Equal(t, second.ID.String(), readSSE(t, r).ID)
+
+		r := connect(t, second.ID.String())
+		waitForSubs(t, 2)
+
+		// Resending the first messages, eg. via retries, doesn't send them to
+		// the resumed subscriber.
+		b.Publish(ctx, first)
+		b.Publish(ctx, second)
+		third := publish("third")
+
+		evt := readSSE(t, r)
+		require.Equal(t, third.ID.String(), evt.ID)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/realtime/sse")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
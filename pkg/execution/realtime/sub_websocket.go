@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/coder/websocket"
 	"github.com/google/uuid"
@@ -24,6 +26,8 @@ import (
 //     topics.
 //   - The websocket subscriber listens for incoming messages which can subscribe and unsubscribe from
 //     new topics at will (given a valid JWT in the websocket message, for subscription requests)
+//
+// If resume is not nil, messages from the topics' history are sent before new messages.
 func NewWebsocketSubscription(
 	ctx context.Context,
 	b Broadcaster,
@@ -31,6 +35,7 @@ func NewWebsocketSubscription(
 	jwtSigningKey []byte,
 	conn *websocket.Conn,
 	topics []Topic,
+	resume *ResumeOpts,
 ) (ReadWriteSubscription, error) {
 	sub := &SubscriptionWS{
 		b:             b,
@@ -39,13 +44,41 @@ func NewWebsocketSubscription(
 		id:            uuid.New(),
 		ws:            conn,
 		jwtSigningKey: jwtSigningKey,
+		expiries:      &expiries{timers: map[string]*time.Timer{}},
 	}
 
 	if b == nil {
 		return nil, fmt.Errorf("Cannot make websocket connection without broadcaster")
 	}
-	err := b.Subscribe(ctx, sub, topics)
-	return sub, err
+	if resume != nil {
+		return sub, b.Resume(ctx, sub, topics, *resume)
+	}
+	return sub, b.Subscribe(ctx, sub, topics)
+}
+
+// subscribeData is the data of a subscribe message which resumes a subscription.
+// Subscribe messages may also contain only the JWT as a string.
+type subscribeData struct {
+	JWT string `json:"jwt"`
+	// Channels lists channels to subscribe to in addition to the JWT's topics,
+	// which must be allowed by the JWT's scopes.
+	Channels []string `json:"channels"`
+	// History sends the topics' entire history before new messages, unless
+	// Resume is set.
+	History bool `json:"history"`
+	// Resume sends messages from the topics' history published after the given
+	// message ID before new messages.
+	Resume *ResumeOpts `json:"resume"`
+}
+
+func (d subscribeData) resume() *ResumeOpts {
+	if d.Resume != nil {
+		return d.Resume
+	}
+	if d.History {
+		return &ResumeOpts{}
+	}
+	return nil
 }
 
 // SubscriptionWS represents a websocket subscription
@@ -64,6 +97,9 @@ type SubscriptionWS struct {
 	jwtSigningKey []byte
 
 	ws *websocket.Conn
+
+	// expiries unsubscribes topics once the scopes allowing them expire.
+	expiries *expiries
 }
 
 func (s SubscriptionWS) ID() uuid.UUID {
@@ -88,6 +124,7 @@ func (s SubscriptionWS) SendKeepalive(m Message) error {
 }
 
 func (s SubscriptionWS) Close() error {
+	s.expiries.stop()
 	return s.ws.Close(websocket.CloseStatus(nil), string(MessageKindClosing))
 }
 
@@ -98,6 +135,9 @@ func (s SubscriptionWS) Poll(ctx context.Context) error {
 		return fmt.Errorf("error pinging websocket conn before polling: %w", err)
 	}
 
+	// Topics are no longer subscribed once the connection closes.
+	defer s.expiries.stop()
+
 	for {
 		mt, byt, err := s.ws.Read(ctx)
 		if err != nil {
@@ -126,27 +166,44 @@ func (s SubscriptionWS) Poll(ctx context.Context) error {
 
 		switch msg.Kind {
 		case MessageKindSubscribe:
-			// Subscribe messages must always have a JWT as the data;
-			// the JWT embeds the topics that will be subscribed to.
-			var jwt string
-			if err := json.Unmarshal(msg.Data, &jwt); err != nil {
-				logger.StdlibLogger(ctx).Warn(
-					"unknown subscribe ws data type",
-					"type", fmt.Sprintf("%T", msg.Data),
-				)
-				continue
+			// Subscribe messages must always have a JWT as the data, or an
+			// object containing the JWT and resume options;  the JWT embeds the
+			// topics that will be subscribed to.
+			data := subscribeData{}
+			if err := json.Unmarshal(msg.Data, &data.JWT); err != nil {
+				if err := json.Unmarshal(msg.Data, &data); err != nil {
+					logger.StdlibLogger(ctx).Warn(
+						"unknown subscribe ws data type",
+						"type", fmt.Sprintf("%T", msg.Data),
+					)
+					continue
+				}
 			}
 
-			topics, err := TopicsFromJWT(ctx, s.jwtSigningKey, jwt)
+			claims, err := ValidateJWT(ctx, s.jwtSigningKey, data.JWT)
+			if err != nil || claims.WorkspaceID() != s.envID {
+				// TODO: Reply with unsuccessful subscribe msg
+				continue
+			}
+			topics, until, err := claims.SubscribeTopics(data.Channels, time.Now())
 			if err != nil {
 				// TODO: Reply with unsuccessful subscribe msg
 				continue
 			}
 
-			if err := s.b.Subscribe(ctx, s, topics); err != nil {
+			if resume := data.resume(); resume != nil {
+				err = s.b.Resume(ctx, s, topics, *resume)
+			} else {
+				err = s.b.Subscribe(ctx, s, topics)
+			}
+			if err != nil {
 				// TODO: Reply with unsuccessful subscribe msg
 				continue
 			}
+			// Unsubscribe once the scopes allowing the subscription expire.
+			s.expiries.set(topics, until, func(t Topic) {
+				_ = s.b.Unsubscribe(context.Background(), s.id, []Topic{t})
+			})
 
 			// TODO: Reply with successful subscribe msg
 			continue
@@ -162,6 +219,7 @@ func (s SubscriptionWS) Poll(ctx context.Context) error {
 				continue
 			}
 
+			s.expiries.set(topics, nil, nil)
 			if err := s.b.Unsubscribe(ctx, s.id, topics); err != nil {
 				// TODO: reply with error.
 				continue
@@ -169,3 +227,51 @@ func (s SubscriptionWS) Poll(ctx context.Context) error {
 		}
 	}
 }
+
+// expiries tracks a timer for each topic subscribed via scopes which expire.
+type expiries struct {
+	l       sync.Mutex
+	timers  map[string]*time.Timer
+	stopped bool
+}
+
+// set replaces the expiry of each topic, calling f with the topic once until
+// passes.  If until is nil the topics no longer expire.
+func (e *expiries) set(topics []Topic, until *time.Time, f func(t Topic)) {
+	e.l.Lock()
+	defer e.l.Unlock()
+
+	for _, t := range topics {
+		key := t.String()
+		if timer, ok := e.timers[key]; ok {
+			timer.Stop()
+			delete(e.timers, key)
+		}
+		if until == nil || e.stopped {
+			continue
+		}
+
+		var timer *time.Timer
+		timer = time.AfterFunc(time.Until(*until), func() {
+			e.l.Lock()
+			if e.timers[key] == timer {
+				delete(e.timers, key)
+			}
+			e.l.Unlock()
+			f(t)
+		})
+		e.timers[key] = timer
+	}
+}
+
+// stop stops every timer, eg. once the connection closes.
+func (e *expiries) stop() {
+	e.l.Lock()
+	defer e.l.Unlock()
+
+	e.stopped = true
+	for key, timer := range e.timers {
+		timer.Stop()
+		delete(e.timers, key)
+	}
+}
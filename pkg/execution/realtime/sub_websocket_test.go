@@ -0,0 +1,60 @@
+package realtime
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpiries(t *testing.T) {
+	envID, runID := uuid.New(), ulid.Make()
+	a := Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: "a"}
+	b := Topic{Kind: TopicKindRun, EnvID: envID, RunID: runID, Name: "b"}
+
+	var (
+		l       sync.Mutex
+		expired []Topic
+	)
+	onExpire := func(t Topic) {
+		l.Lock()
+		defer l.Unlock()
+		expired = append(expired, t)
+	}
+	received := func() []Topic {
+		l.Lock()
+		defer l.Unlock()
+		return append([]Topic{}, expired...)
+	}
+
+	e := &expiries{timers: map[string]*time.Timer{}}
+	soon := time.Now().Add(20 * time.Millisecond)
+
+	// Topics which are unsubscribed or no longer expire are never expired.
+	e.set([]Topic{a, b}, &soon, onExpire)
+	e.set([]Topic{b}, nil, nil)
+	require.Eventually(t, func() bool { return len(received()) == 1 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, []Topic{a}, received())
+
+	e.l.Lock()
+	require.Empty(t, e.timers)
+	e.l.Unlock()
+
+	t.Run("timers are stopped when the subscription closes", func(t *testing.T) {
+		later := time.Now().Add(20 * time.Millisecond)
+		e.set([]Topic{b}, &later, onExpire)
+		e.stop()
+
+		e.l.Lock()
+		require.Empty(t, e.timers)
+		e.l.Unlock()
+
+		// No new timers are started once stopped.
+		e.set([]Topic{b}, &later, onExpire)
+		<-time.After(50 * time.Millisecond)
+		require.Equal(t, []Topic{a}, received())
+	})
+}
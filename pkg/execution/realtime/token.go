@@ -14,12 +14,79 @@ import (
 const (
 	Issuer        = "rt.inngest.com"
 	DefaultExpiry = time.Minute
+	// MaxExpiry is the maximum lifetime of a JWT.
+	MaxExpiry = 24 * time.Hour
 )
 
 type JWTClaims struct {
 	jwt.RegisteredClaims
-	Env    uuid.UUID `json:"env"`
-	Topics []Topic   `json:"topics"`
+	Env uuid.UUID `json:"env"`
+	// Topics lists topics that the JWT may subscribe to.  These are subscribed to
+	// when connecting.
+	Topics []Topic `json:"topics"`
+	// Scopes grant capabilities on channels matching patterns, eg. to publish to
+	// or subscribe to "run:*:progress".
+	Scopes []Scope `json:"scopes,omitempty"`
+}
+
+// Authorize returns whether the claims grant the capability on the topic at the
+// given time.  If the capability is granted by scopes which expire, the latest
+// expiry is returned.
+func (j JWTClaims) Authorize(t Topic, c Capability, now time.Time) (bool, *time.Time) {
+	if t.EnvID != j.Env {
+		return false, nil
+	}
+	if c == CapabilitySubscribe {
+		for _, item := range j.Topics {
+			if item.String() == t.String() {
+				return true, nil
+			}
+		}
+	}
+
+	var (
+		allowed bool
+		until   *time.Time
+	)
+	channel := t.Channel()
+	for _, s := range j.Scopes {
+		if !s.Allows(channel, c, now) {
+			continue
+		}
+		if s.ExpiresAt == nil {
+			return true, nil
+		}
+		if !allowed || s.ExpiresAt.After(*until) {
+			until = s.ExpiresAt
+		}
+		allowed = true
+	}
+	return allowed, until
+}
+
+// SubscribeTopics returns the topics to subscribe to when connecting:  the
+// claims' topics along with the given channels, which must be allowed by the
+// claims' scopes.  If any channel is allowed by scopes which expire, the
+// earliest expiry is returned.
+func (j JWTClaims) SubscribeTopics(channels []string, now time.Time) ([]Topic, *time.Time, error) {
+	topics := append([]Topic{}, j.Topics...)
+
+	var until *time.Time
+	for _, c := range channels {
+		t, err := ParseChannel(j.Env, c)
+		if err != nil {
+			return nil, nil, err
+		}
+		ok, expiry := j.Authorize(t, CapabilitySubscribe, now)
+		if !ok {
+			return nil, nil, fmt.Errorf("not allowed to subscribe to %s", c)
+		}
+		if expiry != nil && (until == nil || expiry.Before(*until)) {
+			until = expiry
+		}
+		topics = append(topics, t)
+	}
+	return topics, until, nil
 }
 
 func (j JWTClaims) AccountID() uuid.UUID {
@@ -73,8 +140,37 @@ func TopicsFromJWT(ctx context.Context, secret []byte, token string) ([]Topic, e
 // JWTs are made using a pre-shared key, and can then be passed to the frontend to
 // subscribe to the JWT's encoded topics.
 func NewJWT(ctx context.Context, secret []byte, accountID, envID uuid.UUID, topics []Topic) (string, error) {
+	return NewJWTWithOpts(ctx, secret, accountID, envID, JWTOpts{Topics: topics})
+}
+
+// JWTOpts configures the access granted by a JWT.
+type JWTOpts struct {
+	// Topics are the topics subscribed to when connecting.
+	Topics []Topic
+	// Scopes grant capabilities on channels matching patterns.
+	Scopes []Scope
+	// Expiry is the lifetime of the JWT, defaulting to DefaultExpiry.  Note that
+	// subscriptions remain open once the JWT expires, unless the scopes
+	// authorizing them expire.
+	Expiry time.Duration
+}
+
+// NewJWTWithOpts returns a new JWT granting the topics and scopes in opts.
+func NewJWTWithOpts(ctx context.Context, secret []byte, accountID, envID uuid.UUID, opts JWTOpts) (string, error) {
 	now := time.Now()
 
+	if opts.Expiry <= 0 {
+		opts.Expiry = DefaultExpiry
+	}
+	if opts.Expiry > MaxExpiry {
+		return "", fmt.Errorf("expiry must be at most %s", MaxExpiry)
+	}
+	for _, s := range opts.Scopes {
+		if err := s.Validate(); err != nil {
+			return "", err
+		}
+	}
+
 	id, err := ulid.New(ulid.Now(), rand.Reader)
 	if err != nil {
 		return "", fmt.Errorf("could not generate session token ID: %w", err)
@@ -83,12 +179,13 @@ func NewJWT(ctx context.Context, secret []byte, accountID, envID uuid.UUID, topi
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    Issuer,
 			Subject:   accountID.String(),
-			ExpiresAt: jwt.NewNumericDate(now.Add(DefaultExpiry)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(opts.Expiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ID:        id.String(),
 		},
 		Env:    envID,
-		Topics: topics,
+		Topics: opts.Topics,
+		Scopes: opts.Scopes,
 	})
 	signed, err := t.SignedString(secret)
 	if err != nil {
@@ -3,7 +3,9 @@ package runner
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/khulnasoft/inngest/pkg/event"
 	"github.com/khulnasoft/inngest/pkg/execution"
 	"github.com/khulnasoft/inngest/pkg/execution/batch"
+	"github.com/khulnasoft/inngest/pkg/execution/cron"
 	"github.com/khulnasoft/inngest/pkg/execution/executor"
 	"github.com/khulnasoft/inngest/pkg/execution/queue"
 	"github.com/khulnasoft/inngest/pkg/execution/ratelimit"
@@ -28,7 +31,6 @@ import (
 	"github.com/khulnasoft/inngest/pkg/service"
 	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
 	"github.com/oklog/ulid/v2"
-	"github.com/robfig/cron/v3"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
@@ -47,8 +49,32 @@ type Runner interface {
 
 	StateManager() state.Manager
 	InitializeCrons(ctx context.Context) error
+	FireCron(ctx context.Context, item queue.Item) error
 	Runs(ctx context.Context, accountId uuid.UUID, eventId ulid.ULID) ([]state.State, error)
 	Events(ctx context.Context, eventId string) ([]event.Event, error)
+	// Replay re-delivers a stored event to the functions whose triggers currently
+	// match it, returning the matched and scheduled functions.
+	Replay(ctx context.Context, tracked event.TrackedEvent, opts ReplayOpts) (ReplayResult, error)
+}
+
+// ReplayOpts configures re-delivering a stored event to function triggers.
+type ReplayOpts struct {
+	// FunctionID limits the replay to the function with the given internal ID.
+	FunctionID *uuid.UUID
+	// DryRun returns the functions whose triggers match the event without
+	// scheduling any runs.
+	DryRun bool
+}
+
+// ReplayResult lists the functions whose triggers matched a replayed event.
+type ReplayResult struct {
+	// Matched lists every function whose triggers match the event.
+	Matched []inngest.Function
+	// Scheduled lists the matched functions for which a new run was scheduled.
+	// Functions which already ran for the event aren't included, nor are
+	// functions which batched, debounced, rate limited or skipped the event.
+	// This is empty for dry runs.
+	Scheduled []inngest.Function
 }
 
 func WithCQRS(data cqrs.Manager) func(s *svc) {
@@ -141,9 +167,7 @@ type svc struct {
 	batcher batch.BatchManager
 	// rl rate-limits functions.
 	rl ratelimit.RateLimiter
-	// cronmanager allows the creation of new scheduled functions.
-	cronmanager *cron.Cron
-	em          *event.Manager
+	em *event.Manager
 
 	tracker *Tracker
 }
@@ -179,17 +203,10 @@ func (s *svc) Pre(ctx context.Context) error {
 }
 
 func (s *svc) Run(ctx context.Context) error {
-	// Each runner service is responsible for initializing cron-based executions.
-	// As the runners are shared-nothing, there is contention when running multiple
-	// services;  each individual service will attempt to create a new cron execution
-	// simultaneously.  We currently rely on idempotency within the state store to
-	// ensure that only one run can execute.
-	//
-	// In the future, we may want to add distributed locking and/or a limit on the
-	// number of concurrent services that can schedule crons.  We don't really want
-	// to rely on a single executor to 'claim' ownership:  we'd have to implement
-	// more complex logic to check for the last heartbeat and valid cron scheduled,
-	// then backtrack to re-execute in the case of node downtime.  This is simple.
+	// Each runner service schedules the next tick of every cron as a queue item.
+	// Ticks are enqueued with idempotent job IDs, so when running many services
+	// each tick is only enqueued once, and whichever executor leases the tick
+	// fires it and enqueues the next.
 	if err := s.InitializeCrons(ctx); err != nil {
 		return err
 	}
@@ -206,99 +223,217 @@ func (s *svc) Run(ctx context.Context) error {
 }
 
 func (s *svc) Stop(ctx context.Context) error {
-	if s.cronmanager != nil {
-		cronCtx := s.cronmanager.Stop()
-		select {
-		case <-cronCtx.Done():
-		case <-ctx.Done():
-			return fmt.Errorf("error waiting for scheduled executions to finish")
-		}
-	}
 	return nil
 }
 
+// InitializeCrons enqueues the next tick of every function's cron schedules,
+// plus any ticks missed since the schedule last fired according to its catch-up
+// policy.  This is safe to call repeatedly, eg. each time apps are synced.
 func (s *svc) InitializeCrons(ctx context.Context) error {
-	// If a previous cron manager exists, cancel it.
-	if s.cronmanager != nil {
-		s.cronmanager.Stop()
-	}
-
-	s.cronmanager = cron.New(
-		cron.WithParser(
-			cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow),
-		),
-	)
-
-	// Set the functions within the engine, then iterate through each function's
-	// triggers so that we can easily invoke them.  We also need to immediately
-	// set up cron timers to invoke functions on a schedule.
 	fns, err := s.data.FunctionsScheduled(ctx)
 	if err != nil {
 		return err
 	}
 
-	for _, f := range fns {
-		fn := f
-		// Set up a cron schedule for the current function.
-		for _, t := range f.Triggers {
+	now := time.Now()
+	for _, fn := range fns {
+		for _, t := range fn.Triggers {
 			if t.CronTrigger == nil {
 				continue
 			}
-			cron := t.CronTrigger.Cron
-			_, err := s.cronmanager.AddFunc(cron, func() {
-				// Create a new context to avoid "context canceled" errors. This
-				// callback is run as a non-blocking goroutine in Cron.Start, so
-				// contexts from outside its scope will likely be cancelled
-				// before the function is run
-				ctx := context.Background()
-
-				ctx, span := itrace.UserTracer().Provider().
-					Tracer(consts.OtelScopeCron).
-					Start(ctx, "cron", trace.WithAttributes(
-						attribute.String(consts.OtelSysFunctionID, fn.ID.String()),
-						attribute.Int(consts.OtelSysFunctionVersion, fn.FunctionVersion),
-					))
-				defer span.End()
-
-				trackedEvent := event.NewOSSTrackedEvent(event.Event{
-					Data: map[string]any{
-						"cron": cron,
-					},
-					ID:        time.Now().UTC().Format(time.RFC3339),
-					Name:      event.FnCronName,
-					Timestamp: time.Now().UnixMilli(),
-				})
-
-				byt, err := json.Marshal(trackedEvent)
-				if err == nil {
-					err := s.publisher.Publish(
-						ctx,
-						s.config.EventStream.Service.TopicName(),
-						pubsub.Message{
-							Name:      event.EventReceivedName,
-							Data:      string(byt),
-							Timestamp: time.Now(),
-						},
-					)
-					if err != nil {
-						logger.From(ctx).Error().Err(err).Msg("error publishing cron event")
-					}
-				} else {
-					logger.From(ctx).Error().Err(err).Msg("error marshaling cron event")
-				}
-
-				err = s.initialize(ctx, fn, trackedEvent)
-				if err != nil {
-					logger.From(ctx).Error().Err(err).Msg("error initializing scheduled function")
-				}
-			})
+			ct := *t.CronTrigger
+			p, err := s.cronPayload(ctx, fn, ct)
 			if err != nil {
 				return err
 			}
+			s.catchUpCron(ctx, ct, p, now)
+			if err := s.enqueueNextCron(ctx, ct, p, now); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// FireCron fires the cron tick leased from the queue, first enqueueing the
+// schedule's next tick.  Ticks for schedules which no longer exist are dropped.
+func (s *svc) FireCron(ctx context.Context, item queue.Item) error {
+	raw, ok := item.Payload.(json.RawMessage)
+	if !ok {
+		return fmt.Errorf("unknown cron payload type: %T", item.Payload)
+	}
+	p := cron.Payload{}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return fmt.Errorf("error unmarshalling cron payload: %w", err)
+	}
+
+	l := logger.From(ctx).With().
+		Str("function_id", p.FunctionID.String()).
+		Str("cron", p.Spec).
+		Time("scheduled_at", p.ScheduledAt()).
+		Logger()
+
+	f, err := s.cqrs.GetFunctionByInternalUUID(ctx, p.WorkspaceID, p.FunctionID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !f.ArchivedAt.IsZero()) {
+		// The function was removed since this tick was enqueued.
+		l.Debug().Msg("dropping tick for removed cron function")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error loading cron function: %w", err)
+	}
+	fn, err := f.InngestFunction()
+	if err != nil {
+		return fmt.Errorf("error loading cron function config: %w", err)
+	}
+
+	for _, t := range fn.Triggers {
+		if t.CronTrigger == nil || t.CronTrigger.Spec() != p.Spec {
+			continue
+		}
+		ct := *t.CronTrigger
+		if !p.CatchUp {
+			// Always continue the schedule, even if this tick fails.  Ticks may be
+			// leased shortly before they're scheduled, so the next tick is
+			// calculated from whichever is later.
+			after := p.ScheduledAt()
+			if now := time.Now(); now.After(after) {
+				after = now
+			}
+			if err := s.enqueueNextCron(ctx, ct, p, after); err != nil {
+				return err
+			}
 		}
+		return s.fireCron(ctx, *fn, ct, p.ScheduledAt())
 	}
 
-	s.cronmanager.Start()
+	// The function's schedule changed since this tick was enqueued;  the new
+	// schedule is enqueued when the app is synced.
+	l.Debug().Msg("dropping tick for removed cron schedule")
+	return nil
+}
+
+// cronPayload returns the payload for the function's schedule.  Ticks carry the
+// tenant they were scheduled for, which FireCron loads the function with.
+// Functions and apps aren't scoped to an account or environment in this store,
+// so schedules belong to the single local tenant that events are tracked in.
+func (s *svc) cronPayload(ctx context.Context, fn inngest.Function, ct inngest.CronTrigger) (cron.Payload, error) {
+	p := cron.Payload{
+		AccountID:       consts.DevServerAccountId,
+		WorkspaceID:     consts.DevServerEnvId,
+		FunctionID:      fn.ID,
+		FunctionVersion: fn.FunctionVersion,
+		Spec:            ct.Spec(),
+	}
+	f, err := s.cqrs.GetFunctionByInternalUUID(ctx, p.WorkspaceID, fn.ID)
+	if err != nil {
+		return p, err
+	}
+	p.AppID = f.AppID
+	return p, nil
+}
+
+// enqueueNextCron enqueues the schedule's first tick after the given time.
+func (s *svc) enqueueNextCron(ctx context.Context, ct inngest.CronTrigger, p cron.Payload, after time.Time) error {
+	schedule, err := ct.Schedule()
+	if err != nil {
+		return err
+	}
+	next := schedule.Next(after)
+	if next.IsZero() {
+		return nil
+	}
+	p.At = next.UnixMilli()
+	p.CatchUp = false
+	return cron.Enqueue(ctx, s.queue, p)
+}
+
+// catchUpCron enqueues ticks of the given schedule missed since it last fired,
+// according to the trigger's catch-up policy.
+func (s *svc) catchUpCron(ctx context.Context, ct inngest.CronTrigger, p cron.Payload, now time.Time) {
+	l := logger.From(ctx).With().
+		Str("function_id", p.FunctionID.String()).
+		Str("cron", ct.Cron).
+		Logger()
+
+	last, err := s.data.GetCronLastFired(ctx, p.FunctionID, p.Spec)
+	if err != nil {
+		l.Error().Err(err).Msg("error loading cron last fired time")
+		return
+	}
+	if last == nil {
+		// This schedule has never fired, so there's nothing to catch up on.
+		// Record now so that ticks missed from here on can be caught up.
+		if err := s.data.SetCronLastFired(ctx, p.FunctionID, p.Spec, now); err != nil {
+			l.Error().Err(err).Msg("error saving cron last fired time")
+		}
+		return
+	}
+
+	ticks, err := ct.MissedTicks(*last, now)
+	if err != nil {
+		l.Error().Err(err).Msg("error calculating missed cron ticks")
+		return
+	}
+	if len(ticks) > 0 {
+		l.Info().Int("ticks", len(ticks)).Time("last_fired", *last).Msg("catching up on missed cron ticks")
+	}
+	for _, at := range ticks {
+		p.At = at.UnixMilli()
+		p.CatchUp = true
+		if err := cron.Enqueue(ctx, s.queue, p); err != nil {
+			l.Error().Err(err).Time("scheduled_at", at).Msg("error enqueueing missed cron tick")
+		}
+	}
+}
+
+// fireCron publishes the cron event for the tick scheduled at the given time
+// and initializes the function.
+func (s *svc) fireCron(ctx context.Context, fn inngest.Function, ct inngest.CronTrigger, at time.Time) error {
+	ctx, span := itrace.UserTracer().Provider().
+		Tracer(consts.OtelScopeCron).
+		Start(ctx, "cron", trace.WithAttributes(
+			attribute.String(consts.OtelSysFunctionID, fn.ID.String()),
+			attribute.Int(consts.OtelSysFunctionVersion, fn.FunctionVersion),
+		))
+	defer span.End()
+
+	if err := s.data.SetCronLastFired(ctx, fn.ID, ct.Spec(), at); err != nil {
+		logger.From(ctx).Error().Err(err).Msg("error saving cron last fired time")
+	}
+
+	// The event ID is the scheduled time, so that retrying the tick is
+	// idempotent when initializing the function.
+	trackedEvent := event.NewOSSTrackedEvent(event.Event{
+		Data: map[string]any{
+			"cron": ct.Cron,
+		},
+		ID:        at.UTC().Format(time.RFC3339),
+		Name:      event.FnCronName,
+		Timestamp: at.UnixMilli(),
+	})
+
+	byt, err := json.Marshal(trackedEvent)
+	if err == nil {
+		err := s.publisher.Publish(
+			ctx,
+			s.config.EventStream.Service.TopicName(),
+			pubsub.Message{
+				Name:      event.EventReceivedName,
+				Data:      string(byt),
+				Timestamp: time.Now(),
+			},
+		)
+		if err != nil {
+			logger.From(ctx).Error().Err(err).Msg("error publishing cron event")
+		}
+	} else {
+		logger.From(ctx).Error().Err(err).Msg("error marshaling cron event")
+	}
+
+	if err := s.initialize(ctx, fn, trackedEvent); err != nil {
+		return fmt.Errorf("error initializing scheduled function: %w", err)
+	}
 	return nil
 }
 
@@ -533,36 +668,24 @@ func (s *svc) functions(ctx context.Context, tracked event.TrackedEvent) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			for _, t := range copied.Triggers {
-				// Evaluate all expressions for matching triggers
-				if t.Expression != nil {
-					// Execute expressions here, ensuring that each function is only triggered
-					// under the correct conditions.
-					ok, _, evalerr := expressions.EvaluateBoolean(ctx, *t.Expression, map[string]interface{}{
-						"event": evtMap,
-					})
-					if evalerr != nil {
-						errs = multierror.Append(errs, evalerr)
-						continue
-					}
-					if !ok {
-						// Skip this trigger.
-						continue
-					}
-				}
-
-				// Initialize this function for this event only once;  we don't
-				// want multiple matching triggers to run the function more than once.
-				err := s.initialize(ctx, copied, tracked)
-				if err != nil {
-					logger.From(ctx).Error().
-						Err(err).
-						Str("function", copied.Name).
-						Msg("error initializing fn")
-					errs = multierror.Append(errs, err)
-				}
+			ok, err := matchesTrigger(ctx, copied, evtMap)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			}
+			if !ok {
 				return
 			}
+
+			// Initialize this function for this event only once;  we don't
+			// want multiple matching triggers to run the function more than once.
+			err = s.initialize(ctx, copied, tracked)
+			if err != nil {
+				logger.From(ctx).Error().
+					Err(err).
+					Str("function", copied.Name).
+					Msg("error initializing fn")
+				errs = multierror.Append(errs, err)
+			}
 		}()
 	}
 
@@ -570,6 +693,78 @@ func (s *svc) functions(ctx context.Context, tracked event.TrackedEvent) error {
 	return errs
 }
 
+// Replay re-delivers a stored event to the functions whose triggers currently
+// match it, eg. to backfill a newly created function from historical events.
+// Functions which already ran for the event within consts.FunctionIdempotencyPeriod
+// are not run again;  replaying an event after this period runs them again.
+func (s *svc) Replay(ctx context.Context, tracked event.TrackedEvent, opts ReplayOpts) (ReplayResult, error) {
+	evt := tracked.GetEvent()
+	res := ReplayResult{}
+
+	fns, err := s.data.FunctionsByTrigger(ctx, evt.Name)
+	if err != nil {
+		return res, fmt.Errorf("error loading functions by trigger: %w", err)
+	}
+
+	var (
+		errs   error
+		seen   = map[uuid.UUID]bool{}
+		evtMap = evt.Map()
+	)
+	for _, fn := range fns {
+		// Functions with many matching triggers are listed once per trigger.
+		if seen[fn.ID] || (opts.FunctionID != nil && fn.ID != *opts.FunctionID) {
+			continue
+		}
+		seen[fn.ID] = true
+		ok, err := matchesTrigger(ctx, fn, evtMap)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		if !ok {
+			continue
+		}
+		res.Matched = append(res.Matched, fn)
+		if opts.DryRun {
+			continue
+		}
+		md, err := s.initializeRun(ctx, fn, tracked)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("error initializing %s: %w", fn.GetSlug(), err))
+		}
+		if md != nil {
+			res.Scheduled = append(res.Scheduled, fn)
+		}
+	}
+	return res, errs
+}
+
+// matchesTrigger returns whether any of the function's triggers match the event,
+// evaluating each trigger's expression.
+func matchesTrigger(ctx context.Context, fn inngest.Function, evtMap map[string]any) (bool, error) {
+	var errs error
+	for _, t := range fn.Triggers {
+		// Evaluate all expressions for matching triggers
+		if t.Expression != nil {
+			// Execute expressions here, ensuring that each function is only triggered
+			// under the correct conditions.
+			ok, _, evalerr := expressions.EvaluateBoolean(ctx, *t.Expression, map[string]interface{}{
+				"event": evtMap,
+			})
+			if evalerr != nil {
+				errs = multierror.Append(errs, evalerr)
+				continue
+			}
+			if !ok {
+				// Skip this trigger.
+				continue
+			}
+		}
+		return true, errs
+	}
+	return false, errs
+}
+
 // invokes looks for a pause with the same correlation ID and triggers it
 func (s *svc) invokes(ctx context.Context, evt event.TrackedEvent) error {
 	l := logger.From(ctx).With().
@@ -610,6 +805,14 @@ func (s *svc) pauses(ctx context.Context, evt event.TrackedEvent) error {
 }
 
 func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.TrackedEvent) error {
+	_, err := s.initializeRun(ctx, fn, evt)
+	return err
+}
+
+// initializeRun schedules a run of the function for the event, returning the
+// new run's metadata.  No metadata is returned if a run wasn't scheduled, eg.
+// if the event was batched or a run already exists for the event.
+func (s *svc) initializeRun(ctx context.Context, fn inngest.Function, evt event.TrackedEvent) (*sv2.Metadata, error) {
 	l := logger.From(ctx).With().
 		Str("function", fn.Name).
 		Str("function_id", fn.ID.String()).Logger()
@@ -619,7 +822,7 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 	{
 		fn, err := s.cqrs.GetFunctionByInternalUUID(ctx, wsID, fn.ID)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		appID = fn.AppID
 	}
@@ -636,10 +839,10 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 		}
 
 		if err := s.executor.AppendAndScheduleBatch(ctx, fn, bi, nil); err != nil {
-			return fmt.Errorf("could not append and schedule batch item: %w", err)
+			return nil, fmt.Errorf("could not append and schedule batch item: %w", err)
 		}
 
-		return nil
+		return nil, nil
 	}
 
 	// Attempt to rate-limit the incoming function.
@@ -649,7 +852,7 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 		case nil:
 			limited, _, err := s.rl.RateLimit(ctx, key, *fn.RateLimit)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			if limited {
 				if evt.GetEvent().IsInvokeEvent() {
@@ -667,17 +870,17 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 					}
 				}
 				// Do nothing.
-				return nil
+				return nil, nil
 			}
 		case ratelimit.ErrNotRateLimited:
 			// no-op: proceed with function run as usual
 		default:
-			return err
+			return nil, err
 		}
 	}
 
 	l.Info().Msg("initializing fn")
-	_, err := Initialize(ctx, InitOpts{
+	md, err := Initialize(ctx, InitOpts{
 		appID: appID,
 		fn:    fn,
 		evt:   evt,
@@ -685,12 +888,28 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 	})
 	if err == state.ErrIdentifierExists {
 		// This run exists;  do not attempt to recreate it.
-		return nil
+		return nil, nil
 	}
 	if err == executor.ErrFunctionDebounced {
-		return nil
+		return nil, nil
 	}
-	return err
+	if err == executor.ErrFunctionSingletonSkipped {
+		if evt.GetEvent().IsInvokeEvent() {
+			// As with rate limiting, ensure that the invoker fails instead of
+			// waiting for a run that was never created.
+			if err := s.executor.InvokeFailHandler(ctx, execution.InvokeFailHandlerOpts{
+				OriginalEvent: evt,
+				Err: map[string]any{
+					"name":    "Error",
+					"message": "invoked function was skipped",
+				},
+			}); err != nil {
+				l.Error().Err(err).Msg("error handling invoke skip")
+			}
+		}
+		return nil, nil
+	}
+	return md, err
 }
 
 type InitOpts struct {
@@ -739,6 +958,9 @@ func Initialize(ctx context.Context, opts InitOpts) (*sv2.Metadata, error) {
 		executor.ErrFunctionSkipped,
 		state.ErrIdentifierExists:
 		return nil, nil
+	case executor.ErrFunctionSingletonSkipped:
+		// Returned so that invoking functions can be failed.
+		return nil, err
 	}
 
 	if err != nil {
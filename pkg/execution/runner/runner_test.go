@@ -0,0 +1,370 @@
+package runner
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/config"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/execution/cron"
+	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/execution/state/redis_state"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/khulnasoft/inngest/pkg/pubsub"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFireCronBeforeScheduledTime(t *testing.T) {
+	ctx := context.Background()
+	fn := inngest.Function{
+		ID:       uuid.New(),
+		Name:     "cron",
+		Slug:     "cron",
+		Triggers: []inngest.Trigger{{CronTrigger: &inngest.CronTrigger{Cron: "* * * * *"}}},
+		Steps:    []inngest.Step{{ID: "step", Name: "step", URI: "http://localhost/step"}},
+	}
+	data := newFakeCQRS(t, fn)
+	exec := &fakeExecutor{}
+	q, items := newTestQueue(t)
+	s := newTestService(data, exec, q)
+
+	// The queue may lease a tick before its scheduled time.
+	at := time.Now().Truncate(time.Minute).Add(time.Minute)
+	p := cron.Payload{
+		AccountID:   consts.DevServerAccountId,
+		WorkspaceID: consts.DevServerEnvId,
+		AppID:       data.appID,
+		FunctionID:  fn.ID,
+		Spec:        fn.Triggers[0].CronTrigger.Spec(),
+		At:          at.UnixMilli(),
+	}
+	raw, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	err = s.FireCron(ctx, queue.Item{Kind: queue.KindCron, Payload: json.RawMessage(raw)})
+	require.NoError(t, err)
+	require.Len(t, exec.scheduled(), 1)
+
+	// The next tick is the following minute, not the tick being fired.
+	found := items()
+	require.Len(t, found, 1)
+	next := cron.Payload{}
+	require.NoError(t, json.Unmarshal(found[0].Data.Payload.(json.RawMessage), &next))
+	require.Equal(t, at.Add(time.Minute).UnixMilli(), next.At)
+	require.Equal(t, at.Add(time.Minute).UnixMilli(), found[0].AtMS)
+}
+
+func TestFireCronRemovedFunction(t *testing.T) {
+	ctx := context.Background()
+	fn := inngest.Function{
+		ID:       uuid.New(),
+		Name:     "cron",
+		Slug:     "cron",
+		Triggers: []inngest.Trigger{{CronTrigger: &inngest.CronTrigger{Cron: "* * * * *"}}},
+		Steps:    []inngest.Step{{ID: "step", Name: "step", URI: "http://localhost/step"}},
+	}
+	data := newFakeCQRS(t, fn)
+	exec := &fakeExecutor{}
+	q, items := newTestQueue(t)
+	s := newTestService(data, exec, q)
+
+	fire := func(p cron.Payload) error {
+		raw, err := json.Marshal(p)
+		require.NoError(t, err)
+		return s.FireCron(ctx, queue.Item{Kind: queue.KindCron, Payload: json.RawMessage(raw)})
+	}
+	p := cron.Payload{
+		AccountID:   consts.DevServerAccountId,
+		WorkspaceID: consts.DevServerEnvId,
+		FunctionID:  fn.ID,
+		Spec:        fn.Triggers[0].CronTrigger.Spec(),
+		At:          time.Now().Truncate(time.Minute).UnixMilli(),
+	}
+
+	t.Run("changed schedule", func(t *testing.T) {
+		changed := p
+		changed.Spec = "CRON_TZ=UTC 0 * * * *"
+		require.NoError(t, fire(changed))
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		unknown := p
+		unknown.FunctionID = uuid.New()
+		require.NoError(t, fire(unknown))
+	})
+
+	t.Run("archived function", func(t *testing.T) {
+		data.archive(fn.ID)
+		require.NoError(t, fire(p))
+	})
+
+	require.Empty(t, exec.scheduled())
+	require.Empty(t, items())
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	step := []inngest.Step{{ID: "step", Name: "step", URI: "http://localhost/step"}}
+	expr := "event.data.total > 100"
+	all := inngest.Function{
+		ID:       uuid.New(),
+		Name:     "all",
+		Slug:     "app-all",
+		Triggers: []inngest.Trigger{{EventTrigger: &inngest.EventTrigger{Event: "order/created"}}},
+		Steps:    step,
+	}
+	large := inngest.Function{
+		ID:       uuid.New(),
+		Name:     "large",
+		Slug:     "app-large",
+		Triggers: []inngest.Trigger{{EventTrigger: &inngest.EventTrigger{Event: "order/created", Expression: &expr}}},
+		Steps:    step,
+	}
+	data := newFakeCQRS(t, all, large)
+	exec := &fakeExecutor{}
+	q, _ := newTestQueue(t)
+	s := newTestService(data, exec, q)
+
+	newEvent := func(total int) event.TrackedEvent {
+		return event.NewOSSTrackedEvent(event.Event{
+			ID:   ulid.Make().String(),
+			Name: "order/created",
+			Data: map[string]any{"total": total},
+		})
+	}
+	slugs := func(fns []inngest.Function) []string {
+		res := []string{}
+		for _, fn := range fns {
+			res = append(res, fn.Slug)
+		}
+		return res
+	}
+
+	t.Run("dry runs match functions without scheduling", func(t *testing.T) {
+		res, err := s.Replay(ctx, newEvent(200), ReplayOpts{DryRun: true})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"app-all", "app-large"}, slugs(res.Matched))
+		require.Empty(t, res.Scheduled)
+		require.Empty(t, exec.scheduled())
+	})
+
+	t.Run("trigger expressions are evaluated", func(t *testing.T) {
+		res, err := s.Replay(ctx, newEvent(50), ReplayOpts{DryRun: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"app-all"}, slugs(res.Matched))
+	})
+
+	t.Run("replays are limited to the given function", func(t *testing.T) {
+		res, err := s.Replay(ctx, newEvent(200), ReplayOpts{DryRun: true, FunctionID: &large.ID})
+		require.NoError(t, err)
+		require.Equal(t, []string{"app-large"}, slugs(res.Matched))
+	})
+
+	t.Run("functions which already ran aren't scheduled again", func(t *testing.T) {
+		evt := newEvent(200)
+		res, err := s.Replay(ctx, evt, ReplayOpts{})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"app-all", "app-large"}, slugs(res.Scheduled))
+		require.Len(t, exec.scheduled(), 2)
+
+		res, err = s.Replay(ctx, evt, ReplayOpts{})
+		require.NoError(t, err)
+		require.Len(t, res.Matched, 2)
+		require.Empty(t, res.Scheduled)
+	})
+}
+
+func newTestService(data cqrs.Manager, exec execution.Executor, q queue.Queue) *svc {
+	return NewService(
+		config.Config{
+			EventStream: config.EventStream{
+				Service: config.MessagingService{
+					Backend:  config.MessagingInMemory,
+					Concrete: &config.InMemoryMessaging{Topic: "events"},
+				},
+			},
+		},
+		WithCQRS(data),
+		WithExecutionManager(data),
+		WithExecutor(exec),
+		WithRunnerQueue(q),
+		WithPublisher(&fakePublisher{}),
+	).(*svc)
+}
+
+// newTestQueue returns a redis queue along with a function listing its items.
+func newTestQueue(t *testing.T) (queue.Queue, func() []queue.QueueItem) {
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(rc.Close)
+
+	client := redis_state.NewQueueClient(rc, redis_state.QueueDefaultKey)
+	q := redis_state.NewQueue(redis_state.QueueShard{
+		Kind:        string(enums.QueueShardKindRedis),
+		RedisClient: client,
+		Name:        consts.DefaultQueueShardName,
+	})
+
+	items := func() []queue.QueueItem {
+		all := []queue.QueueItem{}
+		key := client.KeyGenerator().QueueItem()
+		if !r.Exists(key) {
+			return all
+		}
+		fields, err := r.HKeys(key)
+		require.NoError(t, err)
+		for _, field := range fields {
+			qi := queue.QueueItem{}
+			require.NoError(t, json.Unmarshal([]byte(r.HGet(key, field)), &qi))
+			all = append(all, qi)
+		}
+		return all
+	}
+	return q, items
+}
+
+// fakeCQRS serves the given functions, implementing the subset of cqrs.Manager
+// used by the runner.
+type fakeCQRS struct {
+	cqrs.Manager
+
+	appID uuid.UUID
+
+	l         sync.Mutex
+	config    map[uuid.UUID]inngest.Function
+	fns       map[uuid.UUID]*cqrs.Function
+	lastFired map[string]time.Time
+}
+
+func newFakeCQRS(t *testing.T, fns ...inngest.Function) *fakeCQRS {
+	f := &fakeCQRS{
+		appID:     uuid.New(),
+		config:    map[uuid.UUID]inngest.Function{},
+		fns:       map[uuid.UUID]*cqrs.Function{},
+		lastFired: map[string]time.Time{},
+	}
+	for _, fn := range fns {
+		f.config[fn.ID] = fn
+		config, err := json.Marshal(fn)
+		require.NoError(t, err)
+		f.fns[fn.ID] = &cqrs.Function{
+			ID:     fn.ID,
+			AppID:  f.appID,
+			Slug:   fn.Slug,
+			Name:   fn.Name,
+			Config: config,
+		}
+	}
+	return f
+}
+
+func (f *fakeCQRS) archive(fnID uuid.UUID) {
+	f.l.Lock()
+	defer f.l.Unlock()
+	f.fns[fnID].ArchivedAt = time.Now()
+}
+
+func (f *fakeCQRS) GetFunctionByInternalUUID(ctx context.Context, wsID, fnID uuid.UUID) (*cqrs.Function, error) {
+	f.l.Lock()
+	defer f.l.Unlock()
+	fn, ok := f.fns[fnID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *fn
+	return &copied, nil
+}
+
+func (f *fakeCQRS) FunctionsByTrigger(ctx context.Context, eventName string) ([]inngest.Function, error) {
+	f.l.Lock()
+	defer f.l.Unlock()
+	res := []inngest.Function{}
+	for _, fn := range f.config {
+		for _, t := range fn.Triggers {
+			if t.EventTrigger != nil && t.Event == eventName {
+				res = append(res, fn)
+			}
+		}
+	}
+	return res, nil
+}
+
+func (f *fakeCQRS) GetCronLastFired(ctx context.Context, fnID uuid.UUID, spec string) (*time.Time, error) {
+	f.l.Lock()
+	defer f.l.Unlock()
+	last, ok := f.lastFired[fnID.String()+spec]
+	if !ok {
+		return nil, nil
+	}
+	return &last, nil
+}
+
+func (f *fakeCQRS) SetCronLastFired(ctx context.Context, fnID uuid.UUID, spec string, at time.Time) error {
+	f.l.Lock()
+	defer f.l.Unlock()
+	f.lastFired[fnID.String()+spec] = at
+	return nil
+}
+
+// fakeExecutor records scheduled runs, implementing the subset of
+// execution.Executor used by the runner.
+type fakeExecutor struct {
+	execution.Executor
+
+	l    sync.Mutex
+	reqs []execution.ScheduleRequest
+	// keys records the idempotency keys of scheduled runs.
+	keys map[string]bool
+	// err, if set, is returned when scheduling runs.
+	err error
+}
+
+func (e *fakeExecutor) Schedule(ctx context.Context, r execution.ScheduleRequest) (*sv2.Metadata, error) {
+	e.l.Lock()
+	defer e.l.Unlock()
+	if r.IdempotencyKey != nil {
+		key := r.Function.ID.String() + *r.IdempotencyKey
+		if e.keys[key] {
+			return nil, state.ErrIdentifierExists
+		}
+		if e.keys == nil {
+			e.keys = map[string]bool{}
+		}
+		e.keys[key] = true
+	}
+	e.reqs = append(e.reqs, r)
+	if e.err != nil {
+		return nil, e.err
+	}
+	return &sv2.Metadata{}, nil
+}
+
+func (e *fakeExecutor) scheduled() []execution.ScheduleRequest {
+	e.l.Lock()
+	defer e.l.Unlock()
+	return append([]execution.ScheduleRequest{}, e.reqs...)
+}
+
+type fakePublisher struct{}
+
+func (fakePublisher) Publish(ctx context.Context, topic string, m pubsub.Message) error {
+	return nil
+}
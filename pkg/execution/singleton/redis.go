@@ -0,0 +1,88 @@
+package singleton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+)
+
+const (
+	redisReleaseScript = `
+if redis.call('get', KEYS[1]) == ARGV[1] then
+  return redis.call('del', KEYS[1])
+end
+return 0
+`
+)
+
+func New(r rueidis.Client, prefix string) Singleton {
+	return &redisSingleton{
+		r:             r,
+		releaseScript: rueidis.NewLuaScript(redisReleaseScript),
+		prefix:        prefix,
+	}
+}
+
+type redisSingleton struct {
+	r             rueidis.Client
+	releaseScript *rueidis.Lua
+
+	prefix string
+}
+
+func (r *redisSingleton) Claim(ctx context.Context, key string, runID ulid.ULID) (*ulid.ULID, error) {
+	key = r.prefix + key
+
+	set, err := r.r.Do(ctx, r.r.B().Setnx().Key(key).Value(runID.String()).Build()).AsBool()
+	if err != nil {
+		return nil, fmt.Errorf("error claiming singleton key: %w", err)
+	}
+	if set {
+		return nil, nil
+	}
+
+	val, err := r.r.Do(ctx, r.r.B().Get().Key(key).Build()).ToString()
+	if rueidis.IsRedisNil(err) {
+		// The key was released in the meantime, so try again.
+		return r.Claim(ctx, key[len(r.prefix):], runID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading singleton key: %w", err)
+	}
+
+	return parseRunID(val)
+}
+
+func (r *redisSingleton) Replace(ctx context.Context, key string, runID ulid.ULID) (*ulid.ULID, error) {
+	key = r.prefix + key
+
+	val, err := r.r.Do(ctx, r.r.B().Getset().Key(key).Value(runID.String()).Build()).ToString()
+	if rueidis.IsRedisNil(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error replacing singleton key: %w", err)
+	}
+
+	return parseRunID(val)
+}
+
+func (r *redisSingleton) Release(ctx context.Context, key string, runID ulid.ULID) error {
+	key = r.prefix + key
+
+	err := r.releaseScript.Exec(ctx, r.r, []string{key}, []string{runID.String()}).Error()
+	if err != nil {
+		return fmt.Errorf("error releasing singleton key: %w", err)
+	}
+	return nil
+}
+
+func parseRunID(val string) (*ulid.ULID, error) {
+	id, err := ulid.Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid run ID for singleton key: %w", err)
+	}
+	return &id, nil
+}
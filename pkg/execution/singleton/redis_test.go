@@ -0,0 +1,62 @@
+package singleton
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisSingleton(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	s := New(rc, "{singleton}:")
+	first := ulid.MustNew(ulid.Now(), rand.Reader)
+	second := ulid.MustNew(ulid.Now(), rand.Reader)
+
+	t.Run("the first run claims the key", func(t *testing.T) {
+		existing, err := s.Claim(ctx, "key", first)
+		require.NoError(t, err)
+		require.Nil(t, existing)
+	})
+
+	t.Run("further claims return the current run", func(t *testing.T) {
+		existing, err := s.Claim(ctx, "key", second)
+		require.NoError(t, err)
+		require.Equal(t, &first, existing)
+	})
+
+	t.Run("releasing with another run does not remove the key", func(t *testing.T) {
+		require.NoError(t, s.Release(ctx, "key", second))
+		existing, err := s.Claim(ctx, "key", second)
+		require.NoError(t, err)
+		require.Equal(t, &first, existing)
+	})
+
+	t.Run("replacing returns the previous run", func(t *testing.T) {
+		previous, err := s.Replace(ctx, "key", second)
+		require.NoError(t, err)
+		require.Equal(t, &first, previous)
+
+		previous, err = s.Replace(ctx, "other", first)
+		require.NoError(t, err)
+		require.Nil(t, previous)
+	})
+
+	t.Run("released keys can be claimed again", func(t *testing.T) {
+		require.NoError(t, s.Release(ctx, "key", second))
+		existing, err := s.Claim(ctx, "key", first)
+		require.NoError(t, err)
+		require.Nil(t, existing)
+	})
+}
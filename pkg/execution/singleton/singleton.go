@@ -0,0 +1,52 @@
+package singleton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/expressions"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/khulnasoft/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
+)
+
+var (
+	ErrEvaluatingSingletonExpression = fmt.Errorf("singleton expression evaluation failed")
+	ErrNotSingleton                  = fmt.Errorf("not singleton")
+)
+
+// Singleton tracks the run currently in progress for each singleton key.
+type Singleton interface {
+	// Claim stores the run as the current run for the key if no other run holds the key.
+	// If another run holds the key, its ID is returned and the key is left unchanged.
+	Claim(ctx context.Context, key string, runID ulid.ULID) (*ulid.ULID, error)
+	// Replace stores the run as the current run for the key, returning the ID of the run
+	// which previously held the key, if any.
+	Replace(ctx context.Context, key string, runID ulid.ULID) (*ulid.ULID, error)
+	// Release removes the key if it's still held by the given run.
+	Release(ctx context.Context, key string, runID ulid.ULID) error
+}
+
+// SingletonKey returns the singleton key given a function ID, singleton config, and
+// incoming event data.
+func SingletonKey(ctx context.Context, id uuid.UUID, c inngest.Singleton, evt map[string]any) (string, error) {
+	if c.Key == nil {
+		return id.String(), nil
+	}
+	eval, err := expressions.NewExpressionEvaluator(ctx, *c.Key)
+	if err != nil {
+		return "", ErrEvaluatingSingletonExpression
+	}
+	res, _, err := eval.Evaluate(ctx, expressions.NewData(map[string]any{"event": evt}))
+	if err != nil {
+		return "", ErrEvaluatingSingletonExpression
+	}
+	if v, ok := res.(bool); ok && !v {
+		return "", ErrNotSingleton
+	}
+
+	// Take a checksum of this data.  It doesn't matter if this is a map or a string;
+	// as long as we're consistent here.
+	return fmt.Sprintf("%s-%s", id, util.XXHash(res)), nil
+}
@@ -0,0 +1,118 @@
+// Package sink exports function and step lifecycle events to external systems,
+// such as webhooks, NATS subjects or Kafka topics, as versioned JSON envelopes.
+package sink
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// Version is the version of the envelope schema.  Fields may be added to the
+// envelope without changing the version, but fields are never removed or
+// changed within a version.
+const Version = "v1"
+
+// EventType is the type of lifecycle event contained within an envelope.
+type EventType string
+
+const (
+	EventFunctionScheduled = EventType("function.scheduled")
+	EventFunctionSkipped   = EventType("function.skipped")
+	EventFunctionStarted   = EventType("function.started")
+	EventFunctionFinished  = EventType("function.finished")
+	EventFunctionCancelled = EventType("function.cancelled")
+	EventStepScheduled     = EventType("step.scheduled")
+	EventStepStarted       = EventType("step.started")
+	EventStepFinished      = EventType("step.finished")
+)
+
+// EventTypes lists every event type exported.
+var EventTypes = []EventType{
+	EventFunctionScheduled,
+	EventFunctionSkipped,
+	EventFunctionStarted,
+	EventFunctionFinished,
+	EventFunctionCancelled,
+	EventStepScheduled,
+	EventStepStarted,
+	EventStepFinished,
+}
+
+// Status is the outcome of a run or step.
+type Status string
+
+const (
+	StatusCompleted = Status("completed")
+	StatusFailed    = Status("failed")
+	StatusCancelled = Status("cancelled")
+	StatusSkipped   = Status("skipped")
+	// StatusErrored indicates that a step errored and will be retried.
+	StatusErrored = Status("errored")
+)
+
+// Envelope wraps a single lifecycle event.
+type Envelope struct {
+	// ID uniquely identifies the envelope.  Envelopes may be delivered more than
+	// once when retried, so consumers should deduplicate using the ID.
+	ID ulid.ULID `json:"id"`
+	// Version is the envelope schema version.
+	Version string `json:"version"`
+	// Type is the lifecycle event type.
+	Type EventType `json:"type"`
+	// Timestamp is the time that the lifecycle event occurred.
+	Timestamp time.Time `json:"ts"`
+
+	AccountID       uuid.UUID   `json:"account_id"`
+	EnvID           uuid.UUID   `json:"env_id"`
+	AppID           uuid.UUID   `json:"app_id"`
+	FunctionID      uuid.UUID   `json:"function_id"`
+	FunctionSlug    string      `json:"function_slug,omitempty"`
+	FunctionVersion int         `json:"function_version"`
+	RunID           ulid.ULID   `json:"run_id"`
+	EventIDs        []ulid.ULID `json:"event_ids,omitempty"`
+	BatchID         *ulid.ULID  `json:"batch_id,omitempty"`
+	// Attempt is the zero-indexed attempt of the run or step.
+	Attempt int `json:"attempt"`
+
+	// Run contains the outcome of the run for function.finished,
+	// function.cancelled and function.skipped events.
+	Run *Run `json:"run,omitempty"`
+	// Step contains the step for step events.
+	Step *Step `json:"step,omitempty"`
+}
+
+// Run is the outcome of a run.
+type Run struct {
+	Status Status `json:"status"`
+	// Output is the run's output, if completed.
+	Output json.RawMessage `json:"output,omitempty"`
+	// Error is the run's error, if failed.
+	Error *Error `json:"error,omitempty"`
+	// StepCount is the number of steps completed by the run.
+	StepCount int `json:"step_count"`
+	// SkipReason is the reason the run was skipped.
+	SkipReason string `json:"skip_reason,omitempty"`
+	// CancelEventID is the ID of the event which cancelled the run, if cancelled
+	// by an event.
+	CancelEventID *ulid.ULID `json:"cancel_event_id,omitempty"`
+}
+
+// Step describes a step within a run.
+type Step struct {
+	// ID is the hashed step ID.
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// Status is set for step.finished events.
+	Status     Status `json:"status,omitempty"`
+	DurationMS int64  `json:"duration_ms,omitempty"`
+	Error      *Error `json:"error,omitempty"`
+}
+
+// Error is an error returned by a run or step.
+type Error struct {
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
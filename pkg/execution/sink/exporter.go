@@ -0,0 +1,297 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/logger"
+	"github.com/khulnasoft/inngest/pkg/telemetry/metrics"
+)
+
+const (
+	pkgName = "sink.execution.inngest"
+
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = time.Second
+	DefaultBufferSize    = 10_000
+	DefaultMaxAttempts   = 5
+)
+
+// Sink delivers batches of envelopes to an external system.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+	// Send delivers the envelopes, in order.  Returning an error retries the
+	// whole batch unless the error is wrapped via Permanent.
+	Send(ctx context.Context, envs []Envelope) error
+	// Close closes any connections held by the sink.
+	Close(ctx context.Context) error
+}
+
+// Permanent wraps an error to indicate that sending a batch can never succeed,
+// so the batch is dropped without retrying.
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+type permanentError struct {
+	error
+}
+
+func (p permanentError) Unwrap() error {
+	return p.error
+}
+
+// ExporterOpts configures how envelopes are batched and retried.
+type ExporterOpts struct {
+	// BatchSize is the maximum number of envelopes sent in a single batch.
+	// Defaults to DefaultBatchSize.
+	BatchSize int
+	// FlushInterval is the maximum time an envelope is buffered before its
+	// batch is sent.  Defaults to DefaultFlushInterval.
+	FlushInterval time.Duration
+	// BufferSize is the number of envelopes buffered for each sink.  Envelopes
+	// are dropped once the buffer is full, so that slow sinks never block the
+	// executor.  Defaults to DefaultBufferSize.
+	BufferSize int
+	// MaxAttempts is the number of times a batch is sent before it's dropped.
+	// Defaults to DefaultMaxAttempts.
+	MaxAttempts int
+	// Backoff returns the delay before retrying a batch after the given
+	// attempt, starting at 1.  Defaults to an exponential backoff from 500ms
+	// up to 30s.
+	Backoff func(attempt int) time.Duration
+}
+
+func (o ExporterOpts) withDefaults() ExporterOpts {
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = DefaultFlushInterval
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = DefaultBufferSize
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.Backoff == nil {
+		o.Backoff = defaultBackoff
+	}
+	return o
+}
+
+func defaultBackoff(attempt int) time.Duration {
+	if attempt > 6 {
+		return 30 * time.Second
+	}
+	return (500 * time.Millisecond) << (attempt - 1)
+}
+
+// NewExporter returns an Exporter which batches envelopes and sends them to each
+// sink.  Each sink is sent to independently, so that a failing sink doesn't
+// delay delivery to the others.
+func NewExporter(opts ExporterOpts, sinks ...Sink) *Exporter {
+	opts = opts.withDefaults()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &Exporter{cancel: cancel}
+	for _, s := range sinks {
+		w := &worker{
+			sink: s,
+			opts: opts,
+			ch:   make(chan Envelope, opts.BufferSize),
+			done: make(chan struct{}),
+		}
+		e.workers = append(e.workers, w)
+		go w.run(ctx)
+	}
+	return e
+}
+
+// Exporter buffers envelopes and delivers them to sinks in batches.
+type Exporter struct {
+	workers []*worker
+	cancel  context.CancelFunc
+
+	l      sync.RWMutex
+	closed bool
+}
+
+// Export buffers the envelope for delivery to every sink, without blocking.
+func (e *Exporter) Export(ctx context.Context, env Envelope) {
+	e.l.RLock()
+	defer e.l.RUnlock()
+	if e.closed {
+		return
+	}
+
+	for _, w := range e.workers {
+		select {
+		case w.ch <- env:
+		default:
+			logger.StdlibLogger(ctx).Warn(
+				"lifecycle sink buffer full, dropping envelope",
+				"sink", w.sink.Name(),
+				"type", env.Type,
+				"run_id", env.RunID,
+			)
+			w.record(ctx, "dropped", 1)
+		}
+	}
+}
+
+// Close flushes buffered envelopes and closes every sink.  If the context is
+// cancelled before flushing completes, in-flight retries are abandoned.
+func (e *Exporter) Close(ctx context.Context) error {
+	e.l.Lock()
+	if e.closed {
+		e.l.Unlock()
+		return nil
+	}
+	e.closed = true
+	for _, w := range e.workers {
+		close(w.ch)
+	}
+	e.l.Unlock()
+
+	var err error
+	for _, w := range e.workers {
+		select {
+		case <-w.done:
+		case <-ctx.Done():
+			e.cancel()
+			<-w.done
+		}
+		err = errors.Join(err, w.sink.Close(ctx))
+	}
+	e.cancel()
+	return err
+}
+
+type worker struct {
+	sink Sink
+	opts ExporterOpts
+	ch   chan Envelope
+	done chan struct{}
+}
+
+func (w *worker) run(ctx context.Context) {
+	defer close(w.done)
+
+	t := time.NewTicker(w.opts.FlushInterval)
+	defer t.Stop()
+
+	batch := make([]Envelope, 0, w.opts.BatchSize)
+	for {
+		select {
+		case env, ok := <-w.ch:
+			if !ok {
+				w.flush(ctx, batch)
+				return
+			}
+			batch = append(batch, env)
+			if len(batch) < w.opts.BatchSize {
+				continue
+			}
+		case <-t.C:
+		}
+
+		w.flush(ctx, batch)
+		batch = make([]Envelope, 0, w.opts.BatchSize)
+	}
+}
+
+// flush sends the batch, retrying with backoff until it's sent or the maximum
+// number of attempts is reached.
+func (w *worker) flush(ctx context.Context, batch []Envelope) {
+	if len(batch) == 0 {
+		return
+	}
+
+	l := logger.StdlibLogger(ctx).With("sink", w.sink.Name(), "size", len(batch))
+	for attempt := 1; ; attempt++ {
+		err := w.sink.Send(ctx, batch)
+		if err == nil {
+			w.record(ctx, "success", len(batch))
+			return
+		}
+
+		var perr permanentError
+		if errors.As(err, &perr) || attempt >= w.opts.MaxAttempts || ctx.Err() != nil {
+			l.Error("error sending lifecycle envelopes, dropping batch", "error", err, "attempt", attempt)
+			w.record(ctx, "error", len(batch))
+			return
+		}
+		l.Warn("error sending lifecycle envelopes, retrying", "error", err, "attempt", attempt)
+
+		select {
+		case <-time.After(w.opts.Backoff(attempt)):
+		case <-ctx.Done():
+			l.Error("lifecycle sink closed before batch was sent, dropping batch", "error", ctx.Err())
+			w.record(ctx, "error", len(batch))
+			return
+		}
+	}
+}
+
+func (w *worker) record(ctx context.Context, status string, n int) {
+	metrics.IncrLifecycleSinkEnvelopesCounter(context.WithoutCancel(ctx), int64(n), metrics.CounterOpt{
+		PkgName: pkgName,
+		Tags: map[string]any{
+			"sink":   w.sink.Name(),
+			"status": status,
+		},
+	})
+}
+
+// Filter returns a sink which only sends envelopes of the given types to s.  If
+// no types are given, s is returned.
+func Filter(s Sink, types ...EventType) Sink {
+	if len(types) == 0 {
+		return s
+	}
+	allowed := map[EventType]bool{}
+	for _, t := range types {
+		allowed[t] = true
+	}
+	return filtered{Sink: s, types: allowed}
+}
+
+type filtered struct {
+	Sink
+	types map[EventType]bool
+}
+
+func (f filtered) Send(ctx context.Context, envs []Envelope) error {
+	matched := make([]Envelope, 0, len(envs))
+	for _, env := range envs {
+		if f.types[env.Type] {
+			matched = append(matched, env)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	return f.Sink.Send(ctx, matched)
+}
+
+// ParseEventTypes parses a list of event types, eg. from configuration.
+func ParseEventTypes(types []string) ([]EventType, error) {
+	parsed := make([]EventType, 0, len(types))
+	for _, t := range types {
+		found := false
+		for _, et := range EventTypes {
+			found = found || string(et) == t
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown lifecycle event type: %s", t)
+		}
+		parsed = append(parsed, EventType(t))
+	}
+	return parsed, nil
+}
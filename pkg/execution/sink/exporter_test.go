@@ -0,0 +1,209 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSink records batches, failing the first n sends with err.
+type fakeSink struct {
+	l       sync.Mutex
+	batches [][]Envelope
+	fails   int
+	err     error
+	sends   int
+	closed  bool
+}
+
+func (f *fakeSink) Name() string {
+	return "fake"
+}
+
+func (f *fakeSink) Send(ctx context.Context, envs []Envelope) error {
+	f.l.Lock()
+	defer f.l.Unlock()
+	f.sends++
+	if f.sends <= f.fails {
+		return f.err
+	}
+	f.batches = append(f.batches, envs)
+	return nil
+}
+
+func (f *fakeSink) Close(ctx context.Context) error {
+	f.l.Lock()
+	defer f.l.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeSink) received() []Envelope {
+	f.l.Lock()
+	defer f.l.Unlock()
+	all := []Envelope{}
+	for _, b := range f.batches {
+		all = append(all, b...)
+	}
+	return all
+}
+
+func envelope(t EventType) Envelope {
+	return Envelope{ID: ulid.Make(), Version: Version, Type: t, RunID: ulid.Make()}
+}
+
+func noBackoff(int) time.Duration {
+	return time.Millisecond
+}
+
+func TestExporterBatches(t *testing.T) {
+	ctx := context.Background()
+	s := &fakeSink{}
+	e := NewExporter(ExporterOpts{BatchSize: 2, FlushInterval: time.Hour}, s)
+
+	for i := 0; i < 5; i++ {
+		e.Export(ctx, envelope(EventFunctionStarted))
+	}
+
+	// Full batches are sent immediately, without waiting for the interval.
+	require.Eventually(t, func() bool {
+		return len(s.received()) == 4
+	}, time.Second, 5*time.Millisecond)
+
+	// Closing flushes the remaining partial batch.
+	require.NoError(t, e.Close(ctx))
+	require.Len(t, s.received(), 5)
+	require.Len(t, s.batches, 3)
+	require.True(t, s.closed)
+
+	// Envelopes exported after closing are ignored.
+	e.Export(ctx, envelope(EventFunctionStarted))
+	require.Len(t, s.received(), 5)
+}
+
+func TestExporterFlushInterval(t *testing.T) {
+	ctx := context.Background()
+	s := &fakeSink{}
+	e := NewExporter(ExporterOpts{BatchSize: 100, FlushInterval: 10 * time.Millisecond}, s)
+	defer e.Close(ctx)
+
+	e.Export(ctx, envelope(EventFunctionStarted))
+	require.Eventually(t, func() bool {
+		return len(s.received()) == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestExporterRetries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries until sent", func(t *testing.T) {
+		s := &fakeSink{fails: 2, err: fmt.Errorf("unavailable")}
+		e := NewExporter(ExporterOpts{MaxAttempts: 3, Backoff: noBackoff}, s)
+		e.Export(ctx, envelope(EventFunctionFinished))
+		require.NoError(t, e.Close(ctx))
+
+		require.Equal(t, 3, s.sends)
+		require.Len(t, s.received(), 1)
+	})
+
+	t.Run("drops after max attempts", func(t *testing.T) {
+		s := &fakeSink{fails: 3, err: fmt.Errorf("unavailable")}
+		e := NewExporter(ExporterOpts{MaxAttempts: 3, Backoff: noBackoff}, s)
+		e.Export(ctx, envelope(EventFunctionFinished))
+		require.NoError(t, e.Close(ctx))
+
+		require.Equal(t, 3, s.sends)
+		require.Empty(t, s.received())
+	})
+
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		s := &fakeSink{fails: 1, err: Permanent(fmt.Errorf("bad request"))}
+		e := NewExporter(ExporterOpts{MaxAttempts: 3, Backoff: noBackoff}, s)
+		e.Export(ctx, envelope(EventFunctionFinished))
+		require.NoError(t, e.Close(ctx))
+
+		require.Equal(t, 1, s.sends)
+		require.Empty(t, s.received())
+	})
+
+	t.Run("closing abandons retries once the context is done", func(t *testing.T) {
+		s := &fakeSink{fails: 10, err: fmt.Errorf("unavailable")}
+		e := NewExporter(ExporterOpts{
+			MaxAttempts: 10,
+			Backoff:     func(int) time.Duration { return time.Hour },
+		}, s)
+		e.Export(ctx, envelope(EventFunctionFinished))
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		require.NoError(t, e.Close(ctx))
+		require.Empty(t, s.received())
+	})
+}
+
+func TestExporterBufferFull(t *testing.T) {
+	ctx := context.Background()
+
+	// The sink blocks until released, so that the buffer fills.
+	release := make(chan struct{})
+	s := &blockingSink{fakeSink: &fakeSink{}, release: release}
+	e := NewExporter(ExporterOpts{BatchSize: 1, BufferSize: 1}, s)
+
+	for i := 0; i < 10; i++ {
+		e.Export(ctx, envelope(EventStepStarted))
+	}
+	close(release)
+	require.NoError(t, e.Close(ctx))
+
+	// One envelope is being sent, and one is buffered.  The rest are dropped.
+	n := len(s.received())
+	require.GreaterOrEqual(t, n, 1)
+	require.LessOrEqual(t, n, 3)
+}
+
+type blockingSink struct {
+	*fakeSink
+	release chan struct{}
+}
+
+func (b *blockingSink) Send(ctx context.Context, envs []Envelope) error {
+	<-b.release
+	return b.fakeSink.Send(ctx, envs)
+}
+
+func TestFilter(t *testing.T) {
+	ctx := context.Background()
+	s := &fakeSink{}
+	f := Filter(s, EventFunctionFinished, EventFunctionCancelled)
+
+	require.NoError(t, f.Send(ctx, []Envelope{
+		envelope(EventFunctionStarted),
+		envelope(EventFunctionFinished),
+		envelope(EventStepFinished),
+		envelope(EventFunctionCancelled),
+	}))
+	received := s.received()
+	require.Len(t, received, 2)
+	require.Equal(t, EventFunctionFinished, received[0].Type)
+	require.Equal(t, EventFunctionCancelled, received[1].Type)
+
+	// Batches without matching envelopes aren't sent.
+	require.NoError(t, f.Send(ctx, []Envelope{envelope(EventStepStarted)}))
+	require.Equal(t, 1, s.sends)
+
+	require.Equal(t, Sink(s), Filter(s))
+}
+
+func TestParseEventTypes(t *testing.T) {
+	types, err := ParseEventTypes([]string{"function.finished", "step.started"})
+	require.NoError(t, err)
+	require.Equal(t, []EventType{EventFunctionFinished, EventStepStarted}, types)
+
+	_, err = ParseEventTypes([]string{"function.unknown"})
+	require.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+)
+
+// NewLifecycleListener returns a lifecycle listener which exports function and
+// step lifecycle events via the exporter.
+func NewLifecycleListener(e *Exporter) execution.LifecycleListener {
+	return lifecycle{e: e}
+}
+
+type lifecycle struct {
+	execution.NoopLifecyceListener
+
+	e *Exporter
+}
+
+func (l lifecycle) Close(ctx context.Context) error {
+	return l.e.Close(ctx)
+}
+
+func (l lifecycle) OnFunctionScheduled(
+	ctx context.Context,
+	md sv2.Metadata,
+	item queue.Item,
+	_ []event.TrackedEvent,
+) {
+	l.e.Export(ctx, newEnvelope(EventFunctionScheduled, md, item.Attempt))
+}
+
+func (l lifecycle) OnFunctionSkipped(
+	ctx context.Context,
+	md sv2.Metadata,
+	s execution.SkipState,
+) {
+	env := newEnvelope(EventFunctionSkipped, md, 0)
+	env.Run = &Run{
+		Status:     StatusSkipped,
+		SkipReason: s.Reason.String(),
+	}
+	l.e.Export(ctx, env)
+}
+
+func (l lifecycle) OnFunctionStarted(
+	ctx context.Context,
+	md sv2.Metadata,
+	item queue.Item,
+	_ []json.RawMessage,
+) {
+	l.e.Export(ctx, newEnvelope(EventFunctionStarted, md, item.Attempt))
+}
+
+func (l lifecycle) OnFunctionFinished(
+	ctx context.Context,
+	md sv2.Metadata,
+	item queue.Item,
+	_ []json.RawMessage,
+	resp state.DriverResponse,
+) {
+	env := newEnvelope(EventFunctionFinished, md, item.Attempt)
+	env.Run = &Run{
+		Status:    StatusCompleted,
+		StepCount: md.Metrics.StepCount,
+	}
+	if resp.Err != nil {
+		env.Run.Status = StatusFailed
+		env.Run.Error = responseError(resp)
+	} else {
+		env.Run.Output = output(resp.Output)
+	}
+	l.e.Export(ctx, env)
+}
+
+func (l lifecycle) OnFunctionCancelled(
+	ctx context.Context,
+	md sv2.Metadata,
+	req execution.CancelRequest,
+	_ []json.RawMessage,
+) {
+	env := newEnvelope(EventFunctionCancelled, md, 0)
+	env.Run = &Run{
+		Status:        StatusCancelled,
+		StepCount:     md.Metrics.StepCount,
+		CancelEventID: req.EventID,
+	}
+	l.e.Export(ctx, env)
+}
+
+func (l lifecycle) OnStepScheduled(
+	ctx context.Context,
+	md sv2.Metadata,
+	item queue.Item,
+	name *string,
+) {
+	env := newEnvelope(EventStepScheduled, md, item.Attempt)
+	env.Step = &Step{}
+	if edge, err := queue.GetEdge(item); err == nil {
+		env.Step.ID = edge.Edge.Incoming
+	}
+	if name != nil {
+		env.Step.Name = *name
+	}
+	l.e.Export(ctx, env)
+}
+
+func (l lifecycle) OnStepStarted(
+	ctx context.Context,
+	md sv2.Metadata,
+	item queue.Item,
+	edge inngest.Edge,
+	_ string,
+) {
+	env := newEnvelope(EventStepStarted, md, item.Attempt)
+	env.Step = &Step{
+		ID:   edge.Incoming,
+		Name: edge.IncomingGeneratorStepName,
+	}
+	l.e.Export(ctx, env)
+}
+
+func (l lifecycle) OnStepFinished(
+	ctx context.Context,
+	md sv2.Metadata,
+	item queue.Item,
+	edge inngest.Edge,
+	resp *state.DriverResponse,
+	runErr error,
+) {
+	if runErr != nil || resp == nil {
+		return
+	}
+
+	env := newEnvelope(EventStepFinished, md, item.Attempt)
+	env.Step = &Step{
+		ID:         edge.Incoming,
+		Name:       resp.Step.Name,
+		Status:     StatusCompleted,
+		DurationMS: resp.Duration.Milliseconds(),
+	}
+	if op := resp.HistoryVisibleStep(); op != nil {
+		env.Step.ID = op.ID
+		env.Step.Name = op.UserDefinedName()
+		if op.Op == enums.OpcodeStepError {
+			env.Step.Status = StatusErrored
+			if resp.NoRetry || (op.Error != nil && op.Error.NoRetry) {
+				env.Step.Status = StatusFailed
+			}
+			if op.Error != nil {
+				env.Step.Error = &Error{Name: op.Error.Name, Message: op.Error.Message}
+			}
+		}
+	}
+	if resp.Err != nil {
+		env.Step.Status = StatusErrored
+		if !resp.Retryable() {
+			env.Step.Status = StatusFailed
+		}
+		env.Step.Error = responseError(*resp)
+	}
+	l.e.Export(ctx, env)
+}
+
+func newEnvelope(t EventType, md sv2.Metadata, attempt int) Envelope {
+	return Envelope{
+		ID:              ulid.Make(),
+		Version:         Version,
+		Type:            t,
+		Timestamp:       time.Now(),
+		AccountID:       md.ID.Tenant.AccountID,
+		EnvID:           md.ID.Tenant.EnvID,
+		AppID:           md.ID.Tenant.AppID,
+		FunctionID:      md.ID.FunctionID,
+		FunctionSlug:    md.Config.FunctionSlug(),
+		FunctionVersion: md.Config.FunctionVersion,
+		RunID:           md.ID.RunID,
+		EventIDs:        md.Config.EventIDs,
+		BatchID:         md.Config.BatchID,
+		Attempt:         attempt,
+	}
+}
+
+func responseError(resp state.DriverResponse) *Error {
+	if resp.UserError != nil {
+		return &Error{Name: resp.UserError.Name, Message: resp.UserError.Message}
+	}
+	return &Error{Message: resp.Error()}
+}
+
+// output returns the response output as JSON.  Outputs are often already
+// marshalled JSON strings, which are embedded as-is.
+func output(o any) json.RawMessage {
+	if o == nil {
+		return nil
+	}
+	if s, ok := o.(string); ok && json.Valid([]byte(s)) {
+		return json.RawMessage(s)
+	}
+	byt, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	return byt
+}
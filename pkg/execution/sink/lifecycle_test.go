@@ -0,0 +1,138 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/execution"
+	"github.com/khulnasoft/inngest/pkg/execution/queue"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/khulnasoft/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleListener(t *testing.T) {
+	ctx := context.Background()
+
+	evtID := ulid.Make()
+	md := sv2.Metadata{
+		ID: sv2.ID{
+			RunID:      ulid.Make(),
+			FunctionID: uuid.New(),
+			Tenant: sv2.Tenant{
+				AppID:     uuid.New(),
+				EnvID:     uuid.New(),
+				AccountID: uuid.New(),
+			},
+		},
+		Config: *sv2.InitConfig(&sv2.Config{
+			FunctionVersion: 3,
+			EventIDs:        []ulid.ULID{evtID},
+		}),
+		Metrics: sv2.RunMetrics{StepCount: 2},
+	}
+	md.Config.SetFunctionSlug("app-fn")
+
+	export := func(t *testing.T, f func(l execution.LifecycleListener)) Envelope {
+		t.Helper()
+		s := &fakeSink{}
+		l := NewLifecycleListener(NewExporter(ExporterOpts{}, s))
+		f(l)
+		require.NoError(t, l.Close(ctx))
+
+		received := s.received()
+		require.Len(t, received, 1)
+
+		// Envelopes are stable JSON.
+		byt, err := json.Marshal(received[0])
+		require.NoError(t, err)
+		env := Envelope{}
+		require.NoError(t, json.Unmarshal(byt, &env))
+		return env
+	}
+
+	t.Run("function finished", func(t *testing.T) {
+		env := export(t, func(l execution.LifecycleListener) {
+			l.OnFunctionFinished(ctx, md, queue.Item{Attempt: 1}, nil, state.DriverResponse{
+				Output: `{"ok":true}`,
+			})
+		})
+
+		require.Equal(t, Version, env.Version)
+		require.Equal(t, EventFunctionFinished, env.Type)
+		require.NotEqual(t, ulid.ULID{}, env.ID)
+		require.Equal(t, md.ID.RunID, env.RunID)
+		require.Equal(t, md.ID.FunctionID, env.FunctionID)
+		require.Equal(t, md.ID.Tenant.AppID, env.AppID)
+		require.Equal(t, md.ID.Tenant.EnvID, env.EnvID)
+		require.Equal(t, md.ID.Tenant.AccountID, env.AccountID)
+		require.Equal(t, "app-fn", env.FunctionSlug)
+		require.Equal(t, 3, env.FunctionVersion)
+		require.Equal(t, []ulid.ULID{evtID}, env.EventIDs)
+		require.Equal(t, 1, env.Attempt)
+
+		require.NotNil(t, env.Run)
+		require.Equal(t, StatusCompleted, env.Run.Status)
+		require.JSONEq(t, `{"ok":true}`, string(env.Run.Output))
+		require.Equal(t, 2, env.Run.StepCount)
+		require.Nil(t, env.Step)
+	})
+
+	t.Run("function failed", func(t *testing.T) {
+		msg := "boom"
+		env := export(t, func(l execution.LifecycleListener) {
+			l.OnFunctionFinished(ctx, md, queue.Item{}, nil, state.DriverResponse{
+				Err:       &msg,
+				UserError: &state.UserError{Name: "Error", Message: "boom"},
+			})
+		})
+		require.Equal(t, StatusFailed, env.Run.Status)
+		require.Equal(t, &Error{Name: "Error", Message: "boom"}, env.Run.Error)
+		require.Nil(t, env.Run.Output)
+	})
+
+	t.Run("function cancelled", func(t *testing.T) {
+		cancelID := ulid.Make()
+		env := export(t, func(l execution.LifecycleListener) {
+			l.OnFunctionCancelled(ctx, md, execution.CancelRequest{EventID: &cancelID}, nil)
+		})
+		require.Equal(t, EventFunctionCancelled, env.Type)
+		require.Equal(t, StatusCancelled, env.Run.Status)
+		require.Equal(t, &cancelID, env.Run.CancelEventID)
+	})
+
+	t.Run("function skipped", func(t *testing.T) {
+		env := export(t, func(l execution.LifecycleListener) {
+			l.OnFunctionSkipped(ctx, md, execution.SkipState{Reason: enums.SkipReasonFunctionPaused})
+		})
+		require.Equal(t, EventFunctionSkipped, env.Type)
+		require.Equal(t, StatusSkipped, env.Run.Status)
+		require.Equal(t, enums.SkipReasonFunctionPaused.String(), env.Run.SkipReason)
+	})
+
+	t.Run("step errored", func(t *testing.T) {
+		env := export(t, func(l execution.LifecycleListener) {
+			l.OnStepFinished(ctx, md, queue.Item{Attempt: 2}, inngest.Edge{Incoming: "step"}, &state.DriverResponse{
+				Generator: []*state.GeneratorOpcode{{
+					Op:    enums.OpcodeStepError,
+					ID:    "step-id",
+					Name:  "charge",
+					Error: &state.UserError{Name: "Error", Message: "declined"},
+				}},
+			}, nil)
+		})
+		require.Equal(t, EventStepFinished, env.Type)
+		require.Equal(t, 2, env.Attempt)
+		require.Equal(t, &Step{
+			ID:     "step-id",
+			Name:   "charge",
+			Status: StatusErrored,
+			Error:  &Error{Name: "Error", Message: "declined"},
+		}, env.Step)
+	})
+}
@@ -0,0 +1,64 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/khulnasoft/inngest/pkg/telemetry/exporters"
+)
+
+// NewNATSSink returns a sink which publishes each envelope as a separate message
+// to the NATS JetStream subjects configured by the exporter options.
+func NewNATSSink(ctx context.Context, opts ...exporters.NatsExporterOpts) (Sink, error) {
+	p, err := exporters.NewNATSPublisher(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublisherSink("nats", p), nil
+}
+
+// NewKafkaSink returns a sink which produces each envelope as a separate record
+// to the Kafka topic configured by the exporter options.  Records are keyed by
+// run ID, so that every envelope for a run is sent to the same partition and is
+// consumed in order.
+func NewKafkaSink(ctx context.Context, opts ...exporters.KafkaSpansExporterOpts) (Sink, error) {
+	p, err := exporters.NewKafkaPublisher(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublisherSink("kafka", p), nil
+}
+
+// NewPublisherSink returns a sink which publishes envelopes via the publisher.
+func NewPublisherSink(name string, p exporters.Publisher) Sink {
+	return publisher{name: name, p: p}
+}
+
+type publisher struct {
+	name string
+	p    exporters.Publisher
+}
+
+func (p publisher) Name() string {
+	return p.name
+}
+
+func (p publisher) Send(ctx context.Context, envs []Envelope) error {
+	msgs := make([]exporters.Message, len(envs))
+	for i, env := range envs {
+		byt, err := json.Marshal(env)
+		if err != nil {
+			return Permanent(fmt.Errorf("error marshalling envelope: %w", err))
+		}
+		msgs[i] = exporters.Message{
+			Key:   []byte(env.RunID.String()),
+			Value: byt,
+		}
+	}
+	return p.p.Publish(ctx, msgs)
+}
+
+func (p publisher) Close(ctx context.Context) error {
+	return p.p.Close(ctx)
+}
@@ -0,0 +1,109 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/webhooks"
+	"github.com/oklog/ulid/v2"
+)
+
+// DefaultWebhookTimeout is the timeout for each webhook request if none is
+// configured.
+const DefaultWebhookTimeout = 30 * time.Second
+
+// WebhookOpts configures a webhook sink.
+type WebhookOpts struct {
+	// URL is the URL that batches are POSTed to.
+	URL string
+	// SigningKey signs each request using the Standard Webhooks headers
+	// (Webhook-Id, Webhook-Timestamp and Webhook-Signature).  The key is base64
+	// encoded with an optional "whsec_" prefix.  If empty, requests are unsigned.
+	SigningKey string
+	// Client is the HTTP client used to send requests.  Defaults to a client
+	// with DefaultWebhookTimeout.
+	Client *http.Client
+}
+
+// NewWebhookSink returns a sink which POSTs each batch of envelopes to a URL as
+// a JSON array.
+//
+// Requests which fail with a 4xx status code other than 408 or 429 are not
+// retried, as they indicate that the receiver will never accept the batch.
+func NewWebhookSink(opts WebhookOpts) (Sink, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("no url provided for webhook sink")
+	}
+	if opts.SigningKey != "" {
+		// Validate the key upfront rather than failing every request.
+		if _, err := webhooks.SignStandard(opts.SigningKey, "", time.Now(), nil); err != nil {
+			return nil, fmt.Errorf("invalid webhook signing key: %w", err)
+		}
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: DefaultWebhookTimeout}
+	}
+	return webhook{opts: opts}, nil
+}
+
+type webhook struct {
+	opts WebhookOpts
+}
+
+func (w webhook) Name() string {
+	return "webhook"
+}
+
+func (w webhook) Send(ctx context.Context, envs []Envelope) error {
+	body, err := json.Marshal(envs)
+	if err != nil {
+		return Permanent(fmt.Errorf("error marshalling envelopes: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if w.opts.SigningKey != "" {
+		// The ID is unique to each request, including retries of the same
+		// batch.  Receivers deduplicate using each envelope's ID.
+		id := "msg_" + ulid.Make().String()
+		now := time.Now()
+		sig, err := webhooks.SignStandard(w.opts.SigningKey, id, now, body)
+		if err != nil {
+			return Permanent(err)
+		}
+		req.Header.Set("Webhook-Id", id)
+		req.Header.Set("Webhook-Timestamp", fmt.Sprintf("%d", now.Unix()))
+		req.Header.Set("Webhook-Signature", sig)
+	}
+
+	resp, err := w.opts.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout &&
+		resp.StatusCode != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
+
+func (w webhook) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,156 @@
+package sink
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/webhooks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink(t *testing.T) {
+	ctx := context.Background()
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("lifecycle-key"))
+
+	var (
+		status   = http.StatusOK
+		received []Envelope
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		// Requests are verifiable using the Standard Webhooks signature.
+		err = webhooks.Verify(cqrs.WebhookSource{
+			Signature: cqrs.WebhookSignatureStandard,
+			Secret:    secret,
+		}, r.Header, body, time.Now())
+		require.NoError(t, err)
+
+		received = nil
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s, err := NewWebhookSink(WebhookOpts{URL: srv.URL, SigningKey: secret})
+	require.NoError(t, err)
+
+	envs := []Envelope{envelope(EventFunctionStarted), envelope(EventFunctionFinished)}
+	require.NoError(t, s.Send(ctx, envs))
+	require.Len(t, received, 2)
+	require.Equal(t, envs[0].ID, received[0].ID)
+	require.Equal(t, Version, received[1].Version)
+	require.Equal(t, EventFunctionFinished, received[1].Type)
+
+	t.Run("server errors are retried", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		err := s.Send(ctx, envs)
+		require.Error(t, err)
+		require.False(t, errors.As(err, &permanentError{}))
+
+		status = http.StatusTooManyRequests
+		err = s.Send(ctx, envs)
+		require.Error(t, err)
+		require.False(t, errors.As(err, &permanentError{}))
+	})
+
+	t.Run("client errors are permanent", func(t *testing.T) {
+		status = http.StatusBadRequest
+		err := s.Send(ctx, envs)
+		require.Error(t, err)
+		require.True(t, errors.As(err, &permanentError{}))
+	})
+
+	t.Run("invalid signing key", func(t *testing.T) {
+		_, err := NewWebhookSink(WebhookOpts{URL: srv.URL, SigningKey: "whsec_not base64"})
+		require.Error(t, err)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+
+	received := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The events parameter configures the sink, and isn't sent.
+		require.Empty(t, r.URL.Query().Get("events"))
+		require.Equal(t, "1", r.URL.Query().Get("source"))
+		received++
+	}))
+	defer srv.Close()
+
+	s, err := Open(ctx, srv.URL+"/hook?source=1&events=function.finished", OpenOpts{})
+	require.NoError(t, err)
+	require.NoError(t, s.Send(ctx, []Envelope{envelope(EventFunctionStarted)}))
+	require.Equal(t, 0, received)
+	require.NoError(t, s.Send(ctx, []Envelope{envelope(EventFunctionFinished)}))
+	require.Equal(t, 1, received)
+
+	for _, uri := range []string{
+		"ftp://example.com/hook",
+		"nats://localhost:4222",
+		"kafka://localhost:9092",
+		"https://example.com/hook?events=function.unknown",
+	} {
+		_, err := Open(ctx, uri, OpenOpts{})
+		require.Error(t, err, uri)
+	}
+}
+
+func TestNewLifecycleListenerFromURIs(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		l        sync.Mutex
+		received int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Lock()
+		defer l.Unlock()
+		received++
+	}))
+	defer srv.Close()
+
+	listener, e, err := NewLifecycleListenerFromURIs(ctx, []string{srv.URL}, OpenOpts{})
+	require.NoError(t, err)
+	require.NotNil(t, listener)
+
+	// Closing the exporter sends buffered envelopes.
+	e.Export(ctx, envelope(EventFunctionFinished))
+	require.NoError(t, e.Close(ctx))
+	l.Lock()
+	require.Equal(t, 1, received)
+	l.Unlock()
+
+	_, _, err = NewLifecycleListenerFromURIs(ctx, []string{srv.URL, "ftp://example.com/hook"}, OpenOpts{})
+	require.Error(t, err)
+
+	t.Run("sinks are closed if any fail to open", func(t *testing.T) {
+		opened := []*fakeSink{}
+		_, err := openSinks(ctx, []string{"a", "b", "c"}, func(uri string) (Sink, error) {
+			if uri == "c" {
+				return nil, fmt.Errorf("invalid sink")
+			}
+			s := &fakeSink{}
+			opened = append(opened, s)
+			return s, nil
+		})
+		require.ErrorContains(t, err, "invalid sink")
+		require.Len(t, opened, 2)
+		for _, s := range opened {
+			require.True(t, s.closed)
+		}
+	})
+}
@@ -185,3 +185,51 @@ func TestGeneratorSleepDuration(t *testing.T) {
 func strptr(s string) *string {
 	return &s
 }
+
+func TestGeneratorStepPolicy(t *testing.T) {
+	t.Run("steps without a policy use the function's defaults", func(t *testing.T) {
+		gen := GeneratorOpcode{Op: enums.OpcodeStepPlanned, Opts: map[string]any{"type": "step"}}
+		policy, err := gen.StepPolicy()
+		require.NoError(t, err)
+		require.Nil(t, policy)
+	})
+
+	t.Run("policies are parsed from run opts", func(t *testing.T) {
+		gen := GeneratorOpcode{
+			Op:   enums.OpcodeStepPlanned,
+			Opts: []byte(`{"timeout":"30s","maxAttempts":20,"backoff":{"kind":"linear","interval":"1m","jitter":"5s"}}`),
+		}
+		policy, err := gen.StepPolicy()
+		require.NoError(t, err)
+		require.NotNil(t, policy)
+		require.Equal(t, 30*time.Second, policy.TimeoutDuration())
+		require.Equal(t, 20, *policy.MaxAttempts)
+
+		now := time.Now()
+		at := policy.NextRetryAt(1)
+		require.NotNil(t, at)
+		require.False(t, at.Before(now.Add(2*time.Minute)))
+		require.True(t, at.Before(now.Add(2*time.Minute+6*time.Second)))
+	})
+
+	t.Run("steps without a backoff use the default retry time", func(t *testing.T) {
+		attempts := 2
+		policy := StepPolicy{MaxAttempts: &attempts}
+		require.Nil(t, policy.NextRetryAt(0))
+		require.Zero(t, policy.TimeoutDuration())
+	})
+
+	t.Run("invalid policies error", func(t *testing.T) {
+		for _, opts := range []string{
+			`{"maxAttempts":0}`,
+			`{"maxAttempts":100}`,
+			`{"timeout":"soon"}`,
+			`{"backoff":{"kind":"random"}}`,
+			`{"backoff":{"kind":"fixed","interval":"-1s"}}`,
+		} {
+			gen := GeneratorOpcode{Op: enums.OpcodeStepPlanned, Opts: []byte(opts)}
+			_, err := gen.StepPolicy()
+			require.Error(t, err, opts)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/khulnasoft/inngest/pkg/backoff"
 	"github.com/khulnasoft/inngest/pkg/consts"
 	"github.com/khulnasoft/inngest/pkg/dateutil"
 	"github.com/khulnasoft/inngest/pkg/enums"
@@ -147,6 +148,22 @@ func (g GeneratorOpcode) RunOpts() (*RunOpts, error) {
 	return opts, nil
 }
 
+// StepPolicy returns the SDK-declared policy for the step, or nil if the step uses the
+// function's defaults.
+func (g GeneratorOpcode) StepPolicy() (*StepPolicy, error) {
+	opts, err := g.RunOpts()
+	if err != nil {
+		return nil, err
+	}
+	if opts.StepPolicy.IsEmpty() {
+		return nil, nil
+	}
+	if err := opts.StepPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	return &opts.StepPolicy, nil
+}
+
 func (g GeneratorOpcode) WaitForEventOpts() (*WaitForEventOpts, error) {
 	if opts, ok := g.Opts.(*WaitForEventOpts); ok && opts != nil {
 		return opts, nil
@@ -166,6 +183,22 @@ func (g GeneratorOpcode) WaitForEventOpts() (*WaitForEventOpts, error) {
 	return opts, nil
 }
 
+func (g GeneratorOpcode) WaitForSignalOpts() (*WaitForSignalOpts, error) {
+	opts := &WaitForSignalOpts{}
+	if err := opts.UnmarshalAny(g.Opts); err != nil {
+		return nil, err
+	}
+	if opts.Signal == "" {
+		return nil, fmt.Errorf("A signal name must be provided when waiting for a signal")
+	}
+	if opts.Timeout == "" {
+		// Without a timeout the pause would expire immediately, so the run
+		// could never be resumed by the signal.
+		return nil, fmt.Errorf("A timeout must be provided when waiting for a signal")
+	}
+	return opts, nil
+}
+
 func (g GeneratorOpcode) SleepDuration() (time.Duration, error) {
 	if g.Op != enums.OpcodeSleep {
 		return 0, fmt.Errorf("unable to return sleep duration for opcode %s", g.Op.String())
@@ -271,6 +304,88 @@ func (s *SleepOpts) UnmarshalAny(a any) error {
 type RunOpts struct {
 	Type  string          `json:"type,omitempty"`
 	Input json.RawMessage `json:"input"`
+
+	// StepPolicy contains the SDK-declared timeout and retry policy for the step.
+	StepPolicy
+}
+
+// StepPolicy represents SDK-declared options for running a single step, overriding the
+// function's retries and the default queue backoff.
+type StepPolicy struct {
+	// Timeout is the maximum duration of each attempt of the step, eg. "30s".
+	Timeout string `json:"timeout,omitempty"`
+	// MaxAttempts is the maximum number of attempts for the step, including the
+	// first attempt.
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// Backoff configures the delay between attempts of the step.
+	Backoff *StepBackoff `json:"backoff,omitempty"`
+}
+
+// StepBackoff configures the delay between attempts of a step.
+type StepBackoff struct {
+	Kind backoff.Kind `json:"kind"`
+	// Interval is the delay before the first retry, defaulting to 10 seconds.
+	Interval string `json:"interval,omitempty"`
+	// Jitter is the maximum random duration added to each delay.
+	Jitter string `json:"jitter,omitempty"`
+}
+
+// IsEmpty returns whether the step declared no policy, using the function's defaults.
+func (p StepPolicy) IsEmpty() bool {
+	return p.Timeout == "" && p.MaxAttempts == nil && p.Backoff == nil
+}
+
+func (p StepPolicy) Validate() error {
+	if p.Timeout != "" {
+		if dur, err := str2duration.ParseDuration(p.Timeout); err != nil || dur <= 0 {
+			return fmt.Errorf("invalid step timeout: %s", p.Timeout)
+		}
+	}
+	if p.MaxAttempts != nil && (*p.MaxAttempts < 1 || *p.MaxAttempts > consts.MaxRetries+1) {
+		return fmt.Errorf("step max attempts must be between 1 and %d", consts.MaxRetries+1)
+	}
+	if p.Backoff != nil {
+		if !p.Backoff.Kind.IsValid() {
+			return fmt.Errorf("invalid step backoff kind: %s", p.Backoff.Kind)
+		}
+		for _, d := range []string{p.Backoff.Interval, p.Backoff.Jitter} {
+			if d == "" {
+				continue
+			}
+			if dur, err := str2duration.ParseDuration(d); err != nil || dur < 0 {
+				return fmt.Errorf("invalid step backoff duration: %s", d)
+			}
+		}
+	}
+	return nil
+}
+
+// TimeoutDuration returns the timeout for each attempt of the step, or 0 if the step
+// has no timeout.
+func (p StepPolicy) TimeoutDuration() time.Duration {
+	if p.Timeout == "" {
+		return 0
+	}
+	dur, _ := str2duration.ParseDuration(p.Timeout)
+	return dur
+}
+
+// NextRetryAt returns when the step should be retried given the zero-indexed attempt
+// that failed, or nil if the step uses the default backoff.
+func (p StepPolicy) NextRetryAt(attempt int) *time.Time {
+	if p.Backoff == nil {
+		return nil
+	}
+	interval := consts.DefaultStepBackoffInterval
+	if p.Backoff.Interval != "" {
+		interval, _ = str2duration.ParseDuration(p.Backoff.Interval)
+	}
+	var jitter time.Duration
+	if p.Backoff.Jitter != "" {
+		jitter, _ = str2duration.ParseDuration(p.Backoff.Jitter)
+	}
+	at := backoff.GetPolicyBackoffFunc(p.Backoff.Kind, interval, jitter)(attempt)
+	return &at
 }
 
 func (r *RunOpts) UnmarshalAny(a any) error {
@@ -339,6 +454,41 @@ func (w WaitForEventOpts) Expires() (time.Time, error) {
 	return time.Now().Add(dur), nil
 }
 
+type WaitForSignalOpts struct {
+	// Signal is the user-defined name of the signal to wait for.  Signals
+	// are unique per environment.
+	Signal  string `json:"signal"`
+	Timeout string `json:"timeout"`
+}
+
+func (w *WaitForSignalOpts) UnmarshalAny(a any) error {
+	opts := WaitForSignalOpts{}
+	var mappedByt []byte
+	switch typ := a.(type) {
+	case []byte:
+		mappedByt = typ
+	default:
+		byt, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		mappedByt = byt
+	}
+	if err := json.Unmarshal(mappedByt, &opts); err != nil {
+		return err
+	}
+	*w = opts
+	return nil
+}
+
+func (w WaitForSignalOpts) Expires() (time.Time, error) {
+	dur, err := str2duration.ParseDuration(w.Timeout)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(dur), nil
+}
+
 // AIGatewayOpts returns the AI gateway options within the driver.
 func (g *GeneratorOpcode) AIGatewayOpts() (aigateway.Request, error) {
 	req := aigateway.Request{}
@@ -77,6 +77,12 @@ type PauseGetter interface {
 	//
 	// This should not return consumed pauses.
 	PauseByInvokeCorrelationID(ctx context.Context, wsID uuid.UUID, correlationID string) (*Pause, error)
+
+	// PauseBySignalID returns a given pause by the signal ID.  Signals are unique
+	// per workspace, so at most one pause can exist for a given signal at a time.
+	//
+	// This should not return consumed pauses.
+	PauseBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) (*Pause, error)
 }
 
 // PauseIterator allows the runner to iterate over all pauses returned by a PauseGetter.  This
@@ -164,6 +170,9 @@ type Pause struct {
 	// This is used to be able to accurately reconstruct the entire invocation
 	// span.
 	InvokeTargetFnID *string `json:"itFnID,omitempty"`
+	// SignalID is the user-defined signal name that resumes this pause.  Signal
+	// pauses are not matched against events and are only resumed via the API.
+	SignalID *string `json:"sigID,omitempty"`
 	// OnTimeout indicates that this incoming edge should only be ran
 	// when the pause times out, if set to true.
 	OnTimeout bool `json:"onTimeout"`
@@ -238,6 +247,10 @@ func (p Pause) IsInvoke() bool {
 	return p.Opcode != nil && *p.Opcode == enums.OpcodeInvokeFunction.String()
 }
 
+func (p Pause) IsSignal() bool {
+	return p.SignalID != nil && *p.SignalID != ""
+}
+
 type ResumeData struct {
 	// If non-nil, RunID is the ID of the run that completed to cause this
 	// resume.
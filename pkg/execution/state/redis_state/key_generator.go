@@ -103,6 +103,8 @@ func (s runStateKeyGenerator) ActionInputs(ctx context.Context, isSharded bool,
 type GlobalKeyGenerator interface {
 	// Invoke returns the key used to store the correlation key associated with invoke functions
 	Invoke(ctx context.Context, wsID uuid.UUID) string
+	// Signal returns the key used to store the signal IDs associated with signal pauses
+	Signal(ctx context.Context, wsID uuid.UUID) string
 }
 
 type globalKeyGenerator struct {
@@ -113,6 +115,10 @@ func (u globalKeyGenerator) Invoke(ctx context.Context, wsID uuid.UUID) string {
 	return fmt.Sprintf("{%s}:invoke:%s", u.stateDefaultKey, wsID)
 }
 
+func (u globalKeyGenerator) Signal(ctx context.Context, wsID uuid.UUID) string {
+	return fmt.Sprintf("{%s}:signal:%s", u.stateDefaultKey, wsID)
+}
+
 type QueueKeyGenerator interface {
 	// QueueItem returns the key for the hash containing all items within a
 	// queue for a function.
@@ -14,9 +14,11 @@ local pauseInvokeKey = KEYS[4]
 local keyPauseAddIdx = KEYS[5]
 local keyPauseExpIdx = KEYS[6]
 local keyRunPauses   = KEYS[7]
+local pauseSignalKey = KEYS[8]
 
 local pauseID       = ARGV[1]
 local invokeCorrelationId = ARGV[2]
+local signalID      = ARGV[3]
 
 redis.call("HDEL", pauseEventKey, pauseID)
 redis.call("DEL", pauseKey)
@@ -28,6 +30,13 @@ if invokeCorrelationId ~= false and invokeCorrelationId ~= "" and invokeCorrelat
   redis.call("HDEL", pauseInvokeKey, invokeCorrelationId)
 end
 
+if signalID ~= false and signalID ~= "" and signalID ~= nil then
+  -- Only remove the signal if it still points to this pause.
+  if redis.call("HGET", pauseSignalKey, signalID) == pauseID then
+    redis.call("HDEL", pauseSignalKey, signalID)
+  end
+end
+
 -- Add an index of when the pause was added.
 redis.call("ZREM", keyPauseAddIdx, pauseID)
 -- Add an index of when the pause expires.  This lets us manually
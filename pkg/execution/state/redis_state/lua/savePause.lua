@@ -3,6 +3,7 @@
 -- Output:
 --   0: Successfully saved pause
 --   1: Pause already exists
+--   2: Signal is already in use by another pause
 -- ]]
 
 local pauseKey    = KEYS[1]
@@ -11,6 +12,7 @@ local pauseInvokeKey = KEYS[3]
 local keyPauseAddIdx = KEYS[4]
 local keyPauseExpIdx = KEYS[5]
 local keyRunPauses   = KEYS[6]
+local pauseSignalKey = KEYS[7]
 
 local pause          = ARGV[1]
 local pauseID        = ARGV[2]
@@ -18,7 +20,21 @@ local event          = ARGV[3]
 local invokeCorrelationID = ARGV[4]
 local extendedExpiry = tonumber(ARGV[5])
 local nowUnixSeconds = tonumber(ARGV[6])
+local signalID       = ARGV[7]
 
+local hasSignal = signalID ~= false and signalID ~= "" and signalID ~= nil
+
+if hasSignal then
+	local existing = redis.call("HGET", pauseSignalKey, signalID)
+	if existing ~= false and existing ~= nil and existing ~= pauseID then
+		-- Pause keys are "{prefix}:pauses:$id";  only conflict if the existing
+		-- pause has not yet expired or been removed.
+		local existingKey = string.sub(pauseKey, 1, #pauseKey - #pauseID) .. existing
+		if redis.call("EXISTS", existingKey) == 1 then
+			return 2
+		end
+	end
+end
 
 if redis.call("SETNX", pauseKey, pause) == 0 then
 	return 1
@@ -43,4 +59,8 @@ if invokeCorrelationID ~= false and invokeCorrelationID ~= "" and invokeCorrelat
 	redis.call("HSETNX", pauseInvokeKey, invokeCorrelationID, pauseID)
 end
 
+if hasSignal then
+	redis.call("HSET", pauseSignalKey, signalID, pauseID)
+end
+
 return 0
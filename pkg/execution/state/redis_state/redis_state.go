@@ -757,6 +757,11 @@ func (m unshardedMgr) SavePause(ctx context.Context, p state.Pause) error {
 		corrId = *p.InvokeCorrelationID
 	}
 
+	signalID := ""
+	if p.SignalID != nil {
+		signalID = *p.SignalID
+	}
+
 	extendedExpiry := time.Until(p.Expires.Time().Add(10 * time.Minute)).Seconds()
 	nowUnixSeconds := time.Now().Unix()
 
@@ -772,6 +777,7 @@ func (m unshardedMgr) SavePause(ctx context.Context, p state.Pause) error {
 		pause.kg.PauseIndex(ctx, "add", p.WorkspaceID, evt),
 		pause.kg.PauseIndex(ctx, "exp", p.WorkspaceID, evt),
 		pause.kg.RunPauses(ctx, p.Identifier.RunID),
+		global.kg.Signal(ctx, p.WorkspaceID),
 	}
 
 	args, err := StrSlice([]any{
@@ -783,6 +789,7 @@ func (m unshardedMgr) SavePause(ctx context.Context, p state.Pause) error {
 		// pause by ID for 10 minutes past expiry.
 		int(extendedExpiry),
 		nowUnixSeconds,
+		signalID,
 	})
 	if err != nil {
 		return err
@@ -803,6 +810,8 @@ func (m unshardedMgr) SavePause(ctx context.Context, p state.Pause) error {
 		return nil
 	case 1:
 		return state.ErrPauseAlreadyExists
+	case 2:
+		return state.ErrSignalConflict
 	}
 	return fmt.Errorf("unknown response saving pause: %d", status)
 }
@@ -977,6 +986,11 @@ func (m unshardedMgr) DeletePause(ctx context.Context, p state.Pause) error {
 		corrId = *p.InvokeCorrelationID
 	}
 
+	signalID := ""
+	if p.SignalID != nil {
+		signalID = *p.SignalID
+	}
+
 	pauseKey := pause.kg.Pause(ctx, p.ID)
 	pauseStepKey := pause.kg.PauseStep(ctx, p.Identifier, p.Incoming)
 	runPausesKey := pause.kg.RunPauses(ctx, p.Identifier.RunID)
@@ -990,6 +1004,7 @@ func (m unshardedMgr) DeletePause(ctx context.Context, p state.Pause) error {
 		pause.kg.PauseIndex(ctx, "add", p.WorkspaceID, evt),
 		pause.kg.PauseIndex(ctx, "exp", p.WorkspaceID, evt),
 		runPausesKey,
+		global.kg.Signal(ctx, p.WorkspaceID),
 	}
 
 	status, err := scripts["deletePause"].Exec(
@@ -999,6 +1014,7 @@ func (m unshardedMgr) DeletePause(ctx context.Context, p state.Pause) error {
 		[]string{
 			p.ID.String(),
 			corrId,
+			signalID,
 		},
 	).AsInt64()
 	if err != nil {
@@ -1119,6 +1135,27 @@ func (m unshardedMgr) PauseByInvokeCorrelationID(ctx context.Context, wsID uuid.
 	return m.PauseByID(ctx, pauseID)
 }
 
+func (m unshardedMgr) PauseBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) (*state.Pause, error) {
+	ctx = redis_telemetry.WithScope(redis_telemetry.WithOpName(ctx, "PauseBySignalID"), redis_telemetry.ScopePauses)
+
+	global := m.u.Global()
+	key := global.kg.Signal(ctx, wsID)
+	cmd := global.Client().B().Hget().Key(key).Field(signalID).Build()
+	pauseIDstr, err := global.Client().Do(ctx, cmd).ToString()
+	if err == rueidis.Nil {
+		return nil, state.ErrSignalPauseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pauseID, err := uuid.Parse(pauseIDstr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pauseID UUID: %w", err)
+	}
+	return m.PauseByID(ctx, pauseID)
+}
+
 func (m unshardedMgr) PausesByID(ctx context.Context, ids ...uuid.UUID) ([]*state.Pause, error) {
 	ctx = redis_telemetry.WithScope(redis_telemetry.WithOpName(ctx, "PausesByID"), redis_telemetry.ScopePauses)
 
@@ -0,0 +1,406 @@
+package sql_state
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	"github.com/inngest/expr"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+)
+
+// pauseGracePeriod is how long pauses are kept past their expiry, allowing
+// timeouts to load pauses by ID after they expire.
+const pauseGracePeriod = 10 * time.Minute
+
+func (m *mgr) SavePause(ctx context.Context, p state.Pause) error {
+	packed, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	// Pauses are only matched by event name if they're not part of an invoke.
+	// Invoke pauses are resumed via their correlation ID.
+	var evt *string
+	if p.Event != nil && (p.InvokeCorrelationID == nil || *p.InvokeCorrelationID == "") {
+		evt = p.Event
+	}
+	var corrID *string
+	if p.InvokeCorrelationID != nil && *p.InvokeCorrelationID != "" {
+		corrID = p.InvokeCorrelationID
+	}
+	var signalID *string
+	if p.IsSignal() {
+		signalID = p.SignalID
+	}
+
+	now := time.Now()
+
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		row, err := queryRow(ctx, tx, m.q().From(tablePauses).Select("id").Where(
+			sq.C("id").Eq(p.ID.String()),
+		).Prepared(true))
+		if err != nil {
+			return err
+		}
+		var found string
+		switch err := row.Scan(&found); {
+		case err == nil:
+			return state.ErrPauseAlreadyExists
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		if signalID != nil {
+			// Signals are unique per workspace, but only whilst the pause
+			// waiting for the signal can still be loaded.
+			if _, err := exec(ctx, tx, m.q().Delete(tablePauses).Where(
+				sq.C("workspace_id").Eq(p.WorkspaceID.String()),
+				sq.C("signal_id").Eq(*signalID),
+				sq.C("expires_at").Lte(now.UnixMilli()),
+			).Prepared(true)); err != nil {
+				return err
+			}
+			row, err := queryRow(ctx, tx, m.q().From(tablePauses).Select("id").Where(
+				sq.C("workspace_id").Eq(p.WorkspaceID.String()),
+				sq.C("signal_id").Eq(*signalID),
+			).Prepared(true))
+			if err != nil {
+				return err
+			}
+			switch err := row.Scan(&found); {
+			case err == nil:
+				return state.ErrSignalConflict
+			case !errors.Is(err, sql.ErrNoRows):
+				return err
+			}
+		}
+
+		_, err = exec(ctx, tx, m.q().Insert(tablePauses).Rows(sq.Record{
+			"id":                    p.ID.String(),
+			"workspace_id":          p.WorkspaceID.String(),
+			"run_id":                p.Identifier.RunID.String(),
+			"incoming":              p.Incoming,
+			"event":                 evt,
+			"invoke_correlation_id": corrID,
+			"signal_id":             signalID,
+			"pause":                 packed,
+			"created_at":            now.UnixMilli(),
+			// Keep the pause for a period past expiry, allowing us to process the
+			// pause by ID for timeouts.
+			"expires_at": p.Expires.Time().Add(pauseGracePeriod).UnixMilli(),
+		}).Prepared(true))
+		return err
+	})
+}
+
+func (m *mgr) LeasePause(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		n, err := exec(ctx, tx, m.q().Update(tablePauses).Set(sq.Record{
+			"leased_until": now.Add(state.PauseLeaseDuration).UnixMilli(),
+		}).Where(
+			sq.C("id").Eq(id.String()),
+			sq.C("expires_at").Gt(now.UnixMilli()),
+			sq.Or(
+				sq.C("leased_until").IsNull(),
+				sq.C("leased_until").Lte(now.UnixMilli()),
+			),
+		).Prepared(true))
+		if err != nil {
+			return fmt.Errorf("error leasing pause: %w", err)
+		}
+		if n > 0 {
+			return nil
+		}
+
+		if _, err := m.pauseByID(ctx, tx, id, now); err != nil {
+			return err
+		}
+		return state.ErrPauseLeased
+	})
+}
+
+func (m *mgr) ConsumePause(ctx context.Context, id uuid.UUID, data any) error {
+	marshalledData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("cannot marshal data to store in state: %w", err)
+	}
+
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		p, err := m.pauseByID(ctx, tx, id, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if p.DataKey != "" {
+			// saveStep is idempotent, only ever storing data for the key once.
+			_, err := m.saveStep(ctx, tx, p.Identifier.RunID, p.DataKey, marshalledData)
+			if err != nil && err != state.ErrRunNotFound {
+				return fmt.Errorf("error consuming pause: %w", err)
+			}
+		}
+
+		// The pause was now consumed, so let's clean up
+		_, err = exec(ctx, tx, m.q().Delete(tablePauses).Where(sq.C("id").Eq(id.String())).Prepared(true))
+		return err
+	})
+}
+
+func (m *mgr) DeletePause(ctx context.Context, p state.Pause) error {
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		_, err := exec(ctx, tx, m.q().Delete(tablePauses).Where(sq.C("id").Eq(p.ID.String())).Prepared(true))
+		if err != nil {
+			return fmt.Errorf("error deleting pause: %w", err)
+		}
+		return nil
+	})
+}
+
+func (m *mgr) EventHasPauses(ctx context.Context, workspaceID uuid.UUID, event string) (bool, error) {
+	var exists bool
+	err := m.read(ctx, func(db *sql.DB) error {
+		row, err := queryRow(ctx, db, m.q().From(tablePauses).Select("id").Where(
+			sq.C("workspace_id").Eq(workspaceID.String()),
+			sq.C("event").Eq(event),
+			sq.C("expires_at").Gt(time.Now().UnixMilli()),
+		).Limit(1).Prepared(true))
+		if err != nil {
+			return err
+		}
+		var found string
+		err = row.Scan(&found)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		exists = err == nil
+		return err
+	})
+	return exists, err
+}
+
+func (m *mgr) PauseByID(ctx context.Context, id uuid.UUID) (*state.Pause, error) {
+	var p *state.Pause
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		p, err = m.pauseByID(ctx, db, id, time.Now())
+		return err
+	})
+	return p, err
+}
+
+func (m *mgr) pauseByID(ctx context.Context, q querier, id uuid.UUID, now time.Time) (*state.Pause, error) {
+	pauses, err := m.pauses(ctx, q, sq.C("id").Eq(id.String()), sq.C("expires_at").Gt(now.UnixMilli()))
+	if err != nil {
+		return nil, err
+	}
+	if len(pauses) == 0 {
+		return nil, state.ErrPauseNotFound
+	}
+	return pauses[0], nil
+}
+
+func (m *mgr) PausesByID(ctx context.Context, ids ...uuid.UUID) ([]*state.Pause, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	strs := make([]string, len(ids))
+	for n, id := range ids {
+		strs[n] = id.String()
+	}
+
+	var pauses []*state.Pause
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		pauses, err = m.pauses(ctx, db, sq.C("id").In(strs), sq.C("expires_at").Gt(time.Now().UnixMilli()))
+		return err
+	})
+	return pauses, err
+}
+
+func (m *mgr) PauseByInvokeCorrelationID(ctx context.Context, wsID uuid.UUID, correlationID string) (*state.Pause, error) {
+	p, err := m.first(ctx,
+		sq.C("workspace_id").Eq(wsID.String()),
+		sq.C("invoke_correlation_id").Eq(correlationID),
+	)
+	if err == state.ErrPauseNotFound {
+		return nil, state.ErrInvokePauseNotFound
+	}
+	return p, err
+}
+
+func (m *mgr) PauseBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) (*state.Pause, error) {
+	p, err := m.first(ctx,
+		sq.C("workspace_id").Eq(wsID.String()),
+		sq.C("signal_id").Eq(signalID),
+	)
+	if err == state.ErrPauseNotFound {
+		return nil, state.ErrSignalPauseNotFound
+	}
+	return p, err
+}
+
+// PauseByStep returns a specific pause for a given workflow run, from a given step.
+func (m *mgr) PauseByStep(ctx context.Context, i state.Identifier, actionID string) (*state.Pause, error) {
+	return m.first(ctx,
+		sq.C("run_id").Eq(i.RunID.String()),
+		sq.C("incoming").Eq(actionID),
+	)
+}
+
+// first returns the oldest unexpired pause matching the given filters.
+func (m *mgr) first(ctx context.Context, filter ...sq.Expression) (*state.Pause, error) {
+	var pauses []*state.Pause
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		pauses, err = m.pauses(ctx, db, append(filter, sq.C("expires_at").Gt(time.Now().UnixMilli()))...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pauses) == 0 {
+		return nil, state.ErrPauseNotFound
+	}
+	return pauses[0], nil
+}
+
+func (m *mgr) pauses(ctx context.Context, q querier, filter ...sq.Expression) ([]*state.Pause, error) {
+	rows, err := query(ctx, q, m.q().From(tablePauses).Select("pause").Where(filter...).Order(
+		sq.C("created_at").Asc(),
+	).Prepared(true))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pauses := []*state.Pause{}
+	for rows.Next() {
+		var byt []byte
+		if err := rows.Scan(&byt); err != nil {
+			return nil, err
+		}
+		p := &state.Pause{}
+		if err := json.Unmarshal(byt, p); err != nil {
+			return nil, err
+		}
+		pauses = append(pauses, p)
+	}
+	return pauses, rows.Err()
+}
+
+// PausesByEvent returns all pauses for a given event within a workspace.
+func (m *mgr) PausesByEvent(ctx context.Context, workspaceID uuid.UUID, event string) (state.PauseIterator, error) {
+	return m.PausesByEventSince(ctx, workspaceID, event, time.Time{})
+}
+
+// PausesByEventSince returns all pauses for a given event within a workspace
+// which were created after the given time.
+func (m *mgr) PausesByEventSince(ctx context.Context, workspaceID uuid.UUID, event string, since time.Time) (state.PauseIterator, error) {
+	filter := []sq.Expression{
+		sq.C("workspace_id").Eq(workspaceID.String()),
+		sq.C("event").Eq(event),
+		sq.C("expires_at").Gt(time.Now().UnixMilli()),
+	}
+	if !since.IsZero() {
+		filter = append(filter, sq.C("created_at").Gte(since.UnixMilli()))
+	}
+
+	var pauses []*state.Pause
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		pauses, err = m.pauses(ctx, db, filter...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &iter{items: pauses}, nil
+}
+
+func (m *mgr) EvaluablesByID(ctx context.Context, ids ...uuid.UUID) ([]expr.Evaluable, error) {
+	items, err := m.PausesByID(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	evaluables := make([]expr.Evaluable, len(items))
+	for n, i := range items {
+		evaluables[n] = i
+	}
+	return evaluables, nil
+}
+
+func (m *mgr) LoadEvaluablesSince(ctx context.Context, workspaceID uuid.UUID, eventName string, since time.Time, do func(context.Context, expr.Evaluable) error) error {
+	// Keep a list of pauses that should be deleted because they've expired.
+	expired := []*state.Pause{}
+
+	it, err := m.PausesByEventSince(ctx, workspaceID, eventName, since)
+	if err != nil {
+		return err
+	}
+	for it.Next(ctx) {
+		pause := it.Val(ctx)
+		if pause == nil {
+			continue
+		}
+
+		if pause.Expires.Time().Before(time.Now()) {
+			shouldDelete := pause.Expires.Time().Add(consts.PauseExpiredDeletionGracePeriod).Before(time.Now())
+			if shouldDelete {
+				expired = append(expired, pause)
+			}
+			continue
+		}
+
+		if err := do(ctx, pause); err != nil {
+			return err
+		}
+	}
+
+	// GC pauses on fetch.
+	for _, pause := range expired {
+		_ = m.DeletePause(ctx, *pause)
+	}
+
+	if it.Error() != context.Canceled {
+		return it.Error()
+	}
+	return nil
+}
+
+// iter iterates over a buffered slice of pauses.
+type iter struct {
+	items []*state.Pause
+	idx   int64
+	val   *state.Pause
+	err   error
+}
+
+func (i *iter) Count() int {
+	return len(i.items)
+}
+
+func (i *iter) Index() int64 {
+	return i.idx
+}
+
+func (i *iter) Next(ctx context.Context) bool {
+	if i.idx >= int64(len(i.items)) {
+		i.err = context.Canceled
+		return false
+	}
+	i.val = i.items[i.idx]
+	i.idx++
+	return true
+}
+
+func (i *iter) Val(ctx context.Context) *state.Pause {
+	return i.val
+}
+
+func (i *iter) Error() error {
+	return i.err
+}
@@ -0,0 +1,650 @@
+// Package sql_state implements run state and pauses on top of the same Postgres
+// or SQLite database used by base_cqrs.  This allows single-node deployments to
+// store durable run state without Redis.
+package sql_state
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sq "github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
+	_ "github.com/doug-martin/goqu/v9/dialect/sqlite3"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/enums"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	tableRuns        = "state_runs"
+	tableSteps       = "state_steps"
+	tableIdempotency = "state_idempotency"
+	tablePauses      = "state_pauses"
+)
+
+// New returns a state.Manager which stores run state and pauses in the given
+// database.  The driver must be either "postgres" or "sqlite", matching the
+// driver used to run base_cqrs migrations.
+func New(db *sql.DB, driver string) state.Manager {
+	m := &mgr{
+		db:      db,
+		dialect: "sqlite3",
+	}
+	if driver == "postgres" {
+		m.dialect = "postgres"
+	} else {
+		// SQLite only allows a single writer at a time.  Serialize access
+		// so that concurrent writers don't fail with SQLITE_BUSY.
+		m.lock = &sync.Mutex{}
+	}
+	return m
+}
+
+type mgr struct {
+	db      *sql.DB
+	dialect string
+	lock    *sync.Mutex
+}
+
+func (m *mgr) q() sq.DialectWrapper {
+	return sq.Dialect(m.dialect)
+}
+
+// tx runs f within a transaction, committing if f returns nil.
+func (m *mgr) tx(ctx context.Context, f func(tx *sql.Tx) error) error {
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// read runs f outside of a transaction.
+func (m *mgr) read(ctx context.Context, f func(db *sql.DB) error) error {
+	if m.lock != nil {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+	}
+	return f(m.db)
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type builder interface {
+	ToSQL() (string, []any, error)
+}
+
+func exec(ctx context.Context, q querier, b builder) (int64, error) {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return 0, err
+	}
+	res, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func queryRow(ctx context.Context, q querier, b builder) (*sql.Row, error) {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return q.QueryRowContext(ctx, query, args...), nil
+}
+
+func query(ctx context.Context, q querier, b builder) (*sql.Rows, error) {
+	query, args, err := b.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	return q.QueryContext(ctx, query, args...)
+}
+
+func (m *mgr) New(ctx context.Context, input state.Input) (state.State, error) {
+	events, err := json.Marshal(input.EventBatchData)
+	if err != nil {
+		return nil, err
+	}
+
+	var stepsByt []byte
+	if len(input.Steps) > 0 {
+		stepsByt, err = json.Marshal(input.Steps)
+		if err != nil {
+			return nil, fmt.Errorf("error storing run state when marshalling steps: %w", err)
+		}
+	}
+
+	var stepInputsByt []byte
+	if len(input.StepInputs) > 0 {
+		stepInputsByt, err = json.Marshal(input.StepInputs)
+		if err != nil {
+			return nil, fmt.Errorf("error storing run state when marshalling step inputs: %w", err)
+		}
+	}
+
+	md := state.Metadata{
+		Identifier:     input.Identifier,
+		Status:         enums.RunStatusScheduled,
+		Debugger:       input.Debugger,
+		RunType:        input.RunType,
+		Version:        1,
+		RequestVersion: consts.RequestVersionUnknown, // Always use -1 to indicate unset hash version until first request.
+		Context:        input.Context,
+		SpanID:         input.SpanID,
+	}
+	mdByt, err := json.Marshal(md)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling run metadata: %w", err)
+	}
+
+	id := input.Identifier
+	now := time.Now()
+
+	err = m.tx(ctx, func(tx *sql.Tx) error {
+		// Idempotency keys expire after consts.FunctionIdempotencyPeriod, so
+		// remove any expired key before attempting to claim it.
+		_, err := exec(ctx, tx, m.q().Delete(tableIdempotency).Where(
+			sq.C("account_id").Eq(id.AccountID.String()),
+			sq.C("key").Eq(id.IdempotencyKey()),
+			sq.C("expires_at").Lte(now.UnixMilli()),
+		).Prepared(true))
+		if err != nil {
+			return err
+		}
+
+		n, err := exec(ctx, tx, m.q().Insert(tableIdempotency).Rows(sq.Record{
+			"account_id": id.AccountID.String(),
+			"key":        id.IdempotencyKey(),
+			"expires_at": now.Add(consts.FunctionIdempotencyPeriod).UnixMilli(),
+		}).OnConflict(sq.DoNothing()).Prepared(true))
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return state.ErrIdentifierExists
+		}
+
+		n, err = exec(ctx, tx, m.q().Insert(tableRuns).Rows(sq.Record{
+			"run_id":       id.RunID.String(),
+			"account_id":   id.AccountID.String(),
+			"workspace_id": id.WorkspaceID.String(),
+			"function_id":  id.WorkflowID.String(),
+			"status":       int(enums.RunStatusScheduled),
+			"metadata":     mdByt,
+			"events":       events,
+			"event_size":   len(events),
+			"state_size":   len(events) + len(stepsByt) + len(stepInputsByt),
+			"step_count":   len(input.Steps),
+			"created_at":   now.UnixMilli(),
+		}).OnConflict(sq.DoNothing()).Prepared(true))
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return state.ErrIdentifierExists
+		}
+
+		// Save pre-memoized steps, in order, to the stack.
+		for n, step := range input.Steps {
+			output, err := json.Marshal(step.Data)
+			if err != nil {
+				return fmt.Errorf("error marshalling step %q: %w", step.ID, err)
+			}
+			if _, err := exec(ctx, tx, m.q().Insert(tableSteps).Rows(sq.Record{
+				"run_id":   id.RunID.String(),
+				"step_id":  step.ID,
+				"output":   output,
+				"position": n + 1,
+			}).Prepared(true)); err != nil {
+				return err
+			}
+		}
+
+		for _, step := range input.StepInputs {
+			in, err := json.Marshal(step.Data)
+			if err != nil {
+				return fmt.Errorf("error marshalling step input %q: %w", step.ID, err)
+			}
+			if _, err := exec(ctx, tx, m.q().Insert(tableSteps).Rows(sq.Record{
+				"run_id":  id.RunID.String(),
+				"step_id": step.ID,
+				"input":   in,
+			}).OnConflict(sq.DoUpdate("run_id, step_id", sq.Record{
+				"input": sq.I("excluded.input"),
+			})).Prepared(true)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state.NewStateInstance(
+		input.Identifier,
+		md,
+		input.EventBatchData,
+		input.Steps,
+		make([]string, 0),
+	), nil
+}
+
+func (m *mgr) Exists(ctx context.Context, accountID uuid.UUID, runID ulid.ULID) (bool, error) {
+	var exists bool
+	err := m.read(ctx, func(db *sql.DB) error {
+		row, err := queryRow(ctx, db, m.q().From(tableRuns).Select(sq.C("run_id")).Where(
+			sq.C("run_id").Eq(runID.String()),
+		).Prepared(true))
+		if err != nil {
+			return err
+		}
+		var found string
+		err = row.Scan(&found)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		exists = err == nil
+		return err
+	})
+	return exists, err
+}
+
+// run stores the columns of a single state_runs row.
+type run struct {
+	md        state.Metadata
+	events    []byte
+	eventSize int
+	stateSize int
+	stepCount int
+}
+
+func (m *mgr) loadRun(ctx context.Context, q querier, runID ulid.ULID) (*run, error) {
+	row, err := queryRow(ctx, q, m.q().From(tableRuns).Select(
+		"status", "metadata", "events", "event_size", "state_size", "step_count",
+	).Where(sq.C("run_id").Eq(runID.String())).Prepared(true))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		r      run
+		status int
+		mdByt  []byte
+	)
+	err = row.Scan(&status, &mdByt, &r.events, &r.eventSize, &r.stateSize, &r.stepCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, state.ErrRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(mdByt, &r.md); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal run metadata: %w", err)
+	}
+	// The status column is the source of truth, as it's updated atomically.
+	r.md.Status = enums.RunStatus(status)
+	return &r, nil
+}
+
+func (m *mgr) Metadata(ctx context.Context, accountID uuid.UUID, runID ulid.ULID) (*state.Metadata, error) {
+	var r *run
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		r, err = m.loadRun(ctx, db, runID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+	return &r.md, nil
+}
+
+func (m *mgr) IsComplete(ctx context.Context, accountID uuid.UUID, runID ulid.ULID) (bool, error) {
+	md, err := m.Metadata(ctx, accountID, runID)
+	if err != nil {
+		return false, err
+	}
+	return md.Status != enums.RunStatusRunning, nil
+}
+
+func (m *mgr) Load(ctx context.Context, accountID uuid.UUID, runID ulid.ULID) (state.State, error) {
+	var (
+		r       *run
+		actions []state.MemoizedStep
+		stack   []string
+	)
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		if r, err = m.loadRun(ctx, db, runID); err != nil {
+			return fmt.Errorf("failed to load metadata; %w", err)
+		}
+		steps, err := m.loadSteps(ctx, db, runID)
+		if err != nil {
+			return err
+		}
+		for stepID, s := range steps {
+			if s.output == nil {
+				actions = append(actions, state.MemoizedStep{ID: stepID, Data: s.wrappedInput()})
+				continue
+			}
+			var data any
+			if err := json.Unmarshal(s.output, &data); err != nil {
+				return fmt.Errorf("failed to unmarshal step \"%s\" with data \"%s\"; %w", stepID, s.output, err)
+			}
+			actions = append(actions, state.MemoizedStep{ID: stepID, Data: data})
+		}
+		stack, err = m.stack(ctx, db, runID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := []map[string]any{}
+	if err := json.Unmarshal(r.events, &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch; %w", err)
+	}
+
+	return state.NewStateInstance(r.md.Identifier, r.md, events, actions, stack), nil
+}
+
+// LoadEvents returns the raw events which triggered the given run.
+func (m *mgr) LoadEvents(ctx context.Context, runID ulid.ULID) ([]json.RawMessage, error) {
+	var r *run
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		r, err = m.loadRun(ctx, db, runID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := []json.RawMessage{}
+	if err := json.Unmarshal(r.events, &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch; %w", err)
+	}
+	return events, nil
+}
+
+// LoadSteps returns the raw output for each step, with step inputs wrapped as
+// {"input": $input}.
+func (m *mgr) LoadSteps(ctx context.Context, runID ulid.ULID) (map[string]json.RawMessage, error) {
+	result := map[string]json.RawMessage{}
+	err := m.read(ctx, func(db *sql.DB) error {
+		steps, err := m.loadSteps(ctx, db, runID)
+		if err != nil {
+			return err
+		}
+		for stepID, s := range steps {
+			if s.output == nil {
+				result[stepID] = s.wrappedInput()
+				continue
+			}
+			result[stepID] = s.output
+		}
+		return nil
+	})
+	return result, err
+}
+
+type step struct {
+	output []byte
+	input  []byte
+}
+
+func (s step) wrappedInput() json.RawMessage {
+	byt, _ := json.Marshal(map[string]json.RawMessage{
+		"input": json.RawMessage(s.input),
+	})
+	return byt
+}
+
+func (m *mgr) loadSteps(ctx context.Context, q querier, runID ulid.ULID) (map[string]step, error) {
+	rows, err := query(ctx, q, m.q().From(tableSteps).Select("step_id", "output", "input").Where(
+		sq.C("run_id").Eq(runID.String()),
+	).Prepared(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed loading steps; %w", err)
+	}
+	defer rows.Close()
+
+	steps := map[string]step{}
+	for rows.Next() {
+		var (
+			id string
+			s  step
+		)
+		if err := rows.Scan(&id, &s.output, &s.input); err != nil {
+			return nil, err
+		}
+		steps[id] = s
+	}
+	return steps, rows.Err()
+}
+
+func (m *mgr) stack(ctx context.Context, q querier, runID ulid.ULID) ([]string, error) {
+	rows, err := query(ctx, q, m.q().From(tableSteps).Select("step_id").Where(
+		sq.C("run_id").Eq(runID.String()),
+		sq.C("position").IsNotNull(),
+	).Order(sq.C("position").Asc()).Prepared(true))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching stack: %w", err)
+	}
+	defer rows.Close()
+
+	stack := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		stack = append(stack, id)
+	}
+	return stack, rows.Err()
+}
+
+func (m *mgr) StackIndex(ctx context.Context, accountID uuid.UUID, runID ulid.ULID, stepID string) (int, error) {
+	var stack []string
+	err := m.read(ctx, func(db *sql.DB) (err error) {
+		stack, err = m.stack(ctx, db, runID)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(stack) == 0 {
+		return 0, nil
+	}
+	for n, i := range stack {
+		if i == stepID {
+			return n + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("step not found in stack: %s", stepID)
+}
+
+func (m *mgr) UpdateMetadata(ctx context.Context, accountID uuid.UUID, runID ulid.ULID, update state.MetadataUpdate) error {
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		r, err := m.lockRun(ctx, tx, runID)
+		if err != nil {
+			return err
+		}
+
+		r.md.DisableImmediateExecution = update.DisableImmediateExecution
+		r.md.RequestVersion = update.RequestVersion
+		if r.md.StartedAt.IsZero() {
+			r.md.StartedAt = update.StartedAt
+		}
+		if update.HasAI {
+			r.md.HasAI = true
+		}
+
+		byt, err := json.Marshal(r.md)
+		if err != nil {
+			return err
+		}
+		_, err = exec(ctx, tx, m.q().Update(tableRuns).Set(sq.Record{"metadata": byt}).Where(
+			sq.C("run_id").Eq(runID.String()),
+		).Prepared(true))
+		return err
+	})
+}
+
+// lockRun loads the given run, locking the row until the transaction completes
+// where supported.
+func (m *mgr) lockRun(ctx context.Context, tx *sql.Tx, runID ulid.ULID) (*run, error) {
+	if m.dialect == "postgres" {
+		if _, err := exec(ctx, tx, m.q().From(tableRuns).Select("run_id").Where(
+			sq.C("run_id").Eq(runID.String()),
+		).ForUpdate(exp.Wait).Prepared(true)); err != nil {
+			return nil, err
+		}
+	}
+	return m.loadRun(ctx, tx, runID)
+}
+
+func (m *mgr) Cancel(ctx context.Context, id state.Identifier) error {
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		r, err := m.lockRun(ctx, tx, id.RunID)
+		if err != nil {
+			return fmt.Errorf("error cancelling: %w", err)
+		}
+
+		// We only allow cancellation of scheduled or running functions.
+		switch r.md.Status {
+		case enums.RunStatusRunning, enums.RunStatusScheduled:
+		case enums.RunStatusCompleted:
+			return state.ErrFunctionComplete
+		case enums.RunStatusFailed:
+			return state.ErrFunctionFailed
+		case enums.RunStatusCancelled:
+			return state.ErrFunctionCancelled
+		default:
+			return fmt.Errorf("unknown return value cancelling function: %d", r.md.Status)
+		}
+
+		return m.setStatus(ctx, tx, id.RunID, enums.RunStatusCancelled)
+	})
+}
+
+func (m *mgr) SetStatus(ctx context.Context, id state.Identifier, status enums.RunStatus) error {
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		return m.setStatus(ctx, tx, id.RunID, status)
+	})
+}
+
+func (m *mgr) setStatus(ctx context.Context, tx *sql.Tx, runID ulid.ULID, status enums.RunStatus) error {
+	_, err := exec(ctx, tx, m.q().Update(tableRuns).Set(sq.Record{"status": int(status)}).Where(
+		sq.C("run_id").Eq(runID.String()),
+	).Prepared(true))
+	return err
+}
+
+func (m *mgr) SaveResponse(ctx context.Context, i state.Identifier, stepID, marshalledOutput string) error {
+	return m.tx(ctx, func(tx *sql.Tx) error {
+		saved, err := m.saveStep(ctx, tx, i.RunID, stepID, []byte(marshalledOutput))
+		if err != nil {
+			return fmt.Errorf("error saving response: %w", err)
+		}
+		if !saved {
+			return state.ErrDuplicateResponse
+		}
+		return nil
+	})
+}
+
+// saveStep stores output for the given step, pushing the step onto the run's stack.
+// This returns false if the step already has output.
+func (m *mgr) saveStep(ctx context.Context, tx *sql.Tx, runID ulid.ULID, stepID string, output []byte) (bool, error) {
+	r, err := m.lockRun(ctx, tx, runID)
+	if err != nil {
+		return false, err
+	}
+
+	row, err := queryRow(ctx, tx, m.q().From(tableSteps).Select("output", "input").Where(
+		sq.C("run_id").Eq(runID.String()),
+		sq.C("step_id").Eq(stepID),
+	).Prepared(true))
+	if err != nil {
+		return false, err
+	}
+	var existing step
+	err = row.Scan(&existing.output, &existing.input)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	found := err == nil
+	if existing.output != nil {
+		return false, nil
+	}
+
+	// If we're saving output for a step that previously had input, remove the
+	// input from the state size in order to keep it as accurate as possible.
+	position := r.stepCount + 1
+	if _, err := exec(ctx, tx, m.q().Update(tableRuns).Set(sq.Record{
+		"step_count": position,
+		"state_size": r.stateSize + len(output) - len(existing.input),
+	}).Where(sq.C("run_id").Eq(runID.String())).Prepared(true)); err != nil {
+		return false, err
+	}
+
+	if found {
+		_, err = exec(ctx, tx, m.q().Update(tableSteps).Set(sq.Record{
+			"output":   output,
+			"position": position,
+		}).Where(
+			sq.C("run_id").Eq(runID.String()),
+			sq.C("step_id").Eq(stepID),
+		).Prepared(true))
+		return err == nil, err
+	}
+
+	_, err = exec(ctx, tx, m.q().Insert(tableSteps).Rows(sq.Record{
+		"run_id":   runID.String(),
+		"step_id":  stepID,
+		"output":   output,
+		"position": position,
+	}).Prepared(true))
+	return err == nil, err
+}
+
+// Delete removes all state and pauses for the given run.  The run's idempotency
+// key is kept until it expires, preventing the run from being recreated.
+func (m *mgr) Delete(ctx context.Context, i state.Identifier) (bool, error) {
+	var deleted bool
+	err := m.tx(ctx, func(tx *sql.Tx) error {
+		n, err := exec(ctx, tx, m.q().Delete(tableRuns).Where(sq.C("run_id").Eq(i.RunID.String())).Prepared(true))
+		if err != nil {
+			return err
+		}
+		deleted = n > 0
+		if _, err := exec(ctx, tx, m.q().Delete(tableSteps).Where(sq.C("run_id").Eq(i.RunID.String())).Prepared(true)); err != nil {
+			return err
+		}
+		_, err = exec(ctx, tx, m.q().Delete(tablePauses).Where(sq.C("run_id").Eq(i.RunID.String())).Prepared(true))
+		return err
+	})
+	return deleted, err
+}
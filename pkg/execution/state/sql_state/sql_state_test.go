@@ -0,0 +1,93 @@
+package sql_state
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/khulnasoft/inngest/pkg/cqrs/base_cqrs"
+	"github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/execution/state/testharness"
+	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateHarness(t *testing.T) {
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+
+	sm := New(db, "sqlite")
+
+	create := func() (state.Manager, func()) {
+		return sm, func() {
+			for _, table := range []string{tableRuns, tableSteps, tableIdempotency, tablePauses} {
+				_, err := db.Exec("DELETE FROM " + table)
+				require.NoError(t, err)
+			}
+		}
+	}
+
+	testharness.CheckState(t, create)
+}
+
+func TestStateServiceV2(t *testing.T) {
+	ctx := context.Background()
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+
+	svc := MustStateServiceV2(New(db, "sqlite"))
+
+	id := sv2.ID{
+		RunID:      ulid.MustNew(ulid.Now(), rand.Reader),
+		FunctionID: uuid.New(),
+		Tenant: sv2.Tenant{
+			AppID:     uuid.New(),
+			EnvID:     uuid.New(),
+			AccountID: uuid.New(),
+		},
+	}
+	evt := json.RawMessage(`{"name":"test/event","data":{"ok":true}}`)
+
+	err = svc.Create(ctx, sv2.CreateState{
+		Metadata: sv2.Metadata{
+			ID:     id,
+			Config: *sv2.InitConfig(&sv2.Config{}),
+		},
+		Events: []json.RawMessage{evt},
+		StepInputs: []state.MemoizedStep{
+			{ID: "step-b", Data: map[string]any{"in": 1}},
+		},
+	})
+	require.NoError(t, err)
+
+	exists, err := svc.Exists(ctx, id)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, svc.SaveStep(ctx, id, "step-a", []byte(`{"data":"a"}`)))
+	require.ErrorIs(t, svc.SaveStep(ctx, id, "step-a", []byte(`{"data":"dupe"}`)), state.ErrDuplicateResponse)
+
+	loaded, err := svc.LoadState(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, id, loaded.Metadata.ID)
+	require.Equal(t, []string{"step-a"}, loaded.Metadata.Stack)
+	require.Equal(t, 1, loaded.Metadata.Metrics.StepCount)
+	require.Len(t, loaded.Events, 1)
+	require.JSONEq(t, string(evt), string(loaded.Events[0]))
+	require.JSONEq(t, `{"data":"a"}`, string(loaded.Steps["step-a"]))
+	require.JSONEq(t, `{"input":{"in":1}}`, string(loaded.Steps["step-b"]))
+
+	metrics, err := svc.FunctionMetrics(ctx, id.FunctionID)
+	require.NoError(t, err)
+	require.Equal(t, loaded.Metadata.Metrics, metrics)
+
+	deleted, err := svc.Delete(ctx, id)
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	_, err = svc.LoadMetadata(ctx, id)
+	require.ErrorIs(t, err, state.ErrRunNotFound)
+}
@@ -0,0 +1,221 @@
+package sql_state
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/doug-martin/goqu/v9"
+	"github.com/google/uuid"
+	statev1 "github.com/khulnasoft/inngest/pkg/execution/state"
+	"github.com/khulnasoft/inngest/pkg/execution/state/v2"
+)
+
+func MustStateServiceV2(m statev1.Manager) state.StateService {
+	v2, err := StateServiceV2(m)
+	if err != nil {
+		panic(err)
+	}
+	return v2
+}
+
+func StateServiceV2(m statev1.Manager) (state.StateService, error) {
+	mgr, ok := m.(*mgr)
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %T into type sql_state.*mgr", m)
+	}
+	return v2{mgr}, nil
+}
+
+type v2 struct {
+	mgr *mgr
+}
+
+// Create creates new state in the store for the given run ID.
+func (v v2) Create(ctx context.Context, s state.CreateState) error {
+	batchData := make([]map[string]any, len(s.Events))
+	for n, evt := range s.Events {
+		data := map[string]any{}
+		if err := json.Unmarshal(evt, &data); err != nil {
+			return err
+		}
+		batchData[n] = data
+	}
+	_, err := v.mgr.New(ctx, statev1.Input{
+		Identifier: statev1.Identifier{
+			RunID:                 s.Metadata.ID.RunID,
+			WorkflowID:            s.Metadata.ID.FunctionID,
+			WorkflowVersion:       s.Metadata.Config.FunctionVersion,
+			EventID:               s.Metadata.Config.EventID(),
+			EventIDs:              s.Metadata.Config.EventIDs,
+			Key:                   s.Metadata.Config.Idempotency,
+			AccountID:             s.Metadata.ID.Tenant.AccountID,
+			WorkspaceID:           s.Metadata.ID.Tenant.EnvID,
+			AppID:                 s.Metadata.ID.Tenant.AppID,
+			OriginalRunID:         s.Metadata.Config.OriginalRunID,
+			ReplayID:              s.Metadata.Config.ReplayID,
+			PriorityFactor:        s.Metadata.Config.PriorityFactor,
+			CustomConcurrencyKeys: s.Metadata.Config.CustomConcurrencyKeys,
+			BatchID:               s.Metadata.Config.BatchID,
+		},
+		EventBatchData: batchData,
+		Context:        s.Metadata.Config.Context,
+		SpanID:         s.Metadata.Config.SpanID,
+		Steps:          s.Steps,
+		StepInputs:     s.StepInputs,
+	})
+	return err
+}
+
+// Delete deletes state, metadata, and pauses for the run from the store.
+func (v v2) Delete(ctx context.Context, id state.ID) (bool, error) {
+	return v.mgr.Delete(ctx, statev1.Identifier{
+		RunID:      id.RunID,
+		WorkflowID: id.FunctionID,
+		AccountID:  id.Tenant.AccountID,
+	})
+}
+
+func (v v2) Exists(ctx context.Context, id state.ID) (bool, error) {
+	return v.mgr.Exists(ctx, id.Tenant.AccountID, id.RunID)
+}
+
+// LoadEvents returns all events for a run.
+func (v v2) LoadEvents(ctx context.Context, id state.ID) ([]json.RawMessage, error) {
+	return v.mgr.LoadEvents(ctx, id.RunID)
+}
+
+// LoadSteps returns all steps for a run.
+func (v v2) LoadSteps(ctx context.Context, id state.ID) (map[string]json.RawMessage, error) {
+	return v.mgr.LoadSteps(ctx, id.RunID)
+}
+
+// LoadState returns all state for a run.
+func (v v2) LoadState(ctx context.Context, id state.ID) (state.State, error) {
+	var (
+		err   error
+		state = state.State{}
+	)
+
+	if state.Metadata, err = v.LoadMetadata(ctx, id); err != nil {
+		return state, err
+	}
+	if state.Events, err = v.LoadEvents(ctx, id); err != nil {
+		return state, err
+	}
+	if state.Steps, err = v.LoadSteps(ctx, id); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// LoadMetadata returns metadata for a given run
+func (v v2) LoadMetadata(ctx context.Context, id state.ID) (state.Metadata, error) {
+	var (
+		r     *run
+		stack []string
+	)
+	err := v.mgr.read(ctx, func(db *sql.DB) (err error) {
+		if r, err = v.mgr.loadRun(ctx, db, id.RunID); err != nil {
+			return err
+		}
+		stack, err = v.mgr.stack(ctx, db, id.RunID)
+		return err
+	})
+	if err != nil {
+		return state.Metadata{}, err
+	}
+
+	md := r.md
+	result := state.Metadata{
+		ID: state.ID{
+			RunID:      md.Identifier.RunID,
+			FunctionID: md.Identifier.WorkflowID,
+			Tenant: state.Tenant{
+				AppID:     md.Identifier.AppID,
+				EnvID:     md.Identifier.WorkspaceID,
+				AccountID: md.Identifier.AccountID,
+			},
+		},
+		Config: *state.InitConfig(&state.Config{
+			FunctionVersion:       md.Identifier.WorkflowVersion,
+			SpanID:                md.SpanID,
+			StartedAt:             md.StartedAt,
+			EventIDs:              md.Identifier.EventIDs,
+			BatchID:               md.Identifier.BatchID,
+			RequestVersion:        md.RequestVersion,
+			Idempotency:           md.Identifier.Key,
+			ReplayID:              md.Identifier.ReplayID,
+			OriginalRunID:         md.Identifier.OriginalRunID,
+			PriorityFactor:        md.Identifier.PriorityFactor,
+			CustomConcurrencyKeys: md.Identifier.CustomConcurrencyKeys,
+			Context:               md.Context,
+			ForceStepPlan:         md.DisableImmediateExecution,
+			HasAI:                 md.HasAI,
+		}),
+		Stack: stack,
+		Metrics: state.RunMetrics{
+			EventSize: r.eventSize,
+			StateSize: r.stateSize,
+			StepCount: r.stepCount,
+		},
+	}
+
+	// initialize function trace eagerly; this needs to unmarshal the trace carrier
+	_ = result.Config.FunctionTrace()
+
+	return result, nil
+}
+
+// UpdateMetadata updates configuration on the state, eg. setting the execution
+// version after communicating with the SDK.
+func (v v2) UpdateMetadata(ctx context.Context, id state.ID, mutation state.MutableConfig) error {
+	return v.mgr.UpdateMetadata(ctx, id.Tenant.AccountID, id.RunID, statev1.MetadataUpdate{
+		DisableImmediateExecution: mutation.ForceStepPlan,
+		RequestVersion:            mutation.RequestVersion,
+		StartedAt:                 mutation.StartedAt,
+		HasAI:                     mutation.HasAI,
+	})
+}
+
+// SaveStep saves step output for the given run ID and step ID.
+func (v v2) SaveStep(ctx context.Context, id state.ID, stepID string, data []byte) error {
+	v1id := statev1.Identifier{
+		RunID:      id.RunID,
+		WorkflowID: id.FunctionID,
+		AccountID:  id.Tenant.AccountID,
+	}
+	return v.mgr.SaveResponse(ctx, v1id, stepID, string(data))
+}
+
+// FunctionMetrics returns state metrics for all stored runs of a given function.
+func (v v2) FunctionMetrics(ctx context.Context, fnID uuid.UUID) (state.RunMetrics, error) {
+	return v.metrics(ctx, sq.C("function_id").Eq(fnID.String()))
+}
+
+// EnvMetrics returns state metrics for all stored runs within an environment.
+func (v v2) EnvMetrics(ctx context.Context, envID uuid.UUID) (state.RunMetrics, error) {
+	return v.metrics(ctx, sq.C("workspace_id").Eq(envID.String()))
+}
+
+// AccountMetrics returns state metrics for all stored runs within an account.
+func (v v2) AccountMetrics(ctx context.Context, accountID uuid.UUID) (state.RunMetrics, error) {
+	return v.metrics(ctx, sq.C("account_id").Eq(accountID.String()))
+}
+
+func (v v2) metrics(ctx context.Context, filter sq.Expression) (state.RunMetrics, error) {
+	var m state.RunMetrics
+	err := v.mgr.read(ctx, func(db *sql.DB) error {
+		row, err := queryRow(ctx, db, v.mgr.q().From(tableRuns).Select(
+			sq.COALESCE(sq.SUM("state_size"), 0),
+			sq.COALESCE(sq.SUM("event_size"), 0),
+			sq.COALESCE(sq.SUM("step_count"), 0),
+		).Where(filter).Prepared(true))
+		if err != nil {
+			return err
+		}
+		return row.Scan(&m.StateSize, &m.EventSize, &m.StepCount)
+	})
+	return m, err
+}
@@ -27,11 +27,15 @@ var (
 	// that doesn't exist within the backing state store.
 	ErrPauseNotFound       = fmt.Errorf("pause not found")
 	ErrInvokePauseNotFound = fmt.Errorf("invoke pause not found")
+	ErrSignalPauseNotFound = fmt.Errorf("signal pause not found")
 	ErrRunNotFound         = fmt.Errorf("run not found in state store")
 	// ErrPauseLeased is returned when attempting to lease a pause that is
 	// already leased by another event.
 	ErrPauseLeased        = fmt.Errorf("pause already leased")
 	ErrPauseAlreadyExists = fmt.Errorf("pause already exists")
+	// ErrSignalConflict is returned when saving a signal pause whose signal
+	// is already being waited on by another run in the same workspace.
+	ErrSignalConflict     = fmt.Errorf("signal already exists")
 	ErrIdentifierExists   = fmt.Errorf("identifier already exists")
 	ErrFunctionCancelled  = fmt.Errorf("function cancelled")
 	ErrFunctionComplete   = fmt.Errorf("function completed")
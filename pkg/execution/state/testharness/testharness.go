@@ -100,6 +100,7 @@ func CheckState(t *testing.T, gen Generator) {
 		"PausesByEvent/Consumed":           checkPausesByEvent_consumed,
 		"PauseByID":                        checkPauseByID,
 		"PausesByID":                       checkPausesByID,
+		"PauseBySignalID":                  checkPauseBySignalID,
 		"Idempotency":                      checkIdempotency,
 		"SetStatus":                        checkSetStatus,
 		"Cancel":                           checkCancel,
@@ -1326,6 +1327,57 @@ func checkPauseByID(t *testing.T, m state.Manager) {
 	require.Error(t, state.ErrPauseNotFound, err)
 }
 
+func checkPauseBySignalID(t *testing.T, m state.Manager) {
+	ctx := context.Background()
+	s := setup(t, m)
+
+	signal := "signal-" + uuid.NewString()
+	wsID := s.Identifier().WorkspaceID
+
+	pause := state.Pause{
+		ID:          uuid.New(),
+		WorkspaceID: wsID,
+		Identifier:  s.Identifier(),
+		Outgoing:    inngest.TriggerName,
+		Incoming:    w.Steps[0].ID,
+		Expires:     state.Time(time.Now().Add(time.Minute).Truncate(time.Millisecond).UTC()),
+		SignalID:    &signal,
+		DataKey:     w.Steps[0].ID,
+	}
+	err := m.SavePause(ctx, pause)
+	require.NoError(t, err)
+
+	found, err := m.PauseBySignalID(ctx, wsID, signal)
+	require.NoError(t, err)
+	require.EqualValues(t, pause, *found)
+
+	// Signals are unique per workspace.
+	dupe := pause
+	dupe.ID = uuid.New()
+	err = m.SavePause(ctx, dupe)
+	require.ErrorIs(t, err, state.ErrSignalConflict)
+
+	// The same signal can be used in another workspace.
+	other := dupe
+	other.ID = uuid.New()
+	other.WorkspaceID = uuid.New()
+	err = m.SavePause(ctx, other)
+	require.NoError(t, err)
+
+	_, err = m.PauseBySignalID(ctx, wsID, "unknown")
+	require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+
+	// Consuming the pause frees up the signal.
+	err = m.ConsumePause(ctx, pause.ID, nil)
+	require.NoError(t, err)
+
+	_, err = m.PauseBySignalID(ctx, wsID, signal)
+	require.ErrorIs(t, err, state.ErrSignalPauseNotFound)
+
+	err = m.SavePause(ctx, dupe)
+	require.NoError(t, err)
+}
+
 func checkPausesByID(t *testing.T, m state.Manager) {
 	ctx := context.Background()
 	s := setup(t, m)
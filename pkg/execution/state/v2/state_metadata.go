@@ -21,6 +21,7 @@ const (
 	traceLinkKey    = "__tracelink"
 	debounceKey     = "__debounce"
 	evtmapKey       = "__evtmap"
+	singletonKey    = "__singleton"
 )
 
 type ID struct {
@@ -240,6 +241,33 @@ func (c *Config) TraceLink() *string {
 	return nil
 }
 
+// SetSingletonKey stores the singleton key claimed by the run.
+func (c *Config) SetSingletonKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.initContext()
+	c.Context[singletonKey] = key
+}
+
+// SingletonKey retrieves the singleton key claimed by the run, if any.
+func (c *Config) SingletonKey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.Context == nil {
+		return ""
+	}
+
+	if v, ok := c.Context[singletonKey]; ok {
+		if key, ok := v.(string); ok {
+			return key
+		}
+	}
+
+	return ""
+}
+
 func (c *Config) SetDebounceFlag(flag bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
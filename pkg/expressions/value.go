@@ -0,0 +1,134 @@
+package expressions
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var structValueType = reflect.TypeOf(&structpb.Value{})
+
+// ValueEvaluator evaluates an expression which may return any value, such as a map
+// or list, rather than a boolean.  It's goroutine safe.
+//
+// Unlike boolean evaluators, expressions are evaluated without partial evaluation,
+// allowing macros such as map() and filter() to be used.  Referencing attributes
+// missing from the input is an error;  use has() to check for optional attributes.
+type ValueEvaluator struct {
+	program cel.Program
+}
+
+// NewValueEvaluator compiles an expression, loading the compiled expression from
+// the cache if possible.
+func NewValueEvaluator(ctx context.Context, expression string) (*ValueEvaluator, error) {
+	if item := cache.Get("value:" + expression); item != nil {
+		item.Extend(CacheExtendTime)
+		return item.Value().(*ValueEvaluator), nil
+	}
+
+	e, err := env()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := e.Parse(expression)
+	if issues != nil {
+		return nil, NewCompileError(issues.Err())
+	}
+	program, err := e.Program(ast)
+	if err != nil {
+		return nil, NewCompileError(err)
+	}
+
+	eval := &ValueEvaluator{program: program}
+	cache.Set("value:"+expression, eval, CacheTTL)
+	return eval, nil
+}
+
+// Evaluate evaluates the expression with the given input.  The result is converted
+// to the types produced by unmarshalling JSON: maps of strings, slices, strings,
+// float64s, bools and nil.
+func (v *ValueEvaluator) Evaluate(ctx context.Context, input map[string]any) (any, error) {
+	result, _, err := v.program.ContextEval(ctx, mapify(input))
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating expression: %w", err)
+	}
+	return jsonValue(result)
+}
+
+// EvaluateValue is a helper function to create a new, cached value evaluator to
+// evaluate the given input immediately.
+func EvaluateValue(ctx context.Context, expression string, input map[string]any) (any, error) {
+	eval, err := NewValueEvaluator(ctx, expression)
+	if err != nil {
+		return nil, err
+	}
+	return eval.Evaluate(ctx, input)
+}
+
+// jsonValue converts values returned from cel to JSON compatible types.  Maps and
+// lists created within an expression are returned as cel values, as are any
+// values nested within them.
+func jsonValue(v any) (any, error) {
+	switch val := v.(type) {
+	case ref.Val:
+		native, err := val.ConvertToNative(structValueType)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported value of type %s: %w", val.Type().TypeName(), err)
+		}
+		return native.(*structpb.Value).AsInterface(), nil
+	case map[ref.Val]ref.Val:
+		res := make(map[string]any, len(val))
+		for k, item := range val {
+			key, ok := k.Value().(string)
+			if !ok {
+				return nil, fmt.Errorf("map keys must be strings, got %T", k.Value())
+			}
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			res[key] = converted
+		}
+		return res, nil
+	case map[string]any:
+		res := make(map[string]any, len(val))
+		for k, item := range val {
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = converted
+		}
+		return res, nil
+	case []any:
+		res := make([]any, len(val))
+		for i, item := range val {
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = converted
+		}
+		return res, nil
+	case []ref.Val:
+		res := make([]any, len(val))
+		for i, item := range val {
+			converted, err := jsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = converted
+		}
+		return res, nil
+	case int64:
+		return float64(val), nil
+	case uint64:
+		return float64(val), nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,76 @@
+package expressions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateValue(t *testing.T) {
+	ctx := context.Background()
+	input := map[string]any{
+		"body": map[string]any{
+			"type":   "charge.succeeded",
+			"amount": 1200.0,
+			"items":  []any{map[string]any{"sku": "a"}, map[string]any{"sku": "b"}},
+		},
+		"headers": map[string]any{"x-github-event": "push"},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   any
+	}{
+		{
+			name:       "map",
+			expression: `{"name": "stripe/" + body.type, "data": {"amount": body.amount, "n": 1}}`,
+			expected: map[string]any{
+				"name": "stripe/charge.succeeded",
+				"data": map[string]any{"amount": 1200.0, "n": 1.0},
+			},
+		},
+		{
+			name:       "list",
+			expression: `body.items.map(i, {"name": "item/" + i.sku, "data": i})`,
+			expected: []any{
+				map[string]any{"name": "item/a", "data": map[string]any{"sku": "a"}},
+				map[string]any{"name": "item/b", "data": map[string]any{"sku": "b"}},
+			},
+		},
+		{
+			name:       "headers",
+			expression: `"github/" + headers["x-github-event"]`,
+			expected:   "github/push",
+		},
+		{
+			name:       "optional attributes",
+			expression: `{"name": "x", "data": {"coupon": has(body.coupon) ? body.coupon : null}}`,
+			expected:   map[string]any{"name": "x", "data": map[string]any{"coupon": nil}},
+		},
+		{
+			name:       "conditional",
+			expression: `body.type == "charge.failed" ? [{"name": "charge/failed"}] : []`,
+			expected:   []any{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := EvaluateValue(ctx, test.expression, input)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("missing attributes error", func(t *testing.T) {
+		_, err := EvaluateValue(ctx, `body.coupon`, input)
+		require.ErrorContains(t, err, "no such key: coupon")
+	})
+
+	t.Run("invalid expressions error", func(t *testing.T) {
+		_, err := NewValueEvaluator(ctx, `{"name": `)
+		require.ErrorIs(t, err, &CompileError{})
+	})
+}
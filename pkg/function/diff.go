@@ -0,0 +1,115 @@
+package function
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ConfigChange is a single difference between two function configs.
+type ConfigChange struct {
+	// Path is the path of the changed field, eg. "concurrency.limits[0].key".
+	Path string `json:"path"`
+	// From is the JSON-encoded value in the first config, or nil if the field
+	// was added.
+	From *string `json:"from,omitempty"`
+	// To is the JSON-encoded value in the second config, or nil if the field
+	// was removed.
+	To *string `json:"to,omitempty"`
+}
+
+// versionField is the config field holding the function's version, which is
+// ignored when comparing configs as it differs between every version.
+const versionField = "fv"
+
+// DiffConfigs returns the differences between two function configs, ordered by
+// path.  The function version is ignored, so configs which only differ by version
+// have no changes.
+func DiffConfigs(from, to string) ([]ConfigChange, error) {
+	var a, b any
+	if err := json.Unmarshal([]byte(from), &a); err != nil {
+		return nil, fmt.Errorf("invalid function config: %w", err)
+	}
+	if err := json.Unmarshal([]byte(to), &b); err != nil {
+		return nil, fmt.Errorf("invalid function config: %w", err)
+	}
+	if am, ok := a.(map[string]any); ok {
+		delete(am, versionField)
+	}
+	if bm, ok := b.(map[string]any); ok {
+		delete(bm, versionField)
+	}
+
+	changes := []ConfigChange{}
+	diffValues("", a, b, &changes)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// SameConfig returns whether two function configs are equal, ignoring the
+// function version.
+func SameConfig(a, b string) (bool, error) {
+	changes, err := DiffConfigs(a, b)
+	if err != nil {
+		return false, err
+	}
+	return len(changes) == 0, nil
+}
+
+func diffValues(path string, a, b any, changes *[]ConfigChange) {
+	am, aIsMap := a.(map[string]any)
+	bm, bIsMap := b.(map[string]any)
+	if aIsMap && bIsMap {
+		for k, av := range am {
+			bv, ok := bm[k]
+			if !ok {
+				*changes = append(*changes, ConfigChange{Path: joinPath(path, k), From: encode(av)})
+				continue
+			}
+			diffValues(joinPath(path, k), av, bv, changes)
+		}
+		for k, bv := range bm {
+			if _, ok := am[k]; !ok {
+				*changes = append(*changes, ConfigChange{Path: joinPath(path, k), To: encode(bv)})
+			}
+		}
+		return
+	}
+
+	as, aIsSlice := a.([]any)
+	bs, bIsSlice := b.([]any)
+	if aIsSlice && bIsSlice {
+		for i := 0; i < len(as) || i < len(bs); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(bs):
+				*changes = append(*changes, ConfigChange{Path: p, From: encode(as[i])})
+			case i >= len(as):
+				*changes = append(*changes, ConfigChange{Path: p, To: encode(bs[i])})
+			default:
+				diffValues(p, as[i], bs[i], changes)
+			}
+		}
+		return
+	}
+
+	from, to := encode(a), encode(b)
+	if *from != *to {
+		*changes = append(*changes, ConfigChange{Path: path, From: from, To: to})
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func encode(v any) *string {
+	byt, _ := json.Marshal(v)
+	s := string(byt)
+	return &s
+}
@@ -0,0 +1,52 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	from := `{
+		"id": "fn",
+		"name": "Charge",
+		"fv": 1,
+		"concurrency": {"limits": [{"limit": 5, "key": "event.data.user_id"}]},
+		"triggers": [{"event": "charge/requested"}],
+		"debounce": {"period": "5s"}
+	}`
+	to := `{
+		"id": "fn",
+		"name": "Charge",
+		"fv": 2,
+		"concurrency": {"limits": [{"limit": 5, "key": "event.data.account_id"}]},
+		"triggers": [{"event": "charge/requested"}, {"cron": "0 * * * *"}],
+		"idempotency": "event.data.id"
+	}`
+
+	changes, err := DiffConfigs(from, to)
+	require.NoError(t, err)
+
+	str := func(s string) *string { return &s }
+	require.Equal(t, []ConfigChange{
+		{Path: "concurrency.limits[0].key", From: str(`"event.data.user_id"`), To: str(`"event.data.account_id"`)},
+		{Path: "debounce", From: str(`{"period":"5s"}`)},
+		{Path: "idempotency", To: str(`"event.data.id"`)},
+		{Path: "triggers[1]", To: str(`{"cron":"0 * * * *"}`)},
+	}, changes)
+
+	t.Run("ignores the function version", func(t *testing.T) {
+		same, err := SameConfig(`{"id":"fn","fv":1}`, `{"fv":2,"id":"fn"}`)
+		require.NoError(t, err)
+		require.True(t, same)
+
+		same, err = SameConfig(`{"id":"fn","fv":1}`, `{"id":"other","fv":1}`)
+		require.NoError(t, err)
+		require.False(t, same)
+	})
+
+	t.Run("errors on invalid configs", func(t *testing.T) {
+		_, err := DiffConfigs(`{`, `{}`)
+		require.Error(t, err)
+	})
+}
@@ -12,6 +12,9 @@ import (
 // When a given FunctionVersion is live (after a deploy), the valid from timestamp is set.
 // When a given FunctionVersion is no longer live (after a new version has been deployed),
 // the valid to timestamp will be set, recording the entire time window which the version was live.
+//
+// Versions are immutable once created.  Rolling back to an earlier config creates a new
+// version with the earlier config.
 type FunctionVersion struct {
 	FunctionID string
 	Version    uint
@@ -24,4 +27,18 @@ type FunctionVersion struct {
 	ValidTo   *time.Time
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Pinned records whether the function is pinned to this version.  New configs
+	// synced while a function is pinned are stored as drafts and aren't made live.
+	Pinned bool
+}
+
+// Live returns whether this is the function's live version.
+func (f FunctionVersion) Live() bool {
+	return f.ValidFrom != nil && f.ValidTo == nil
+}
+
+// Draft returns whether this version has never been live.
+func (f FunctionVersion) Draft() bool {
+	return f.ValidFrom == nil
 }
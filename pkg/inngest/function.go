@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
 	"strings"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/gosimple/slug"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/enums"
 	"github.com/khulnasoft/inngest/pkg/expressions"
 	"github.com/khulnasoft/inngest/pkg/syscode"
 	"github.com/xhit/go-str2duration/v2"
@@ -84,6 +86,15 @@ type Function struct {
 	// time.
 	Throttle *Throttle `json:"throttle,omitempty"`
 
+	// Singleton ensures that only a single run of the function is in progress at any time,
+	// optionally constrained by a key.  New runs are either skipped or cancel the run in
+	// progress, depending on the mode.
+	Singleton *Singleton `json:"singleton,omitempty"`
+
+	// AI configures caching and token budgets for AI gateway requests made by the
+	// function via step.ai.infer.
+	AI *AI `json:"ai,omitempty"`
+
 	// Cancel specifies cancellation signals for the function
 	Cancel []Cancel `json:"cancel,omitempty"`
 
@@ -129,6 +140,98 @@ func (r RateLimit) IsValid(ctx context.Context) error {
 	return nil
 }
 
+type Singleton struct {
+	// Key is an optional expression to constrain singleton runs using event data.  For
+	// example, "event.data.user_id" ensures that only one run is in progress for each
+	// user.
+	Key *string `json:"key,omitempty"`
+	// Mode specifies whether new runs are skipped, or cancel the run in progress.
+	Mode enums.SingletonMode `json:"mode"`
+}
+
+func (s Singleton) IsValid(ctx context.Context) error {
+	if !s.Mode.IsASingletonMode() {
+		return fmt.Errorf("mode is invalid: %d", s.Mode)
+	}
+
+	if s.Key != nil {
+		if err := expressions.Validate(ctx, *s.Key); err != nil {
+			return fmt.Errorf("key is invalid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// AI configures AI gateway requests made by a function.
+type AI struct {
+	// CacheTTL caches successful inference responses for the given period, eg.
+	// "1h".  Responses are keyed on the request's canonicalized body, so identical
+	// requests made by any run of the function reuse the cached response.
+	CacheTTL string `json:"cacheTTL,omitempty"`
+	// TokenBudget limits the tokens used by inference requests across every run of
+	// the function.
+	TokenBudget *TokenBudget `json:"tokenBudget,omitempty"`
+}
+
+// CacheDuration returns the parsed cache TTL, or zero if responses are not cached.
+func (a AI) CacheDuration() time.Duration {
+	if a.CacheTTL == "" {
+		return 0
+	}
+	dur, _ := str2duration.ParseDuration(a.CacheTTL)
+	return dur
+}
+
+func (a AI) IsValid() error {
+	if a.CacheTTL != "" {
+		dur, err := str2duration.ParseDuration(a.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("failed to parse cache TTL: %w", err)
+		}
+		if dur <= 0 || dur > consts.MaxAICacheTTL {
+			return fmt.Errorf("cache TTL must be between 1s and %s", consts.MaxAICacheTTL)
+		}
+	}
+	if a.TokenBudget != nil {
+		if err := a.TokenBudget.IsValid(); err != nil {
+			return fmt.Errorf("token budget is invalid: %w", err)
+		}
+	}
+	return nil
+}
+
+// TokenBudget limits the number of tokens used within a period.
+type TokenBudget struct {
+	// Limit is the number of input and output tokens allowed within the period.
+	Limit int64 `json:"limit"`
+	// Period is the period that the budget resets after, eg. "24h".
+	Period string `json:"period"`
+}
+
+// PeriodDuration returns the parsed period.
+func (t TokenBudget) PeriodDuration() time.Duration {
+	dur, _ := str2duration.ParseDuration(t.Period)
+	return dur
+}
+
+func (t TokenBudget) IsValid() error {
+	if t.Limit <= 0 {
+		return errors.New("limit must be greater than 0")
+	}
+	if t.Period == "" {
+		return errors.New("period must be specified")
+	}
+	dur, err := str2duration.ParseDuration(t.Period)
+	if err != nil {
+		return fmt.Errorf("failed to parse time duration: %w", err)
+	}
+	if dur < time.Second {
+		return errors.New("period must be at least 1s")
+	}
+	return nil
+}
+
 // DeterministicUUID returns a deterministic V3 UUID based off of the SHA1
 // hash of the function's name.
 func (f *Function) DeterministicUUID() uuid.UUID {
@@ -410,6 +513,23 @@ func (f Function) Validate(ctx context.Context) error {
 		}
 	}
 
+	// Validate singleton expression
+	if f.Singleton != nil {
+		if singletonErr := f.Singleton.IsValid(ctx); singletonErr != nil {
+			err = multierror.Append(err, fmt.Errorf("Singleton is invalid: %w", singletonErr))
+		}
+		// NOTE: Singleton keys are evaluated against a single event.
+		if f.EventBatch != nil {
+			err = multierror.Append(err, fmt.Errorf("A function cannot specify Singleton and Batch together"))
+		}
+	}
+
+	if f.AI != nil {
+		if aiErr := f.AI.IsValid(); aiErr != nil {
+			err = multierror.Append(err, fmt.Errorf("AI config is invalid: %w", aiErr))
+		}
+	}
+
 	return err
 }
 
@@ -442,6 +562,12 @@ func (f Function) RunPriorityFactor(ctx context.Context, event map[string]any) (
 		result = int64(v)
 	case int64:
 		result = v
+	case float64:
+		// Event data is decoded from JSON, which always uses float64 for numbers.
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("Priority.Run expression returned non-int: %v", val)
+		}
+		result = int64(v)
 	default:
 		return 0, fmt.Errorf("Priority.Run expression returned non-int: %v", val)
 	}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/enums"
 	"github.com/stretchr/testify/require"
 )
 
@@ -119,9 +121,63 @@ func TestValidate(t *testing.T) {
 			require.NotNil(t, err)
 			require.Contains(t, err.Error(), "Functions must contain one step")
 		})
+
+		t.Run("With an invalid singleton", func(t *testing.T) {
+			f := Function{
+				Name: "hi",
+				Triggers: []Trigger{
+					{
+						EventTrigger: &EventTrigger{
+							Event: "fail",
+						},
+					},
+				},
+				Singleton: &Singleton{
+					Key:  strptr("event.data.id +"),
+					Mode: enums.SingletonMode(5),
+				},
+				Steps: []Step{
+					{
+						ID:   "step",
+						Name: "Function body",
+						URI:  "http://lol/what.xml.api",
+					},
+				},
+			}
+
+			err := f.Validate(context.Background())
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "Singleton is invalid: mode is invalid")
+		})
 	})
 }
 
+func TestSingletonIsValid(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, Singleton{}.IsValid(ctx))
+	require.NoError(t, Singleton{Key: strptr("event.data.user_id"), Mode: enums.SingletonModeCancel}.IsValid(ctx))
+	require.ErrorContains(t, Singleton{Mode: enums.SingletonMode(5)}.IsValid(ctx), "mode is invalid")
+	require.ErrorContains(t, Singleton{Key: strptr("event.data.user_id +")}.IsValid(ctx), "key is invalid")
+
+	var s Singleton
+	require.NoError(t, json.Unmarshal([]byte(`{"key":"event.data.id","mode":"cancel"}`), &s))
+	require.Equal(t, enums.SingletonModeCancel, s.Mode)
+}
+
+func TestAIIsValid(t *testing.T) {
+	require.NoError(t, AI{}.IsValid())
+	require.NoError(t, AI{CacheTTL: "1h", TokenBudget: &TokenBudget{Limit: 1000, Period: "24h"}}.IsValid())
+	require.ErrorContains(t, AI{CacheTTL: "soon"}.IsValid(), "cache TTL")
+	require.ErrorContains(t, AI{CacheTTL: "30d"}.IsValid(), "cache TTL must be between")
+	require.ErrorContains(t, AI{TokenBudget: &TokenBudget{Period: "1h"}}.IsValid(), "limit must be greater than 0")
+	require.ErrorContains(t, AI{TokenBudget: &TokenBudget{Limit: 10}}.IsValid(), "period must be specified")
+
+	require.Equal(t, time.Hour, AI{CacheTTL: "1h"}.CacheDuration())
+	require.Equal(t, time.Duration(0), AI{}.CacheDuration())
+	require.Equal(t, 24*time.Hour, TokenBudget{Limit: 1, Period: "1d"}.PeriodDuration())
+}
+
 func TestRunPriorityFactor(t *testing.T) {
 	ctx := context.Background()
 	f := Function{}
@@ -184,6 +240,23 @@ func TestRunPriorityFactor(t *testing.T) {
 		require.EqualValues(t, consts.PriorityFactorMin, pf)
 	})
 
+	t.Run("With a JSON number in the expression", func(t *testing.T) {
+		f.Priority = &Priority{
+			Run: strptr("event.data.priority"),
+		}
+
+		pf, err := f.RunPriorityFactor(ctx, map[string]any{
+			"data": map[string]any{"priority": float64(100)},
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 100, pf)
+
+		_, err = f.RunPriorityFactor(ctx, map[string]any{
+			"data": map[string]any{"priority": 1.5},
+		})
+		require.ErrorContains(t, err, "Priority.Run expression returned non-int: 1.5")
+	})
+
 	t.Run("With missing data", func(t *testing.T) {
 		f.Priority = &Priority{
 			Run: strptr("event.data.priority"),
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cespare/xxhash/v2"
 	"github.com/hashicorp/go-multierror"
 	"github.com/khulnasoft/inngest/pkg/consts"
 	"github.com/khulnasoft/inngest/pkg/expressions"
 	cron "github.com/robfig/cron/v3"
+	"github.com/xhit/go-str2duration/v2"
 )
 
 // Triggerable represents a single or multiple triggers for a function.
@@ -149,14 +151,132 @@ func (e EventTrigger) Validate(ctx context.Context) error {
 // CronTrigger is a trigger which invokes the function on a CRON schedule.
 type CronTrigger struct {
 	Cron string `json:"cron"`
+
+	// Timezone is an optional IANA timezone, eg. "Europe/Berlin", that the
+	// schedule is evaluated in.  The timezone may also be given as a "TZ=" or
+	// "CRON_TZ=" prefix on the schedule.  Schedules without a timezone run
+	// in the server's local timezone.
+	Timezone *string `json:"timezone,omitempty"`
+
+	// CatchUp configures whether ticks missed while the server was down are
+	// enqueued on startup.  By default missed ticks are dropped.
+	CatchUp *CronCatchUp `json:"catchUp,omitempty"`
 }
 
-func (c CronTrigger) Validate(ctx context.Context) error {
-	_, err := cron.
-		NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).
-		Parse(c.Cron)
+// CronCatchUpPolicy represents how missed cron ticks are enqueued on startup.
+type CronCatchUpPolicy string
+
+const (
+	// CronCatchUpNone drops all missed ticks.
+	CronCatchUpNone CronCatchUpPolicy = "none"
+	// CronCatchUpLatest enqueues only the most recent missed tick.
+	CronCatchUpLatest CronCatchUpPolicy = "latest"
+	// CronCatchUpAll enqueues every missed tick within the catch-up window.
+	CronCatchUpAll CronCatchUpPolicy = "all"
+)
+
+// CronCatchUp configures catching up on cron ticks missed during downtime.
+type CronCatchUp struct {
+	Policy CronCatchUpPolicy `json:"policy"`
+	// Window is how far back missed ticks are enqueued, as a duration string.
+	// Defaults to consts.DefaultCronCatchUpWindow.
+	Window *string `json:"window,omitempty"`
+}
+
+// WindowDuration returns the catch-up window, or the default if unset.
+func (c CronCatchUp) WindowDuration() (time.Duration, error) {
+	if c.Window == nil || *c.Window == "" {
+		return consts.DefaultCronCatchUpWindow, nil
+	}
+	return str2duration.ParseDuration(*c.Window)
+}
+
+func (c CronCatchUp) Validate() error {
+	switch c.Policy {
+	case CronCatchUpNone, CronCatchUpLatest, CronCatchUpAll:
+	default:
+		return fmt.Errorf("'%s' isn't a valid cron catch-up policy; use none, latest or all", c.Policy)
+	}
+	window, err := c.WindowDuration()
 	if err != nil {
+		return fmt.Errorf("'%s' isn't a valid cron catch-up window: %w", *c.Window, err)
+	}
+	if window <= 0 || window > consts.MaxCronCatchUpWindow {
+		return fmt.Errorf("The cron catch-up window must be between 1s and %s", consts.MaxCronCatchUpWindow)
+	}
+	return nil
+}
+
+// Spec returns the schedule with its timezone as a "CRON_TZ=" prefix, which
+// uniquely identifies the schedule regardless of how the timezone was given.
+// Schedules without a timezone are returned as-is.
+func (c CronTrigger) Spec() string {
+	if hasCronTZ(c.Cron) || c.Timezone == nil || *c.Timezone == "" {
+		return c.Cron
+	}
+	return fmt.Sprintf("CRON_TZ=%s %s", *c.Timezone, c.Cron)
+}
+
+// Schedule parses the cron schedule in its timezone.
+func (c CronTrigger) Schedule() (cron.Schedule, error) {
+	return cronParser.Parse(c.Spec())
+}
+
+func (c CronTrigger) Validate(ctx context.Context) error {
+	if c.Timezone != nil && *c.Timezone != "" {
+		if hasCronTZ(c.Cron) {
+			return fmt.Errorf("'%s' cannot specify both a TZ prefix and a timezone", c.Cron)
+		}
+		if _, err := time.LoadLocation(*c.Timezone); err != nil {
+			return fmt.Errorf("'%s' isn't a valid timezone", *c.Timezone)
+		}
+	}
+	if _, err := c.Schedule(); err != nil {
 		return fmt.Errorf("'%s' isn't a valid cron schedule", c.Cron)
 	}
+	if c.CatchUp != nil {
+		return c.CatchUp.Validate()
+	}
 	return nil
 }
+
+// MissedTicks returns the ticks between lastFired and now which should be
+// enqueued according to the catch-up policy, oldest first.
+func (c CronTrigger) MissedTicks(lastFired, now time.Time) ([]time.Time, error) {
+	if c.CatchUp == nil || c.CatchUp.Policy == CronCatchUpNone || c.CatchUp.Policy == "" {
+		return nil, nil
+	}
+	sched, err := c.Schedule()
+	if err != nil {
+		return nil, err
+	}
+	window, err := c.CatchUp.WindowDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	from := lastFired
+	if earliest := now.Add(-window); from.Before(earliest) {
+		from = earliest
+	}
+
+	ticks := []time.Time{}
+	for next := sched.Next(from); !next.IsZero() && !next.After(now); next = sched.Next(next) {
+		ticks = append(ticks, next)
+	}
+
+	if c.CatchUp.Policy == CronCatchUpLatest && len(ticks) > 1 {
+		return ticks[len(ticks)-1:], nil
+	}
+	if len(ticks) > consts.MaxCronCatchUpTicks {
+		// Keep the most recent ticks.
+		return ticks[len(ticks)-consts.MaxCronCatchUpTicks:], nil
+	}
+	return ticks, nil
+}
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+func hasCronTZ(spec string) bool {
+	return strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=")
+}
@@ -0,0 +1,112 @@
+package inngest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronTriggerValidate(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, CronTrigger{Cron: "0 2 * * *"}.Validate(ctx))
+	require.NoError(t, CronTrigger{Cron: "TZ=Europe/Berlin 0 2 * * *"}.Validate(ctx))
+	require.NoError(t, CronTrigger{Cron: "0 2 * * *", Timezone: strptr("Europe/Berlin")}.Validate(ctx))
+	require.NoError(t, CronTrigger{Cron: "0 2 * * *", CatchUp: &CronCatchUp{Policy: CronCatchUpAll, Window: strptr("48h")}}.Validate(ctx))
+
+	require.ErrorContains(t, CronTrigger{Cron: "0 2 * *"}.Validate(ctx), "isn't a valid cron schedule")
+	require.ErrorContains(t, CronTrigger{Cron: "TZ=Mars/Olympus 0 2 * * *"}.Validate(ctx), "isn't a valid cron schedule")
+	require.ErrorContains(t, CronTrigger{Cron: "0 2 * * *", Timezone: strptr("Mars/Olympus")}.Validate(ctx), "isn't a valid timezone")
+	require.ErrorContains(t, CronTrigger{Cron: "TZ=UTC 0 2 * * *", Timezone: strptr("Europe/Berlin")}.Validate(ctx), "both a TZ prefix and a timezone")
+	require.ErrorContains(t, CronTrigger{Cron: "0 2 * * *", CatchUp: &CronCatchUp{Policy: "some"}}.Validate(ctx), "valid cron catch-up policy")
+	require.ErrorContains(t, CronTrigger{Cron: "0 2 * * *", CatchUp: &CronCatchUp{Policy: CronCatchUpAll, Window: strptr("30d")}}.Validate(ctx), "catch-up window must be")
+}
+
+func TestCronTriggerSchedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	from := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	sched, err := CronTrigger{Cron: "0 2 * * *", Timezone: strptr("Europe/Berlin")}.Schedule()
+	require.NoError(t, err)
+	require.True(t, time.Date(2024, 6, 2, 2, 0, 0, 0, berlin).Equal(sched.Next(from)))
+
+	sched, err = CronTrigger{Cron: "0 2 * * *", Timezone: strptr("UTC")}.Schedule()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 6, 2, 2, 0, 0, 0, time.UTC), sched.Next(from).UTC())
+
+	// Schedules without a timezone run in the local timezone.
+	sched, err = CronTrigger{Cron: "0 2 * * *"}.Schedule()
+	require.NoError(t, err)
+	local := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	require.True(t, time.Date(2024, 6, 2, 2, 0, 0, 0, time.Local).Equal(sched.Next(local)))
+}
+
+func TestCronTriggerSpec(t *testing.T) {
+	require.Equal(t, "0 2 * * *", CronTrigger{Cron: "0 2 * * *"}.Spec())
+	require.Equal(t, "0 2 * * *", CronTrigger{Cron: "0 2 * * *", Timezone: strptr("")}.Spec())
+	require.Equal(t, "CRON_TZ=Europe/Berlin 0 2 * * *", CronTrigger{Cron: "0 2 * * *", Timezone: strptr("Europe/Berlin")}.Spec())
+	require.Equal(t, "TZ=Europe/Berlin 0 2 * * *", CronTrigger{Cron: "TZ=Europe/Berlin 0 2 * * *"}.Spec())
+}
+
+func TestCronTriggerMissedTicks(t *testing.T) {
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	lastFired := time.Date(2024, 6, 2, 2, 0, 0, 0, time.UTC)
+	daily := func(c *CronCatchUp) CronTrigger {
+		return CronTrigger{Cron: "0 2 * * *", Timezone: strptr("UTC"), CatchUp: c}
+	}
+
+	t.Run("no policy drops missed ticks", func(t *testing.T) {
+		ticks, err := daily(nil).MissedTicks(lastFired, now)
+		require.NoError(t, err)
+		require.Empty(t, ticks)
+
+		ticks, err = daily(&CronCatchUp{Policy: CronCatchUpNone}).MissedTicks(lastFired, now)
+		require.NoError(t, err)
+		require.Empty(t, ticks)
+	})
+
+	t.Run("latest enqueues the most recent tick", func(t *testing.T) {
+		ticks, err := daily(&CronCatchUp{Policy: CronCatchUpLatest, Window: strptr("72h")}).MissedTicks(lastFired, now)
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{time.Date(2024, 6, 5, 2, 0, 0, 0, time.UTC)}, utc(ticks))
+	})
+
+	t.Run("all enqueues ticks within the window", func(t *testing.T) {
+		ticks, err := daily(&CronCatchUp{Policy: CronCatchUpAll, Window: strptr("72h")}).MissedTicks(lastFired, now)
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{
+			time.Date(2024, 6, 3, 2, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 4, 2, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 5, 2, 0, 0, 0, time.UTC),
+		}, utc(ticks))
+
+		// The default window is 24h.
+		ticks, err = daily(&CronCatchUp{Policy: CronCatchUpAll}).MissedTicks(lastFired, now)
+		require.NoError(t, err)
+		require.Len(t, ticks, 1)
+	})
+
+	t.Run("all is capped to the most recent ticks", func(t *testing.T) {
+		c := CronTrigger{Cron: "* * * * *", CatchUp: &CronCatchUp{Policy: CronCatchUpAll}}
+		ticks, err := c.MissedTicks(now.Add(-3*time.Hour), now)
+		require.NoError(t, err)
+		require.Len(t, ticks, 100)
+		require.Equal(t, now, ticks[len(ticks)-1].UTC())
+	})
+
+	t.Run("nothing missed", func(t *testing.T) {
+		ticks, err := daily(&CronCatchUp{Policy: CronCatchUpAll}).MissedTicks(time.Date(2024, 6, 5, 2, 0, 0, 0, time.UTC), now)
+		require.NoError(t, err)
+		require.Empty(t, ticks)
+	})
+}
+
+func utc(ticks []time.Time) []time.Time {
+	for n := range ticks {
+		ticks[n] = ticks[n].UTC()
+	}
+	return ticks
+}
@@ -26,8 +26,11 @@ import (
 	"github.com/khulnasoft/inngest/pkg/deploy"
 	"github.com/khulnasoft/inngest/pkg/devserver"
 	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/eventdedupe"
+	"github.com/khulnasoft/inngest/pkg/eventschema"
 	"github.com/khulnasoft/inngest/pkg/execution"
 	"github.com/khulnasoft/inngest/pkg/execution/batch"
+	"github.com/khulnasoft/inngest/pkg/execution/bulk"
 	"github.com/khulnasoft/inngest/pkg/execution/debounce"
 	"github.com/khulnasoft/inngest/pkg/execution/driver"
 	"github.com/khulnasoft/inngest/pkg/execution/driver/httpdriver"
@@ -36,8 +39,11 @@ import (
 	"github.com/khulnasoft/inngest/pkg/execution/queue"
 	"github.com/khulnasoft/inngest/pkg/execution/ratelimit"
 	"github.com/khulnasoft/inngest/pkg/execution/runner"
+	"github.com/khulnasoft/inngest/pkg/execution/singleton"
+	"github.com/khulnasoft/inngest/pkg/execution/sink"
 	"github.com/khulnasoft/inngest/pkg/execution/state"
 	"github.com/khulnasoft/inngest/pkg/execution/state/redis_state"
+	"github.com/khulnasoft/inngest/pkg/execution/state/sql_state"
 	sv2 "github.com/khulnasoft/inngest/pkg/execution/state/v2"
 	"github.com/khulnasoft/inngest/pkg/expressions"
 	"github.com/khulnasoft/inngest/pkg/headers"
@@ -46,6 +52,7 @@ import (
 	"github.com/khulnasoft/inngest/pkg/run"
 	"github.com/khulnasoft/inngest/pkg/service"
 	itrace "github.com/khulnasoft/inngest/pkg/telemetry/trace"
+	"github.com/khulnasoft/inngest/pkg/util/aigateway"
 	"github.com/khulnasoft/inngest/pkg/util/awsgateway"
 	"github.com/redis/rueidis"
 	"go.opentelemetry.io/otel/propagation"
@@ -71,9 +78,30 @@ type StartOpts struct {
 	SigningKey string `json:"signing_key"`
 	SQLiteDir  string `json:"sqlite-dir"`
 
+	// StateStore is the backend used to store run state and pauses:  either
+	// "redis" (the default) or "sql", which stores state in the same database
+	// as apps and history.
+	StateStore string `json:"state-store"`
+
 	// EventKey is used to authorize incoming events, ensuring they match the
 	// given key.
 	EventKey []string `json:"event_key"`
+
+	// EventDedupeWindow is the window in which events sent with the same ID are
+	// dropped as duplicates.  Zero disables deduplication.
+	EventDedupeWindow time.Duration `json:"event_dedupe_window"`
+
+	// LifecycleSinks are URIs of sinks which function and step lifecycle events
+	// are exported to, eg. "https://example.com/webhook", "nats://host:4222/subject"
+	// or "kafka://broker:9092/topic".
+	LifecycleSinks []string `json:"lifecycle_sinks"`
+	// LifecycleSinkSigningKey signs requests sent to lifecycle webhook sinks.
+	LifecycleSinkSigningKey string `json:"lifecycle_sink_signing_key"`
+	// AITokenBudget limits the AI gateway tokens used by each environment within
+	// AITokenBudgetPeriod.  Zero disables the limit.
+	AITokenBudget int64 `json:"ai_token_budget"`
+	// AITokenBudgetPeriod is the period AITokenBudget resets after.
+	AITokenBudgetPeriod time.Duration `json:"ai_token_budget_period"`
 }
 
 // Create and start a new dev server.  The dev server is used during (surprise surprise)
@@ -151,17 +179,28 @@ func start(ctx context.Context, opts StartOpts) error {
 		QueueDefaultKey:        redis_state.QueueDefaultKey,
 	})
 
-	var sm state.Manager
-	t := runner.NewTracker()
-	sm, err = redis_state.New(
-		ctx,
-		redis_state.WithShardedClient(shardedClient),
-		redis_state.WithUnshardedClient(unshardedClient),
+	var (
+		sm   state.Manager
+		smv2 sv2.RunService
 	)
-	if err != nil {
-		return err
+	t := runner.NewTracker()
+	switch opts.StateStore {
+	case "", "redis":
+		sm, err = redis_state.New(
+			ctx,
+			redis_state.WithShardedClient(shardedClient),
+			redis_state.WithUnshardedClient(unshardedClient),
+		)
+		if err != nil {
+			return err
+		}
+		smv2 = redis_state.MustRunServiceV2(sm)
+	case "sql":
+		sm = sql_state.New(db, dbDriver)
+		smv2 = sql_state.MustStateServiceV2(sm)
+	default:
+		return fmt.Errorf("unknown state store: %s", opts.StateStore)
 	}
-	smv2 := redis_state.MustRunServiceV2(sm)
 
 	queueShard := redis_state.QueueShard{Name: consts.DefaultQueueShardName, RedisClient: unshardedClient.Queue(), Kind: string(enums.QueueShardKindRedis)}
 
@@ -244,6 +283,7 @@ func start(ctx context.Context, opts StartOpts) error {
 	rq := redis_state.NewQueue(queueShard, queueOpts...)
 
 	rl := ratelimit.New(ctx, unshardedRc, "{ratelimit}:")
+	sn := singleton.New(unshardedRc, "{singleton}:")
 
 	batcher := batch.NewRedisBatchManager(shardedClient.Batch(), rq)
 	debouncer := debounce.NewRedisDebouncer(unshardedClient.Debounce(), queueShard, rq)
@@ -272,6 +312,36 @@ func start(ctx context.Context, opts StartOpts) error {
 		return fmt.Errorf("failed to create publisher: %w", err)
 	}
 
+	listeners := []execution.LifecycleListener{
+		history.NewLifecycleListener(
+			nil,
+			hd,
+		),
+		devserver.Lifecycle{
+			Cqrs:       dbcqrs,
+			Pb:         pb,
+			EventTopic: opts.Config.EventStream.Service.Concrete.TopicName(),
+		},
+		run.NewTraceLifecycleListener(nil),
+	}
+	if len(opts.LifecycleSinks) > 0 {
+		l, exporter, err := sink.NewLifecycleListenerFromURIs(ctx, opts.LifecycleSinks, sink.OpenOpts{
+			WebhookSigningKey: opts.LifecycleSinkSigningKey,
+		})
+		if err != nil {
+			return err
+		}
+		// Send buffered lifecycle events once every service has stopped.
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sink.DefaultCloseTimeout)
+			defer cancel()
+			if err := exporter.Close(ctx); err != nil {
+				logger.From(ctx).Error().Err(err).Msg("error closing lifecycle sinks")
+			}
+		}()
+		listeners = append(listeners, l)
+	}
+
 	exec, err := executor.NewExecutor(
 		executor.WithStateManager(smv2),
 		executor.WithPauseManager(sm),
@@ -282,17 +352,20 @@ func start(ctx context.Context, opts StartOpts) error {
 		executor.WithQueue(rq),
 		executor.WithLogger(logger.From(ctx)),
 		executor.WithFunctionLoader(loader),
-		executor.WithLifecycleListeners(
-			history.NewLifecycleListener(
-				nil,
-				hd,
-			),
-			devserver.Lifecycle{
-				Cqrs:       dbcqrs,
-				Pb:         pb,
-				EventTopic: opts.Config.EventStream.Service.Concrete.TopicName(),
+		executor.WithLifecycleListeners(listeners...),
+		executor.WithAIGatewayCache(aigateway.NewRedisCache(unshardedRc)),
+		executor.WithAITokenBudgets(
+			aigateway.NewRedisBudgets(unshardedRc),
+			func(ctx context.Context, envID uuid.UUID) *aigateway.Budget {
+				if opts.AITokenBudget <= 0 || opts.AITokenBudgetPeriod <= 0 {
+					return nil
+				}
+				return &aigateway.Budget{
+					Scope:  "env",
+					Limit:  opts.AITokenBudget,
+					Period: opts.AITokenBudgetPeriod,
+				}
 			},
-			run.NewTraceLifecycleListener(nil),
 		),
 		executor.WithStepLimits(func(id sv2.ID) int {
 			if override, hasOverride := stepLimitOverrides[id.FunctionID.String()]; hasOverride {
@@ -313,6 +386,7 @@ func start(ctx context.Context, opts StartOpts) error {
 		executor.WithInvokeFailHandler(getInvokeFailHandler(ctx, pb, opts.Config.EventStream.Service.Concrete.TopicName())),
 		executor.WithSendingEventHandler(getSendingEventHandler(pb, opts.Config.EventStream.Service.Concrete.TopicName())),
 		executor.WithDebouncer(debouncer),
+		executor.WithSingletonManager(sn),
 		executor.WithBatcher(batcher),
 		executor.WithAssignedQueueShard(queueShard),
 		executor.WithShardSelector(shardSelector),
@@ -321,17 +395,6 @@ func start(ctx context.Context, opts StartOpts) error {
 		return err
 	}
 
-	// Create an executor.
-	executorSvc := executor.NewService(
-		opts.Config,
-		executor.WithExecutionManager(dbcqrs),
-		executor.WithState(sm),
-		executor.WithServiceQueue(rq),
-		executor.WithServiceExecutor(exec),
-		executor.WithServiceBatcher(batcher),
-		executor.WithServiceDebouncer(debouncer),
-	)
-
 	runner := runner.NewService(
 		opts.Config,
 		runner.WithCQRS(dbcqrs),
@@ -346,6 +409,18 @@ func start(ctx context.Context, opts StartOpts) error {
 		runner.WithPublisher(pb),
 	)
 
+	// Create an executor.
+	executorSvc := executor.NewService(
+		opts.Config,
+		executor.WithExecutionManager(dbcqrs),
+		executor.WithState(sm),
+		executor.WithServiceQueue(rq),
+		executor.WithServiceExecutor(exec),
+		executor.WithServiceBatcher(batcher),
+		executor.WithServiceDebouncer(debouncer),
+		executor.WithServiceCronHandler(runner.FireCron),
+	)
+
 	// The devserver embeds the event API.
 	pi := consts.StartDefaultPersistenceInterval
 	persistenceInterval := &pi
@@ -386,19 +461,33 @@ func start(ctx context.Context, opts StartOpts) error {
 	// registering functions.
 	devAPI := devserver.NewDevAPI(ds)
 
+	// Bulk operations cancel or replay many runs in the background.
+	bulkOps := bulk.NewRedisReadWriter(unshardedRc, "")
+	bulkSvc := bulk.NewService(bulk.Opts{
+		Store:    bulkOps,
+		Data:     ds.Data,
+		Executor: ds.Executor,
+	})
+
 	devAPI.Route("/v1", func(r chi.Router) {
 		// Add the V1 API to our dev server API.
 		cache := cache.New[[]byte](freecachestore.NewFreecache(freecache.NewCache(1024 * 1024)))
 		caching := apiv1.NewCacheMiddleware(cache)
 
 		apiv1.AddRoutes(r, apiv1.Opts{
-			CachingMiddleware:  caching,
-			EventReader:        ds.Data,
-			FunctionReader:     ds.Data,
-			FunctionRunReader:  ds.Data,
-			JobQueueReader:     ds.Queue.(queue.JobQueueReader),
-			Executor:           ds.Executor,
-			QueueShardSelector: shardSelector,
+			CachingMiddleware:       caching,
+			EventReader:             ds.Data,
+			EventReplayer:           runner,
+			FunctionReader:          ds.Data,
+			FunctionRunReader:       ds.Data,
+			TraceReader:             ds.Data,
+			JobQueueReader:          ds.Queue.(queue.JobQueueReader),
+			Executor:                ds.Executor,
+			QueueShardSelector:      shardSelector,
+			BulkOperationReadWriter: bulkOps,
+			DeadLetterReadWriter:    ds.Data,
+			EventSchemaReadWriter:   ds.Data,
+			WebhookSourceReadWriter: ds.Data,
 		})
 	})
 
@@ -435,9 +524,15 @@ func start(ctx context.Context, opts StartOpts) error {
 		},
 		LocalEventKeys: opts.EventKey,
 		RequireKeys:    true,
+		Schemas:        eventschema.NewRegistry(ds.Data),
+		WebhookSources: ds.Data,
+		Dedupe: eventdedupe.New(
+			eventdedupe.NewRedisStore(unshardedRc, "{dedupe}:"),
+			eventdedupe.StaticWindow(opts.EventDedupeWindow),
+		),
 	})
 
-	return service.StartAll(ctx, ds, runner, executorSvc, ds.Apiservice)
+	return service.StartAll(ctx, ds, runner, executorSvc, ds.Apiservice, bulkSvc)
 }
 
 func connectToOrCreateRedis(redisURI string) (rueidis.Client, error) {
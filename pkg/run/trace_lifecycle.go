@@ -506,6 +506,7 @@ func (l traceLifecycle) OnFunctionSkipped(
 			attribute.String(consts.OtelSysEventIDs, strings.Join(evtIDs, ",")),
 			attribute.String(consts.OtelSysIdempotencyKey, md.IdempotencyKey()),
 			attribute.Int64(consts.OtelSysFunctionStatusCode, enums.RunStatusSkipped.ToCode()),
+			attribute.String(consts.OtelSysFunctionSkipReason, s.Reason.String()),
 		),
 	)
 	defer span.End()
@@ -591,6 +592,9 @@ func (l traceLifecycle) OnStepStarted(
 	if item.Attempt > 0 {
 		span.SetAttributes(attribute.Bool(consts.OtelSysStepRetry, true))
 	}
+	if item.StepPolicy != nil {
+		span.SetAttributes(stepPolicyAttrs(*item.StepPolicy)...)
+	}
 
 	// first step
 	if edge.Incoming == inngest.TriggerName {
@@ -699,10 +703,27 @@ func (l traceLifecycle) OnStepGatewayRequestFinished(
 		if parsed, err := aigateway.ParseInput(ctx, req); err == nil {
 			span.SetAIRequestMetadata(parsed)
 		}
-		// And parse the response.
-		if parsed, err := aigateway.ParseOutput(ctx, req.Format, op.Data); err == nil {
+		// And parse the response.  Successful responses are wrapped in "data".
+		output := op.Data
+		if runErr == nil {
+			wrapped := map[string]json.RawMessage{}
+			if err := json.Unmarshal(op.Data, &wrapped); err == nil && wrapped["data"] != nil {
+				output = wrapped["data"]
+			}
+		}
+		if parsed, err := aigateway.ParseOutput(ctx, req.Format, output); err == nil {
 			span.SetAIResponseMetadata(parsed)
 		}
+		if usage, err := aigateway.ParseUsage(req.Format, output); err == nil {
+			span.SetAttributes(
+				attribute.String(consts.OtelSysStepAIModel, usage.Model),
+				attribute.Int64(consts.OtelSysStepAITokensIn, usage.InputTokens),
+				attribute.Int64(consts.OtelSysStepAITokensOut, usage.OutputTokens),
+			)
+		}
+		if resp.Header.Get(aigateway.HeaderCache) == aigateway.CacheHit {
+			span.SetAttributes(attribute.Bool(consts.OtelSysStepAICacheHit, true))
+		}
 	}
 }
 
@@ -761,6 +782,9 @@ func (l traceLifecycle) OnStepFinished(
 	if item.Attempt > 0 {
 		span.SetAttributes(attribute.Bool(consts.OtelSysStepRetry, true))
 	}
+	if item.StepPolicy != nil {
+		span.SetAttributes(stepPolicyAttrs(*item.StepPolicy)...)
+	}
 
 	// first step
 	if edge.Incoming == inngest.TriggerName {
@@ -1195,6 +1219,113 @@ func (l traceLifecycle) OnWaitForEventResumed(
 	}
 }
 
+func (l traceLifecycle) OnWaitForSignal(
+	ctx context.Context,
+	md statev2.Metadata,
+	item queue.Item,
+	gen statev1.GeneratorOpcode,
+	pause state.Pause,
+) {
+	ctx = l.extractTraceCtx(ctx, md, false)
+
+	runID := md.ID.RunID
+	opts, err := gen.WaitForSignalOpts()
+	if err != nil {
+		l.log.Error("error retrieving signal opts", "error", err, "meta", md, "lifecycle", "OnWaitForSignal")
+		return
+	}
+
+	v, ok := pause.Metadata[consts.OtelPropagationKey]
+	if !ok {
+		l.log.Error("no trace propagation", "meta", md, "lifecycle", "OnWaitForSignal")
+		return
+	}
+	carrier, ok := v.(*itrace.TraceCarrier)
+	if !ok {
+		l.log.Error("no trace carrier", "meta", md, "lifecycle", "OnWaitForSignal")
+		return
+	}
+
+	_, span := NewSpan(ctx,
+		WithScope(consts.OtelScopeStep),
+		WithName(consts.OtelSpanWaitForSignal),
+		WithTimestamp(carrier.Timestamp),
+		WithSpanID(carrier.SpanID()),
+		WithSpanAttributes(
+			attribute.String(consts.OtelSysLifecycleID, "OnWaitForSignal"),
+			attribute.String(consts.OtelSysStepOpcode, enums.OpcodeWaitForSignal.String()),
+			attribute.String(consts.OtelSysAccountID, md.ID.Tenant.AccountID.String()),
+			attribute.String(consts.OtelSysWorkspaceID, md.ID.Tenant.EnvID.String()),
+			attribute.String(consts.OtelSysAppID, md.ID.Tenant.AppID.String()),
+			attribute.String(consts.OtelSysFunctionID, md.ID.FunctionID.String()),
+			attribute.Int(consts.OtelSysFunctionVersion, md.Config.FunctionVersion),
+			attribute.String(consts.OtelAttrSDKRunID, runID.String()),
+			attribute.Int(consts.OtelSysStepAttempt, 0),
+			attribute.Int(consts.OtelSysStepMaxAttempt, 1),
+			attribute.String(consts.OtelSysStepGroupID, item.GroupID),
+			attribute.String(consts.OtelSysStepSignalName, opts.Signal),
+			attribute.Int64(consts.OtelSysStepSignalExpires, pause.Expires.Time().UnixMilli()),
+			attribute.String(consts.OtelSysStepDisplayName, gen.UserDefinedName()),
+		),
+	)
+	defer span.End()
+}
+
+func (l traceLifecycle) OnWaitForSignalResumed(
+	ctx context.Context,
+	md statev2.Metadata,
+	pause state.Pause,
+	r execution.ResumeRequest,
+) {
+	if pause.Metadata == nil {
+		l.log.Error("no pause metadata", "meta", md, "lifecycle", "OnWaitForSignalResumed")
+		return
+	}
+
+	meta, ok := pause.Metadata[consts.OtelPropagationKey]
+	if !ok {
+		l.log.Error("no trace", "meta", md, "lifecycle", "OnWaitForSignalResumed")
+		return
+	}
+
+	carrier := itrace.NewTraceCarrier()
+	if err := carrier.Unmarshal(meta); err == nil {
+		ctx = itrace.UserTracer().Propagator().Extract(ctx, propagation.MapCarrier(carrier.Context))
+		if carrier.CanResumePause() {
+			_, span := NewSpan(ctx,
+				WithScope(consts.OtelScopeStep),
+				WithName(consts.OtelSpanWaitForSignal),
+				WithTimestamp(carrier.Timestamp),
+				WithSpanID(carrier.SpanID()),
+				WithSpanAttributes(
+					attribute.String(consts.OtelSysLifecycleID, "OnWaitForSignalResumed"),
+					attribute.String(consts.OtelSysAccountID, pause.Identifier.AccountID.String()),
+					attribute.String(consts.OtelSysWorkspaceID, pause.Identifier.WorkspaceID.String()),
+					attribute.String(consts.OtelSysAppID, pause.Identifier.AppID.String()),
+					attribute.String(consts.OtelSysFunctionID, pause.Identifier.WorkflowID.String()),
+					attribute.Int(consts.OtelSysFunctionVersion, pause.Identifier.WorkflowVersion),
+					attribute.String(consts.OtelAttrSDKRunID, pause.Identifier.RunID.String()),
+					attribute.Int(consts.OtelSysStepAttempt, 0),
+					attribute.Int(consts.OtelSysStepMaxAttempt, 1),
+					attribute.String(consts.OtelSysStepGroupID, pause.GroupID),
+					attribute.String(consts.OtelSysStepDisplayName, pause.StepName),
+					attribute.String(consts.OtelSysStepOpcode, enums.OpcodeWaitForSignal.String()),
+					attribute.Int64(consts.OtelSysStepSignalExpires, pause.Expires.Time().UnixMilli()),
+					attribute.Bool(consts.OtelSysStepSignalExpired, r.IsTimeout),
+				),
+			)
+			defer span.End()
+
+			if pause.SignalID != nil {
+				span.SetAttributes(attribute.String(consts.OtelSysStepSignalName, *pause.SignalID))
+			}
+			if r.With != nil {
+				span.SetStepOutput(r.With)
+			}
+		}
+	}
+}
+
 // NOTE: this is copied from the same function inside executor.
 // should probably delete it some time when it's no longer needed.
 //
@@ -1229,3 +1360,21 @@ func (l *traceLifecycle) extractTraceCtx(ctx context.Context, md sv2.Metadata, i
 
 	return ctx
 }
+
+// stepPolicyAttrs returns span attributes for the SDK-declared policy of a step.
+func stepPolicyAttrs(p statev1.StepPolicy) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{}
+	if p.Timeout != "" {
+		attrs = append(attrs, attribute.Int64(consts.OtelSysStepTimeout, p.TimeoutDuration().Milliseconds()))
+	}
+	if p.Backoff != nil {
+		attrs = append(attrs, attribute.String(consts.OtelSysStepBackoffKind, string(p.Backoff.Kind)))
+		if p.Backoff.Interval != "" {
+			attrs = append(attrs, attribute.String(consts.OtelSysStepBackoffInterval, p.Backoff.Interval))
+		}
+		if p.Backoff.Jitter != "" {
+			attrs = append(attrs, attribute.String(consts.OtelSysStepBackoffJitter, p.Backoff.Jitter))
+		}
+	}
+	return attrs
+}
@@ -90,6 +90,10 @@ type RegisterRequest struct {
 	// IdempotencyKey is an optional input to deduplicate syncs.
 	IdempotencyKey string `json:"idempotencyKey"`
 
+	// EventSchemas are JSON Schemas for the data of events used by the app.
+	// Incoming events are validated against the schema for their name.
+	EventSchemas []EventSchema `json:"eventSchemas,omitempty"`
+
 	// checksum is a memoized field.
 	checksum string
 
@@ -108,6 +112,15 @@ type Capabilities struct {
 	Connect    string `json:"connect"`
 }
 
+// EventSchema is a JSON Schema for the data of events with the given name.
+type EventSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	// Mode determines how events which don't match the schema are handled: one
+	// of "reject", "warn" or "tag".  Defaults to "warn".
+	Mode string `json:"mode,omitempty"`
+}
+
 type Headers struct {
 	Env      string `json:"env"`
 	Platform string `json:"platform"`
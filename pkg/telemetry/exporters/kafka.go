@@ -82,6 +82,20 @@ func NewKafkaSpanExporter(ctx context.Context, opts ...KafkaSpansExporterOpts) (
 		conf.key = defaultMsgKey
 	}
 
+	cl, err := kafkaConnect(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafkaSpanExporter{
+		client: cl,
+		key:    conf.key,
+	}, nil
+}
+
+// kafkaConnect creates a client producing to the configured topic, checking that
+// the brokers are reachable.
+func kafkaConnect(ctx context.Context, conf *kafkaSpansExporterOpts) (*kgo.Client, error) {
 	kclopts := []kgo.Opt{
 		kgo.SeedBrokers(conf.addrs...),
 		kgo.DefaultProduceTopic(conf.topic),
@@ -116,11 +130,7 @@ func NewKafkaSpanExporter(ctx context.Context, opts ...KafkaSpansExporterOpts) (
 	if err := cl.Ping(ctx); err != nil {
 		return nil, fmt.Errorf("error establishing connection to kafka: %w", err)
 	}
-
-	return &kafkaSpanExporter{
-		client: cl,
-		key:    conf.key,
-	}, nil
+	return cl, nil
 }
 
 func (e *kafkaSpanExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
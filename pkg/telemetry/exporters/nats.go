@@ -95,6 +95,25 @@ func NewNATSSpanExporter(ctx context.Context, opts ...NatsExporterOpts) (trace.S
 		apply(expOpts)
 	}
 
+	conn, err := natsConnect(ctx, "run-span-exporter", expOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter := &natsSpanExporter{
+		conn:    conn,
+		streams: expOpts.streams,
+		buf:     newNatsBuffer(),
+	}
+
+	go exporter.handleBuffered(ctx)
+
+	return exporter, nil
+}
+
+// natsConnect connects to the NATS servers using the exporter's URLs and
+// credentials, with JetStream enabled.
+func natsConnect(ctx context.Context, name string, expOpts *natsSpanExporterOpts) (*broker.NatsConnector, error) {
 	connOpts := []nats.Option{}
 	// attempt to parse nkey file is the option was passed in
 	if expOpts.nkeyFile != "" {
@@ -112,7 +131,7 @@ func NewNATSSpanExporter(ctx context.Context, opts ...NatsExporterOpts) (trace.S
 	}
 
 	conn, err := broker.NewNATSConnector(ctx, broker.NatsConnOpt{
-		Name:      "run-span-exporter",
+		Name:      name,
 		URLS:      expOpts.urls,
 		JetStream: true,
 		Opts:      connOpts,
@@ -120,16 +139,7 @@ func NewNATSSpanExporter(ctx context.Context, opts ...NatsExporterOpts) (trace.S
 	if err != nil {
 		return nil, fmt.Errorf("error setting up nats: %w", err)
 	}
-
-	exporter := &natsSpanExporter{
-		conn:    conn,
-		streams: expOpts.streams,
-		buf:     newNatsBuffer(),
-	}
-
-	go exporter.handleBuffered(ctx)
-
-	return exporter, nil
+	return conn, nil
 }
 
 func (e *natsSpanExporter) handleBuffered(ctx context.Context) {
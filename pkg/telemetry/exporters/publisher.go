@@ -0,0 +1,133 @@
+package exporters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/pubsub/broker"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Message is a message published by a Publisher.
+type Message struct {
+	// Key is used to partition messages, where supported.
+	Key []byte
+	// Value is the message's payload.
+	Value []byte
+}
+
+// Publisher publishes arbitrary messages using the same connection options as
+// the span exporters, allowing other data such as lifecycle events to be shipped
+// to the same NATS or Kafka clusters.
+type Publisher interface {
+	// Publish publishes the messages, returning once every message is
+	// acknowledged or an error if any message fails.
+	Publish(ctx context.Context, msgs []Message) error
+	// Close closes the underlying connection.
+	Close(ctx context.Context) error
+}
+
+// NewNATSPublisher returns a Publisher which publishes messages to each stream
+// subject configured via WithNatsExporterStream.
+func NewNATSPublisher(ctx context.Context, opts ...NatsExporterOpts) (Publisher, error) {
+	expOpts := &natsSpanExporterOpts{
+		streams: []*StreamConf{},
+	}
+	for _, apply := range opts {
+		apply(expOpts)
+	}
+	if len(expOpts.streams) == 0 {
+		return nil, fmt.Errorf("no subject provided for nats publisher")
+	}
+
+	conn, err := natsConnect(ctx, "lifecycle-publisher", expOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &natsPublisher{
+		conn:    conn,
+		streams: expOpts.streams,
+	}, nil
+}
+
+type natsPublisher struct {
+	conn    *broker.NatsConnector
+	streams []*StreamConf
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, msgs []Message) error {
+	js, err := p.conn.JSConn()
+	if err != nil {
+		return err
+	}
+
+	acks := []jetstream.PubAckFuture{}
+	for _, stream := range p.streams {
+		for _, m := range msgs {
+			fack, err := js.PublishAsync(stream.Subject, m.Value,
+				jetstream.WithStallWait(500*time.Millisecond),
+			)
+			if err != nil {
+				return fmt.Errorf("error publishing to nats subject %s: %w", stream.Subject, err)
+			}
+			acks = append(acks, fack)
+		}
+	}
+
+	var errs error
+	for _, fack := range acks {
+		select {
+		case <-fack.Ok():
+		case err := <-fack.Err():
+			errs = errors.Join(errs, err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errs
+}
+
+func (p *natsPublisher) Close(ctx context.Context) error {
+	return p.conn.Shutdown(ctx)
+}
+
+// NewKafkaPublisher returns a Publisher which produces messages to the topic
+// configured via WithKafkaExporterTopic.
+func NewKafkaPublisher(ctx context.Context, opts ...KafkaSpansExporterOpts) (Publisher, error) {
+	conf := &kafkaSpansExporterOpts{}
+	for _, apply := range opts {
+		apply(conf)
+	}
+	if len(conf.addrs) == 0 {
+		return nil, fmt.Errorf("not kafka broker addresses provided")
+	}
+	if conf.topic == "" {
+		return nil, fmt.Errorf("no topic provided for kafka publisher")
+	}
+
+	cl, err := kafkaConnect(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaPublisher{client: cl}, nil
+}
+
+type kafkaPublisher struct {
+	client *kgo.Client
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, msgs []Message) error {
+	recs := make([]*kgo.Record, len(msgs))
+	for i, m := range msgs {
+		recs[i] = &kgo.Record{Key: m.Key, Value: m.Value}
+	}
+	return p.client.ProduceSync(ctx, recs...).FirstErr()
+}
+
+func (p *kafkaPublisher) Close(ctx context.Context) error {
+	p.client.Close()
+	return nil
+}
@@ -262,3 +262,39 @@ func IncrConnectRouterNoHealthyConnectionCounter(ctx context.Context, value int6
 		Tags:        opts.Tags,
 	})
 }
+
+func IncrEventDedupeHitCounter(ctx context.Context, opts CounterOpt) {
+	RecordCounterMetric(ctx, 1, CounterOpt{
+		PkgName:     opts.PkgName,
+		MetricName:  "event_dedupe_hits_total",
+		Description: "Total number of events dropped as duplicates of an event with the same ID",
+		Tags:        opts.Tags,
+	})
+}
+
+func IncrLifecycleSinkEnvelopesCounter(ctx context.Context, value int64, opts CounterOpt) {
+	RecordCounterMetric(ctx, value, CounterOpt{
+		PkgName:     opts.PkgName,
+		MetricName:  "lifecycle_sink_envelopes_total",
+		Description: "Total number of lifecycle envelopes exported to sinks, by status",
+		Tags:        opts.Tags,
+	})
+}
+
+func IncrAIGatewayTokensCounter(ctx context.Context, value int64, opts CounterOpt) {
+	RecordCounterMetric(ctx, value, CounterOpt{
+		PkgName:     opts.PkgName,
+		MetricName:  "ai_gateway_tokens_total",
+		Description: "Total number of tokens used by AI gateway requests",
+		Tags:        opts.Tags,
+	})
+}
+
+func IncrAIGatewayCacheCounter(ctx context.Context, opts CounterOpt) {
+	RecordCounterMetric(ctx, 1, CounterOpt{
+		PkgName:     opts.PkgName,
+		MetricName:  "ai_gateway_cache_total",
+		Description: "Total number of AI gateway cache lookups, by status",
+		Tags:        opts.Tags,
+	})
+}
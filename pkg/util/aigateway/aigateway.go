@@ -184,6 +184,43 @@ func ParseOutput(ctx context.Context, format string, response []byte) (ParsedInf
 			StopReason: string(r.StopReason),
 			Tools:      tools,
 		}, nil
+	case FormatGemini:
+		r := geminiResponse{}
+		if err := json.Unmarshal(response, &r); err != nil {
+			return ParsedInferenceResponse{}, fmt.Errorf("error parsing gemini response: %w", err)
+		}
+		if r.Error != nil {
+			return ParsedInferenceResponse{
+				Error: r.Error.Status,
+			}, fmt.Errorf("gemini api error: %s", r.Error.Status)
+		}
+
+		parsed := ParsedInferenceResponse{
+			ID:    r.ResponseID,
+			Tools: []ToolUseResponse{},
+		}
+		if usage, err := ParseUsage(format, response); err == nil {
+			parsed.TokensIn = int32(usage.InputTokens)
+			parsed.TokensOut = int32(usage.OutputTokens)
+		}
+		if len(r.Candidates) == 0 {
+			return parsed, fmt.Errorf("no candidates returned in gemini api response")
+		}
+
+		// XXX: We do not support multiple candidates in Gemini requests just yet
+		choice := r.Candidates[0]
+		parsed.StopReason = choice.FinishReason
+		for _, p := range choice.Content.Parts {
+			if p.FunctionCall == nil {
+				continue
+			}
+			// Gemini doesn't assign IDs to function calls.
+			parsed.Tools = append(parsed.Tools, ToolUseResponse{
+				Name:      p.FunctionCall.Name,
+				Arguments: string(p.FunctionCall.Args),
+			})
+		}
+		return parsed, nil
 	case FormatOpenAIChat:
 		fallthrough
 	default:
@@ -47,6 +47,32 @@ func TestParseOutput(t *testing.T) {
 			},
 			err: fmt.Errorf("anthropic api error: rate_limit_error"),
 		},
+		{
+			description: "gemini response with function call",
+			format:      FormatGemini,
+			input:       `{"candidates":[{"content":{"parts":[{"functionCall":{"name":"read_file","args":{"filename":"main.go"}}}],"role":"model"},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":120,"candidatesTokenCount":15,"totalTokenCount":135},"modelVersion":"gemini-2.0-flash","responseId":"resp_123"}`,
+			expected: ParsedInferenceResponse{
+				ID:         "resp_123",
+				TokensIn:   120,
+				TokensOut:  15,
+				StopReason: "STOP",
+				Tools: []ToolUseResponse{
+					{
+						Name:      "read_file",
+						Arguments: `{"filename":"main.go"}`,
+					},
+				},
+			},
+		},
+		{
+			description: "gemini error",
+			format:      FormatGemini,
+			input:       `{"error":{"code":429,"message":"Resource has been exhausted","status":"RESOURCE_EXHAUSTED"}}`,
+			expected: ParsedInferenceResponse{
+				Error: "RESOURCE_EXHAUSTED",
+			},
+			err: fmt.Errorf("gemini api error: RESOURCE_EXHAUSTED"),
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,119 @@
+package aigateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// ErrBudgetExceeded is matched by every BudgetExceededError via errors.Is.
+var ErrBudgetExceeded = errors.New("ai token budget exceeded")
+
+// Budget limits the tokens used within fixed periods.
+type Budget struct {
+	// Scope describes what the budget applies to, eg. "function" or "env".
+	Scope string
+	// Limit is the number of tokens allowed within each period.
+	Limit int64
+	// Period is the length of each period.  Periods are aligned to the Unix epoch,
+	// so a 24h budget resets at midnight UTC.
+	Period time.Duration
+}
+
+// window returns the start of the period containing now.
+func (b Budget) window(now time.Time) time.Time {
+	return now.Truncate(b.Period)
+}
+
+// BudgetExceededError is returned when a budget's tokens for the current period
+// are used.  Requests fail with this error without being retried, as retrying
+// cannot succeed until the period resets.
+type BudgetExceededError struct {
+	Scope   string
+	Limit   int64
+	Used    int64
+	ResetAt time.Time
+}
+
+func (e BudgetExceededError) Error() string {
+	return fmt.Sprintf(
+		"%s token budget exceeded: used %d of %d tokens, resets at %s",
+		e.Scope, e.Used, e.Limit, e.ResetAt.UTC().Format(time.RFC3339),
+	)
+}
+
+func (e BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// Budgets tracks token usage against budgets.
+//
+// Budgets are checked before each request and usage is recorded once the
+// provider responds, so concurrent requests may exceed a budget by the tokens
+// they use.  Subsequent requests fail until the period resets.
+type Budgets interface {
+	// Check returns a BudgetExceededError if the tokens used by the key within the
+	// current period have reached the budget's limit.
+	Check(ctx context.Context, key string, b Budget, now time.Time) error
+	// Record adds tokens used by the key within the current period.
+	Record(ctx context.Context, key string, b Budget, tokens int64, now time.Time) error
+}
+
+// NewRedisBudgets returns Budgets which count tokens in Redis.
+func NewRedisBudgets(r rueidis.Client) Budgets {
+	return redisBudgets{r: r}
+}
+
+type redisBudgets struct {
+	r rueidis.Client
+}
+
+func (r redisBudgets) key(key string, b Budget, now time.Time) string {
+	return fmt.Sprintf("{aigateway}:budget:%s:%d", key, b.window(now).Unix())
+}
+
+func (r redisBudgets) Check(ctx context.Context, key string, b Budget, now time.Time) error {
+	val, err := r.r.Do(ctx, r.r.B().Get().Key(r.key(key, b, now)).Build()).ToString()
+	if rueidis.IsRedisNil(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error loading ai token usage: %w", err)
+	}
+	used, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ai token usage: %w", err)
+	}
+	if used < b.Limit {
+		return nil
+	}
+	return BudgetExceededError{
+		Scope:   b.Scope,
+		Limit:   b.Limit,
+		Used:    used,
+		ResetAt: b.window(now).Add(b.Period),
+	}
+}
+
+func (r redisBudgets) Record(ctx context.Context, key string, b Budget, tokens int64, now time.Time) error {
+	if tokens <= 0 {
+		return nil
+	}
+	k := r.key(key, b, now)
+	// Keep each period's count until the period ends.
+	ttl := b.window(now).Add(b.Period).Sub(now)
+	for _, res := range r.r.DoMulti(
+		ctx,
+		r.r.B().Incrby().Key(k).Increment(tokens).Build(),
+		r.r.B().Pexpire().Key(k).Milliseconds(ttl.Milliseconds()+1).Build(),
+	) {
+		if err := res.Error(); err != nil {
+			return fmt.Errorf("error recording ai token usage: %w", err)
+		}
+	}
+	return nil
+}
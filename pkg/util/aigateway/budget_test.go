@@ -0,0 +1,63 @@
+package aigateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisBudgets(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	budgets := NewRedisBudgets(rc)
+	b := Budget{Scope: "function", Limit: 100, Period: time.Hour}
+	now := time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)
+
+	require.NoError(t, budgets.Check(ctx, "fn", b, now))
+
+	require.NoError(t, budgets.Record(ctx, "fn", b, 60, now))
+	require.NoError(t, budgets.Check(ctx, "fn", b, now))
+
+	// Exceeding the limit is allowed when recording, as tokens have already been
+	// used.
+	require.NoError(t, budgets.Record(ctx, "fn", b, 60, now.Add(time.Minute)))
+
+	err = budgets.Check(ctx, "fn", b, now.Add(2*time.Minute))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrBudgetExceeded))
+
+	exceeded := BudgetExceededError{}
+	require.True(t, errors.As(err, &exceeded))
+	require.Equal(t, BudgetExceededError{
+		Scope:   "function",
+		Limit:   100,
+		Used:    120,
+		ResetAt: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+	}, exceeded)
+	require.Contains(t, err.Error(), "function token budget exceeded: used 120 of 100 tokens")
+
+	t.Run("other keys are unaffected", func(t *testing.T) {
+		require.NoError(t, budgets.Check(ctx, "other", b, now))
+	})
+
+	t.Run("budgets reset each period", func(t *testing.T) {
+		require.NoError(t, budgets.Check(ctx, "fn", b, now.Add(45*time.Minute)))
+	})
+
+	t.Run("usage expires after the period", func(t *testing.T) {
+		r.FastForward(time.Hour)
+		require.Empty(t, r.Keys())
+	})
+}
@@ -0,0 +1,86 @@
+package aigateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+// HeaderCache is set on responses served from the cache, with the value
+// CacheHit.
+const (
+	HeaderCache = "X-Inngest-AI-Cache"
+	CacheHit    = "hit"
+)
+
+// Cache stores successful inference responses.
+type Cache interface {
+	// Get returns the cached response for the key, if any.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set caches the response for the key for the given TTL.
+	Set(ctx context.Context, key string, response []byte, ttl time.Duration) error
+}
+
+// CacheKey returns a key identifying the request's format, URL and canonicalized
+// body.  Bodies which differ only in whitespace or object key order have the same
+// key.  Auth keys and headers are not part of the key.
+func CacheKey(req Request) (string, error) {
+	var body any
+	dec := json.NewDecoder(bytes.NewReader(req.Body))
+	// Numbers are kept verbatim to prevent rounding large values.
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return "", fmt.Errorf("error canonicalizing ai request body: %w", err)
+	}
+	// Objects are marshalled with sorted keys.
+	canonical, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("error canonicalizing ai request body: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(req.Format))
+	h.Write([]byte{0})
+	h.Write([]byte(req.URL))
+	h.Write([]byte{0})
+	h.Write(canonical)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// NewRedisCache returns a Cache which stores responses in Redis.
+func NewRedisCache(r rueidis.Client) Cache {
+	return redisCache{r: r}
+}
+
+type redisCache struct {
+	r rueidis.Client
+}
+
+func (c redisCache) key(key string) string {
+	return "{aigateway}:cache:" + key
+}
+
+func (c redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	byt, err := c.r.Do(ctx, c.r.B().Get().Key(c.key(key)).Build()).AsBytes()
+	if rueidis.IsRedisNil(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error loading cached ai response: %w", err)
+	}
+	return byt, true, nil
+}
+
+func (c redisCache) Set(ctx context.Context, key string, response []byte, ttl time.Duration) error {
+	cmd := c.r.B().Set().Key(c.key(key)).Value(rueidis.BinaryString(response)).Px(ttl).Build()
+	if err := c.r.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("error caching ai response: %w", err)
+	}
+	return nil
+}
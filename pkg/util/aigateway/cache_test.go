@@ -0,0 +1,113 @@
+package aigateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKey(t *testing.T) {
+	req := Request{
+		URL:    "https://api.openai.com/v1/chat/completions",
+		Format: FormatOpenAIChat,
+		Body:   []byte(`{"model":"gpt-4o","messages":[{"role":"user","content":"hi"}],"seed":12345678901234567890}`),
+	}
+
+	key, err := CacheKey(req)
+	require.NoError(t, err)
+	require.Len(t, key, 64)
+
+	t.Run("key order and whitespace are ignored", func(t *testing.T) {
+		other := req
+		other.Body = []byte(`{
+			"seed": 12345678901234567890,
+			"messages": [{"content": "hi", "role": "user"}],
+			"model": "gpt-4o"
+		}`)
+		otherKey, err := CacheKey(other)
+		require.NoError(t, err)
+		require.Equal(t, key, otherKey)
+	})
+
+	t.Run("auth is ignored", func(t *testing.T) {
+		other := req
+		other.AuthKey = "sk-other"
+		otherKey, err := CacheKey(other)
+		require.NoError(t, err)
+		require.Equal(t, key, otherKey)
+	})
+
+	t.Run("bodies differ", func(t *testing.T) {
+		other := req
+		other.Body = []byte(`{"model":"gpt-4o","messages":[{"role":"user","content":"hello"}],"seed":12345678901234567890}`)
+		otherKey, err := CacheKey(other)
+		require.NoError(t, err)
+		require.NotEqual(t, key, otherKey)
+	})
+
+	t.Run("large numbers are not rounded", func(t *testing.T) {
+		other := req
+		other.Body = []byte(`{"model":"gpt-4o","messages":[{"role":"user","content":"hi"}],"seed":12345678901234567891}`)
+		otherKey, err := CacheKey(other)
+		require.NoError(t, err)
+		require.NotEqual(t, key, otherKey)
+	})
+
+	t.Run("urls differ", func(t *testing.T) {
+		other := req
+		other.URL = "https://example.com/v1/chat/completions"
+		otherKey, err := CacheKey(other)
+		require.NoError(t, err)
+		require.NotEqual(t, key, otherKey)
+	})
+
+	t.Run("formats differ", func(t *testing.T) {
+		other := req
+		other.Format = FormatAnthropic
+		otherKey, err := CacheKey(other)
+		require.NoError(t, err)
+		require.NotEqual(t, key, otherKey)
+	})
+
+	t.Run("invalid bodies error", func(t *testing.T) {
+		other := req
+		other.Body = []byte(`{`)
+		_, err := CacheKey(other)
+		require.Error(t, err)
+	})
+}
+
+func TestRedisCache(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	c := NewRedisCache(rc)
+
+	_, ok, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	resp := []byte(`{"id":"chatcmpl-123"}`)
+	require.NoError(t, c.Set(ctx, "key", resp, time.Minute))
+
+	byt, ok, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, resp, byt)
+
+	r.FastForward(time.Minute)
+
+	_, ok, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,111 @@
+package aigateway
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Usage represents the tokens used by an inference request, as reported by the
+// provider.
+type Usage struct {
+	// Model is the model which served the request, if reported.
+	Model string `json:"model,omitempty"`
+	// InputTokens is the number of prompt tokens, including any tokens read from
+	// or written to the provider's prompt cache.
+	InputTokens int64 `json:"input_tokens"`
+	// OutputTokens is the number of generated tokens.
+	OutputTokens int64 `json:"output_tokens"`
+}
+
+// Total returns the total number of tokens used.
+func (u Usage) Total() int64 {
+	return u.InputTokens + u.OutputTokens
+}
+
+// ParseUsage parses token usage from an inference response in the given format.
+// Unknown formats are parsed as OpenAI compatible responses.
+func ParseUsage(format string, response []byte) (Usage, error) {
+	switch format {
+	case FormatAnthropic:
+		r := struct {
+			Model string `json:"model"`
+			Usage *struct {
+				InputTokens              int64 `json:"input_tokens"`
+				OutputTokens             int64 `json:"output_tokens"`
+				CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+				CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+			} `json:"usage"`
+		}{}
+		if err := json.Unmarshal(response, &r); err != nil {
+			return Usage{}, fmt.Errorf("error parsing anthropic usage: %w", err)
+		}
+		if r.Usage == nil {
+			return Usage{}, fmt.Errorf("no usage in anthropic response")
+		}
+		return Usage{
+			Model:        r.Model,
+			InputTokens:  r.Usage.InputTokens + r.Usage.CacheCreationInputTokens + r.Usage.CacheReadInputTokens,
+			OutputTokens: r.Usage.OutputTokens,
+		}, nil
+	case FormatGemini:
+		r := geminiResponse{}
+		if err := json.Unmarshal(response, &r); err != nil {
+			return Usage{}, fmt.Errorf("error parsing gemini usage: %w", err)
+		}
+		if r.UsageMetadata == nil {
+			return Usage{}, fmt.Errorf("no usage in gemini response")
+		}
+		return Usage{
+			Model:        r.ModelVersion,
+			InputTokens:  r.UsageMetadata.PromptTokenCount,
+			OutputTokens: r.UsageMetadata.CandidatesTokenCount + r.UsageMetadata.ThoughtsTokenCount,
+		}, nil
+	case FormatOpenAIChat:
+		fallthrough
+	default:
+		r := struct {
+			Model string `json:"model"`
+			Usage *struct {
+				PromptTokens     int64 `json:"prompt_tokens"`
+				CompletionTokens int64 `json:"completion_tokens"`
+			} `json:"usage"`
+		}{}
+		if err := json.Unmarshal(response, &r); err != nil {
+			return Usage{}, fmt.Errorf("error parsing openai usage: %w", err)
+		}
+		if r.Usage == nil {
+			return Usage{}, fmt.Errorf("no usage in openai response")
+		}
+		return Usage{
+			Model:        r.Model,
+			InputTokens:  r.Usage.PromptTokens,
+			OutputTokens: r.Usage.CompletionTokens,
+		}, nil
+	}
+}
+
+// geminiResponse represents a Gemini generateContent response.
+type geminiResponse struct {
+	ResponseID   string `json:"responseId"`
+	ModelVersion string `json:"modelVersion"`
+	Candidates   []struct {
+		FinishReason string `json:"finishReason"`
+		Content      struct {
+			Parts []struct {
+				FunctionCall *struct {
+					Name string          `json:"name"`
+					Args json.RawMessage `json:"args"`
+				} `json:"functionCall,omitempty"`
+			} `json:"parts"`
+		} `json:"content"`
+	} `json:"candidates"`
+	UsageMetadata *struct {
+		PromptTokenCount     int64 `json:"promptTokenCount"`
+		CandidatesTokenCount int64 `json:"candidatesTokenCount"`
+		ThoughtsTokenCount   int64 `json:"thoughtsTokenCount"`
+	} `json:"usageMetadata"`
+	Error *struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
@@ -0,0 +1,65 @@
+package aigateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		description string
+		format      string
+		input       string
+		expected    Usage
+		err         bool
+	}{
+		{
+			description: "openai",
+			format:      FormatOpenAIChat,
+			input:       `{"id":"chatcmpl-123","model":"gpt-4o-2024-08-06","choices":[],"usage":{"prompt_tokens":19,"completion_tokens":10,"total_tokens":29}}`,
+			expected:    Usage{Model: "gpt-4o-2024-08-06", InputTokens: 19, OutputTokens: 10},
+		},
+		{
+			description: "unknown formats are parsed as openai",
+			format:      "",
+			input:       `{"model":"llama-3","usage":{"prompt_tokens":5,"completion_tokens":7}}`,
+			expected:    Usage{Model: "llama-3", InputTokens: 5, OutputTokens: 7},
+		},
+		{
+			description: "anthropic includes prompt cache tokens",
+			format:      FormatAnthropic,
+			input:       `{"id":"msg_1","type":"message","model":"claude-3-5-haiku-20241022","usage":{"input_tokens":10,"cache_creation_input_tokens":100,"cache_read_input_tokens":1000,"output_tokens":20}}`,
+			expected:    Usage{Model: "claude-3-5-haiku-20241022", InputTokens: 1110, OutputTokens: 20},
+		},
+		{
+			description: "gemini includes thoughts tokens",
+			format:      FormatGemini,
+			input:       `{"candidates":[],"usageMetadata":{"promptTokenCount":50,"candidatesTokenCount":30,"thoughtsTokenCount":12},"modelVersion":"gemini-2.5-flash"}`,
+			expected:    Usage{Model: "gemini-2.5-flash", InputTokens: 50, OutputTokens: 42},
+		},
+		{
+			description: "missing usage",
+			format:      FormatOpenAIChat,
+			input:       `{"id":"chatcmpl-123"}`,
+			err:         true,
+		},
+		{
+			description: "invalid json",
+			format:      FormatAnthropic,
+			input:       `{`,
+			err:         true,
+		},
+	}
+
+	for _, test := range tests {
+		usage, err := ParseUsage(test.format, []byte(test.input))
+		if test.err {
+			require.Error(t, err, test.description)
+			continue
+		}
+		require.NoError(t, err, test.description)
+		require.Equal(t, test.expected, usage, test.description)
+		require.Equal(t, test.expected.InputTokens+test.expected.OutputTokens, usage.Total(), test.description)
+	}
+}
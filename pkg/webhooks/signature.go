@@ -0,0 +1,175 @@
+// Package webhooks verifies and transforms requests sent to webhook sources into
+// events.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+)
+
+const (
+	// DefaultSignatureHeader is the header containing the signature for the
+	// hmac-sha256 preset if no header is configured.
+	DefaultSignatureHeader = "X-Signature"
+
+	// SignatureTolerance is the maximum age of a timestamped signature, preventing
+	// replayed requests.
+	SignatureTolerance = 5 * time.Minute
+)
+
+var (
+	ErrMissingSignature = errors.New("missing signature")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpiredSignature = errors.New("signature timestamp is outside of the tolerance")
+)
+
+// Verify verifies the signature of a request sent to the webhook source.
+func Verify(s cqrs.WebhookSource, header http.Header, body []byte, now time.Time) error {
+	switch s.Signature {
+	case cqrs.WebhookSignatureNone:
+		return nil
+	case cqrs.WebhookSignatureHMACSHA256:
+		name := s.SignatureHeader
+		if name == "" {
+			name = DefaultSignatureHeader
+		}
+		return verifyHex(s.Secret, header.Get(name), body)
+	case cqrs.WebhookSignatureGitHub:
+		return verifyHex(s.Secret, header.Get("X-Hub-Signature-256"), body)
+	case cqrs.WebhookSignatureStripe:
+		return verifyStripe(s.Secret, header.Get("Stripe-Signature"), body, now)
+	case cqrs.WebhookSignatureStandard:
+		return verifyStandard(s.Secret, header, body, now)
+	default:
+		return fmt.Errorf("unknown signature preset: %s", s.Signature)
+	}
+}
+
+// verifyHex verifies a hex encoded HMAC-SHA256 of the body, optionally prefixed
+// with "sha256=".
+func verifyHex(secret, sig string, body []byte) error {
+	if sig == "" {
+		return ErrMissingSignature
+	}
+	actual, err := hex.DecodeString(strings.TrimPrefix(sig, "sha256="))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(actual, sign([]byte(secret), body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// verifyStripe verifies a header in the format "t=<unix>,v1=<hex>", where the
+// signature is of "<unix>.<body>".  The header may contain many v1 signatures
+// while secrets are rolled.
+func verifyStripe(secret, sig string, body []byte, now time.Time) error {
+	if sig == "" {
+		return ErrMissingSignature
+	}
+
+	var (
+		ts   string
+		sigs [][]byte
+	)
+	for _, part := range strings.Split(sig, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = val
+		case "v1":
+			if byt, err := hex.DecodeString(val); err == nil {
+				sigs = append(sigs, byt)
+			}
+		}
+	}
+	if ts == "" || len(sigs) == 0 {
+		return ErrInvalidSignature
+	}
+	if err := checkTimestamp(ts, now); err != nil {
+		return err
+	}
+
+	expected := sign([]byte(secret), []byte(ts+"."), body)
+	for _, actual := range sigs {
+		if hmac.Equal(actual, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// verifyStandard verifies the Standard Webhooks headers.  The signature is a space
+// separated list of "v1,<base64>" signatures of "<id>.<timestamp>.<body>", and
+// the secret is base64 encoded with an optional "whsec_" prefix.
+func verifyStandard(secret string, header http.Header, body []byte, now time.Time) error {
+	id := header.Get("Webhook-Id")
+	ts := header.Get("Webhook-Timestamp")
+	sig := header.Get("Webhook-Signature")
+	if id == "" || ts == "" || sig == "" {
+		return ErrMissingSignature
+	}
+	if err := checkTimestamp(ts, now); err != nil {
+		return err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return fmt.Errorf("invalid secret: %w", err)
+	}
+	expected := sign(key, []byte(id+"."+ts+"."), body)
+	for _, part := range strings.Fields(sig) {
+		version, val, _ := strings.Cut(part, ",")
+		if version != "v1" {
+			continue
+		}
+		actual, err := base64.StdEncoding.DecodeString(val)
+		if err == nil && hmac.Equal(actual, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// SignStandard returns the Standard Webhooks signature header value for an
+// outgoing request with the given ID, timestamp and body, as verified by the
+// standard preset.  The secret is base64 encoded with an optional "whsec_" prefix.
+func SignStandard(secret, id string, ts time.Time, body []byte) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return "", fmt.Errorf("invalid secret: %w", err)
+	}
+	sig := sign(key, []byte(fmt.Sprintf("%s.%d.", id, ts.Unix())), body)
+	return "v1," + base64.StdEncoding.EncodeToString(sig), nil
+}
+
+func checkTimestamp(ts string, now time.Time) error {
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	diff := now.Sub(time.Unix(unix, 0))
+	if diff > SignatureTolerance || diff < -SignatureTolerance {
+		return ErrExpiredSignature
+	}
+	return nil
+}
+
+func sign(key []byte, parts ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, p := range parts {
+		mac.Write(p)
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,134 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/stretchr/testify/require"
+)
+
+func hexSig(secret string, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+	ts := fmt.Sprintf("%d", now.Unix())
+
+	t.Run("none", func(t *testing.T) {
+		s := cqrs.WebhookSource{Signature: cqrs.WebhookSignatureNone}
+		require.NoError(t, Verify(s, http.Header{}, body, now))
+	})
+
+	t.Run("hmac-sha256", func(t *testing.T) {
+		s := cqrs.WebhookSource{Signature: cqrs.WebhookSignatureHMACSHA256, Secret: "secret"}
+
+		h := http.Header{}
+		require.ErrorIs(t, Verify(s, h, body, now), ErrMissingSignature)
+
+		h.Set(DefaultSignatureHeader, hexSig("secret", string(body)))
+		require.NoError(t, Verify(s, h, body, now))
+
+		h.Set(DefaultSignatureHeader, "sha256="+hexSig("secret", string(body)))
+		require.NoError(t, Verify(s, h, body, now))
+
+		h.Set(DefaultSignatureHeader, hexSig("wrong", string(body)))
+		require.ErrorIs(t, Verify(s, h, body, now), ErrInvalidSignature)
+
+		h.Set(DefaultSignatureHeader, "not-hex")
+		require.ErrorIs(t, Verify(s, h, body, now), ErrInvalidSignature)
+
+		// Custom headers
+		s.SignatureHeader = "X-Shopify-Hmac-Sha256"
+		h = http.Header{}
+		h.Set("X-Shopify-Hmac-Sha256", hexSig("secret", string(body)))
+		require.NoError(t, Verify(s, h, body, now))
+	})
+
+	t.Run("github", func(t *testing.T) {
+		s := cqrs.WebhookSource{Signature: cqrs.WebhookSignatureGitHub, Secret: "secret"}
+		h := http.Header{}
+		h.Set("X-Hub-Signature-256", "sha256="+hexSig("secret", string(body)))
+		require.NoError(t, Verify(s, h, body, now))
+		require.ErrorIs(t, Verify(s, h, []byte(`{}`), now), ErrInvalidSignature)
+	})
+
+	t.Run("stripe", func(t *testing.T) {
+		s := cqrs.WebhookSource{Signature: cqrs.WebhookSignatureStripe, Secret: "whsec_test"}
+		sig := hexSig("whsec_test", ts+"."+string(body))
+
+		h := http.Header{}
+		h.Set("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s", ts, sig))
+		require.NoError(t, Verify(s, h, body, now))
+
+		// Any matching v1 signature is accepted while secrets are rolled.
+		h.Set("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s,v1=%s", ts, hexSig("old", ts+"."+string(body)), sig))
+		require.NoError(t, Verify(s, h, body, now))
+
+		h.Set("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s", ts, hexSig("wrong", ts+"."+string(body))))
+		require.ErrorIs(t, Verify(s, h, body, now), ErrInvalidSignature)
+
+		h.Set("Stripe-Signature", "v1="+sig)
+		require.ErrorIs(t, Verify(s, h, body, now), ErrInvalidSignature)
+
+		h.Set("Stripe-Signature", fmt.Sprintf("t=%s,v1=%s", ts, sig))
+		require.ErrorIs(t, Verify(s, h, body, now.Add(SignatureTolerance+time.Minute)), ErrExpiredSignature)
+	})
+
+	t.Run("standard", func(t *testing.T) {
+		key := []byte("standard-webhooks-key")
+		s := cqrs.WebhookSource{
+			Signature: cqrs.WebhookSignatureStandard,
+			Secret:    "whsec_" + base64.StdEncoding.EncodeToString(key),
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte("msg_1." + ts + "." + string(body)))
+		sig := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+		h := http.Header{}
+		require.ErrorIs(t, Verify(s, h, body, now), ErrMissingSignature)
+
+		h.Set("Webhook-Id", "msg_1")
+		h.Set("Webhook-Timestamp", ts)
+		h.Set("Webhook-Signature", "v1,invalid v1,"+sig)
+		require.NoError(t, Verify(s, h, body, now))
+
+		h.Set("Webhook-Id", "msg_2")
+		require.ErrorIs(t, Verify(s, h, body, now), ErrInvalidSignature)
+
+		h.Set("Webhook-Id", "msg_1")
+		require.ErrorIs(t, Verify(s, h, body, now.Add(-SignatureTolerance-time.Minute)), ErrExpiredSignature)
+	})
+}
+
+func TestSignStandard(t *testing.T) {
+	body := []byte(`{"id":"evt_1"}`)
+	now := time.Now()
+	s := cqrs.WebhookSource{
+		Signature: cqrs.WebhookSignatureStandard,
+		Secret:    "whsec_" + base64.StdEncoding.EncodeToString([]byte("standard-webhooks-key")),
+	}
+
+	sig, err := SignStandard(s.Secret, "msg_1", now, body)
+	require.NoError(t, err)
+
+	h := http.Header{}
+	h.Set("Webhook-Id", "msg_1")
+	h.Set("Webhook-Timestamp", fmt.Sprintf("%d", now.Unix()))
+	h.Set("Webhook-Signature", sig)
+	require.NoError(t, Verify(s, h, body, now))
+
+	_, err = SignStandard("whsec_not base64", "msg_1", now, body)
+	require.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/khulnasoft/inngest/pkg/consts"
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/khulnasoft/inngest/pkg/event"
+	"github.com/khulnasoft/inngest/pkg/expressions"
+)
+
+var (
+	nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+	pathRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]+(/[a-zA-Z0-9_.-]+)*$`)
+)
+
+// DefaultTransform returns the transform used for sources created without one,
+// which sends the request's body as the data of a single event.
+func DefaultTransform(name string) string {
+	return fmt.Sprintf(`{"name": "webhook/%s", "data": body}`, name)
+}
+
+// Check validates a webhook source before it's saved, setting its defaults.
+func Check(ctx context.Context, s *cqrs.WebhookSource) error {
+	if !nameRegexp.MatchString(s.Name) {
+		return fmt.Errorf("invalid name %q: names may only contain letters, numbers, '_', '.' and '-'", s.Name)
+	}
+
+	s.Path = strings.Trim(s.Path, "/")
+	if s.Path == "" {
+		s.Path = s.Name
+	}
+	if !pathRegexp.MatchString(s.Path) {
+		return fmt.Errorf("invalid path %q: paths may only contain letters, numbers, '_', '.', '-' and '/'", s.Path)
+	}
+
+	if s.Signature == "" {
+		s.Signature = cqrs.WebhookSignatureNone
+		if s.Secret != "" {
+			s.Signature = cqrs.WebhookSignatureHMACSHA256
+		}
+	}
+	if !s.Signature.Valid() {
+		return fmt.Errorf("invalid signature %q: must be one of none, hmac-sha256, github, stripe or standard", s.Signature)
+	}
+	if s.Signature != cqrs.WebhookSignatureNone && s.Secret == "" {
+		return fmt.Errorf("a secret is required to verify %s signatures", s.Signature)
+	}
+	if s.Signature == cqrs.WebhookSignatureHMACSHA256 && s.SignatureHeader == "" {
+		s.SignatureHeader = DefaultSignatureHeader
+	}
+
+	if s.Transform == "" {
+		s.Transform = DefaultTransform(s.Name)
+	}
+	if _, err := expressions.NewValueEvaluator(ctx, s.Transform); err != nil {
+		return fmt.Errorf("invalid transform: %w", err)
+	}
+	return nil
+}
+
+// Request is a request sent to a webhook source.
+type Request struct {
+	Method string
+	Header http.Header
+	Query  url.Values
+	Body   []byte
+}
+
+// Input returns the variables available to transforms:
+//
+//   - body: the parsed JSON or form body, or the raw body as a string
+//   - headers: a map of lowercase header names to their first value
+//   - query: a map of query parameters to their first value
+//   - method: the request's HTTP method
+func (r Request) Input() map[string]any {
+	headers := make(map[string]any, len(r.Header))
+	for k, v := range r.Header {
+		headers[strings.ToLower(k)] = v[0]
+	}
+	query := make(map[string]any, len(r.Query))
+	for k, v := range r.Query {
+		query[k] = v[0]
+	}
+	return map[string]any{
+		"body":    r.body(),
+		"headers": headers,
+		"query":   query,
+		"method":  r.Method,
+	}
+}
+
+func (r Request) body() any {
+	if len(r.Body) == 0 {
+		return map[string]any{}
+	}
+
+	// Some senders use a form content type for JSON bodies, so JSON is always
+	// attempted first.
+	var body any
+	if err := json.Unmarshal(r.Body, &body); err == nil {
+		return body
+	}
+
+	ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ct == "application/x-www-form-urlencoded" {
+		if values, err := url.ParseQuery(string(r.Body)); err == nil {
+			form := make(map[string]any, len(values))
+			for k, v := range values {
+				form[k] = v[0]
+			}
+			return form
+		}
+	}
+	return string(r.Body)
+}
+
+// Transform evaluates a source's transform with the request, returning the events
+// produced.  Transforms return a single event, or a list of events which may be
+// empty to ignore the request.
+func Transform(ctx context.Context, transform string, r Request) ([]event.Event, error) {
+	result, err := expressions.EvaluateValue(ctx, transform, r.Input())
+	if err != nil {
+		return nil, err
+	}
+
+	var items []any
+	switch val := result.(type) {
+	case nil:
+	case []any:
+		items = val
+	case map[string]any:
+		items = []any{val}
+	default:
+		return nil, fmt.Errorf("transform must return an event or a list of events, got %T", result)
+	}
+	if len(items) > consts.MaxEvents {
+		return nil, fmt.Errorf("transform returned %d events, exceeding the limit of %d", len(items), consts.MaxEvents)
+	}
+
+	evts := make([]event.Event, len(items))
+	for i, item := range items {
+		if _, ok := item.(map[string]any); !ok {
+			return nil, fmt.Errorf("event %d: must be an object, got %T", i, item)
+		}
+		byt, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		if err := json.Unmarshal(byt, &evts[i]); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		if evts[i].Name == "" {
+			return nil, fmt.Errorf("event %d: event name is empty", i)
+		}
+	}
+	return evts, nil
+}
@@ -0,0 +1,120 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/khulnasoft/inngest/pkg/cqrs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults", func(t *testing.T) {
+		s := cqrs.WebhookSource{Name: "stripe", Secret: "secret"}
+		require.NoError(t, Check(ctx, &s))
+		require.Equal(t, "stripe", s.Path)
+		require.Equal(t, cqrs.WebhookSignatureHMACSHA256, s.Signature)
+		require.Equal(t, DefaultSignatureHeader, s.SignatureHeader)
+		require.Equal(t, DefaultTransform("stripe"), s.Transform)
+
+		s = cqrs.WebhookSource{Name: "open", Path: "/hooks/open/"}
+		require.NoError(t, Check(ctx, &s))
+		require.Equal(t, "hooks/open", s.Path)
+		require.Equal(t, cqrs.WebhookSignatureNone, s.Signature)
+	})
+
+	t.Run("invalid sources", func(t *testing.T) {
+		for _, s := range []cqrs.WebhookSource{
+			{Name: ""},
+			{Name: "with space"},
+			{Name: "stripe", Path: "a//b"},
+			{Name: "stripe", Signature: "md5", Secret: "secret"},
+			{Name: "stripe", Signature: cqrs.WebhookSignatureStripe},
+			{Name: "stripe", Transform: `{"name": `},
+		} {
+			require.Error(t, Check(ctx, &s), s)
+		}
+	})
+}
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default transform", func(t *testing.T) {
+		evts, err := Transform(ctx, DefaultTransform("stripe"), Request{
+			Method: http.MethodPost,
+			Header: http.Header{"Content-Type": []string{"application/json"}},
+			Body:   []byte(`{"id":"evt_1","amount":10}`),
+		})
+		require.NoError(t, err)
+		require.Len(t, evts, 1)
+		require.Equal(t, "webhook/stripe", evts[0].Name)
+		require.Equal(t, map[string]any{"id": "evt_1", "amount": float64(10)}, evts[0].Data)
+	})
+
+	t.Run("headers, query and ids", func(t *testing.T) {
+		evts, err := Transform(ctx, `{
+			"name": "github/" + headers["x-github-event"],
+			"id": headers["x-github-delivery"],
+			"data": {"repo": body.repository.name, "env": query.env, "method": method},
+		}`, Request{
+			Method: http.MethodPost,
+			Header: http.Header{
+				"X-Github-Event":    []string{"push"},
+				"X-Github-Delivery": []string{"delivery-1"},
+			},
+			Query: url.Values{"env": []string{"prod"}},
+			Body:  []byte(`{"repository":{"name":"inngest"}}`),
+		})
+		require.NoError(t, err)
+		require.Len(t, evts, 1)
+		require.Equal(t, "github/push", evts[0].Name)
+		require.Equal(t, "delivery-1", evts[0].ID)
+		require.Equal(t, map[string]any{"repo": "inngest", "env": "prod", "method": "POST"}, evts[0].Data)
+	})
+
+	t.Run("lists fan out into many events", func(t *testing.T) {
+		evts, err := Transform(ctx,
+			`body.items.map(i, {"name": "shop/item.purchased", "data": {"sku": i.sku}})`,
+			Request{Body: []byte(`{"items":[{"sku":"a"},{"sku":"b"}]}`)},
+		)
+		require.NoError(t, err)
+		require.Len(t, evts, 2)
+		require.Equal(t, "a", evts[0].Data["sku"])
+		require.Equal(t, "b", evts[1].Data["sku"])
+	})
+
+	t.Run("empty lists ignore the request", func(t *testing.T) {
+		evts, err := Transform(ctx,
+			`body.type == "ping" ? [] : [{"name": "hook/" + body.type}]`,
+			Request{Body: []byte(`{"type":"ping"}`)},
+		)
+		require.NoError(t, err)
+		require.Empty(t, evts)
+	})
+
+	t.Run("form bodies", func(t *testing.T) {
+		evts, err := Transform(ctx, `{"name": "twilio/sms", "data": {"from": body.From}}`, Request{
+			Header: http.Header{"Content-Type": []string{"application/x-www-form-urlencoded"}},
+			Body:   []byte(`From=%2B15551234567&Body=hi`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "+15551234567", evts[0].Data["from"])
+	})
+
+	t.Run("invalid results", func(t *testing.T) {
+		for _, expr := range []string{
+			`"not an event"`,
+			`[1, 2]`,
+			`{"data": body}`,
+			`body.missing.field`,
+		} {
+			_, err := Transform(ctx, expr, Request{Body: []byte(`{}`)})
+			require.Error(t, err, expr)
+		}
+	})
+}